@@ -0,0 +1,46 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+
+	"github.com/adystag/jobs-search/internal"
+	"github.com/adystag/jobs-search/internal/provider"
+)
+
+func main() {
+	username := flag.String("username", "", "username of the user to grant the role to")
+	role := flag.String("role", string(internal.RoleAdmin), "role to grant")
+
+	flag.Parse()
+
+	if len(*username) == 0 {
+		log.Fatalln("username is required")
+	}
+
+	module, err := internal.NewModule(
+		provider.Configuration{},
+		provider.DB{},
+		provider.Service{},
+	)
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	user, err := module.UserRoleGranter.GrantUserRole(context.Background(), internal.UserRoleGrantRequest{
+		Username: *username,
+		Role:     internal.Role(*role),
+	})
+	if err != nil {
+		module.Close()
+		log.Fatalln(err)
+	}
+
+	log.Printf("granted role %s to user %d\n", *role, user.ID)
+
+	err = module.Close()
+	if err != nil {
+		log.Fatalln(err)
+	}
+}
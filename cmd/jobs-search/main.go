@@ -1,11 +1,13 @@
 package main
 
 import (
+	"context"
 	"log"
 
 	"github.com/adystag/jobs-search/internal"
 	"github.com/adystag/jobs-search/internal/http"
 	"github.com/adystag/jobs-search/internal/provider"
+	"github.com/adystag/jobs-search/internal/worker"
 )
 
 func main() {
@@ -18,7 +20,28 @@ func main() {
 		log.Fatalln(err)
 	}
 
-	err = http.NewServer(module).Run()
+	if module.JobsSynchronizer != nil {
+		jobsSynchronizationWorker := worker.NewJobsSynchronizationWorker(
+			module.JobsSynchronizer,
+			module.Configuration.Job.SyncInterval,
+		)
+
+		go jobsSynchronizationWorker.Run(context.Background())
+	}
+
+	server, err := http.NewServer(module)
+	if err != nil {
+		module.Close()
+		log.Fatalln(err)
+	}
+
+	err = server.Run()
+	if err != nil {
+		module.Close()
+		log.Fatalln(err)
+	}
+
+	err = module.Close()
 	if err != nil {
 		log.Fatalln(err)
 	}
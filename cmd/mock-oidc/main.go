@@ -0,0 +1,250 @@
+package main
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"flag"
+	"log"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	gourl "net/url"
+
+	"github.com/dgrijalva/jwt-go"
+)
+
+const keyID = "mock"
+
+type authorization struct {
+	clientID      string
+	redirectURI   string
+	nonce         string
+	codeChallenge string
+	expiresAt     time.Time
+}
+
+type server struct {
+	mu             sync.Mutex
+	issuer         string
+	subject        string
+	email          string
+	emailVerified  bool
+	name           string
+	username       string
+	privateKey     *rsa.PrivateKey
+	authorizations map[string]authorization
+}
+
+func (s *server) discovery(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"issuer":                                s.issuer,
+		"authorization_endpoint":                s.issuer + "/authorize",
+		"token_endpoint":                        s.issuer + "/token",
+		"jwks_uri":                              s.issuer + "/jwks",
+		"response_types_supported":              []string{"code"},
+		"subject_types_supported":               []string{"public"},
+		"id_token_signing_alg_values_supported": []string{"RS256"},
+		"code_challenge_methods_supported":      []string{"S256"},
+	})
+}
+
+func (s *server) jwks(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"keys": []map[string]string{
+			{
+				"kty": "RSA",
+				"kid": keyID,
+				"use": "sig",
+				"alg": "RS256",
+				"n":   jwt.EncodeSegment(s.privateKey.N.Bytes()),
+				"e":   jwt.EncodeSegment(big.NewInt(int64(s.privateKey.E)).Bytes()),
+			},
+		},
+	})
+}
+
+func (s *server) authorize(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	redirectURI, err := gourl.Parse(query.Get("redirect_uri"))
+	if err != nil || len(redirectURI.String()) == 0 {
+		http.Error(w, "invalid redirect_uri", http.StatusBadRequest)
+
+		return
+	}
+
+	if query.Get("response_type") != "code" || query.Get("code_challenge_method") != "S256" {
+		http.Error(w, "unsupported response_type or code_challenge_method", http.StatusBadRequest)
+
+		return
+	}
+
+	code, err := randomString()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+
+		return
+	}
+
+	s.mu.Lock()
+	s.authorizations[code] = authorization{
+		clientID:      query.Get("client_id"),
+		redirectURI:   redirectURI.String(),
+		nonce:         query.Get("nonce"),
+		codeChallenge: query.Get("code_challenge"),
+		expiresAt:     time.Now().Add(time.Minute),
+	}
+	s.mu.Unlock()
+
+	values := redirectURI.Query()
+
+	values.Set("code", code)
+	values.Set("state", query.Get("state"))
+
+	redirectURI.RawQuery = values.Encode()
+
+	http.Redirect(w, r, redirectURI.String(), http.StatusFound)
+}
+
+func (s *server) token(w http.ResponseWriter, r *http.Request) {
+	err := r.ParseForm()
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_request"})
+
+		return
+	}
+
+	code := r.PostForm.Get("code")
+
+	s.mu.Lock()
+	auth, ok := s.authorizations[code]
+	delete(s.authorizations, code)
+	s.mu.Unlock()
+
+	sum := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+
+	switch {
+	case r.PostForm.Get("grant_type") != "authorization_code":
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "unsupported_grant_type"})
+
+		return
+	case !ok || time.Now().After(auth.expiresAt):
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+
+		return
+	case auth.clientID != r.PostForm.Get("client_id") || auth.redirectURI != r.PostForm.Get("redirect_uri"):
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+
+		return
+	case auth.codeChallenge != base64.RawURLEncoding.EncodeToString(sum[:]):
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+
+		return
+	}
+
+	now := time.Now()
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"iss":                s.issuer,
+		"sub":                s.subject,
+		"aud":                auth.clientID,
+		"iat":                now.Unix(),
+		"exp":                now.Add(5 * time.Minute).Unix(),
+		"nonce":              auth.nonce,
+		"email":              s.email,
+		"email_verified":     s.emailVerified,
+		"name":               s.name,
+		"preferred_username": s.username,
+	})
+	token.Header["kid"] = keyID
+
+	idToken, err := token.SignedString(s.privateKey)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "server_error"})
+
+		return
+	}
+
+	accessToken, err := randomString()
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "server_error"})
+
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"access_token": accessToken,
+		"token_type":   "Bearer",
+		"expires_in":   300,
+		"id_token":     idToken,
+	})
+}
+
+func (s *server) handler() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/.well-known/openid-configuration", s.discovery)
+	mux.HandleFunc("/jwks", s.jwks)
+	mux.HandleFunc("/authorize", s.authorize)
+	mux.HandleFunc("/token", s.token)
+
+	return mux
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+
+	json.NewEncoder(w).Encode(v)
+}
+
+func randomString() (string, error) {
+	b := make([]byte, 32)
+
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func main() {
+	addr := flag.String("addr", "localhost:9090", "listen address")
+	issuer := flag.String("issuer", "http://localhost:9090", "issuer url")
+	subject := flag.String("sub", "mock-user", "subject of the issued id tokens")
+	email := flag.String("email", "mock-user@example.com", "email of the issued id tokens")
+	emailVerified := flag.Bool("email-verified", true, "email_verified of the issued id tokens")
+	name := flag.String("name", "Mock User", "name of the issued id tokens")
+	username := flag.String("username", "mockuser", "preferred_username of the issued id tokens")
+
+	flag.Parse()
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	s := &server{
+		issuer:         strings.TrimSuffix(*issuer, "/"),
+		subject:        *subject,
+		email:          *email,
+		emailVerified:  *emailVerified,
+		name:           *name,
+		username:       *username,
+		privateKey:     privateKey,
+		authorizations: map[string]authorization{},
+	}
+
+	log.Printf("mock oidc provider listening on %s with issuer %s\n", *addr, s.issuer)
+
+	err = http.ListenAndServe(*addr, s.handler())
+	if err != nil {
+		log.Fatalln(err)
+	}
+}
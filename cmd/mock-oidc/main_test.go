@@ -0,0 +1,139 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	gourl "net/url"
+
+	"github.com/adystag/jobs-search/internal"
+	repohttp "github.com/adystag/jobs-search/internal/repository/http"
+)
+
+type fixedTimer struct {
+	now time.Time
+}
+
+func (t fixedTimer) Now() time.Time {
+	return t.now
+}
+
+func TestOIDCProviderPKCEAndState(t *testing.T) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generating rsa key: %s", err)
+	}
+
+	s := &server{
+		subject:        "mock-user",
+		email:          "mock-user@example.com",
+		emailVerified:  true,
+		name:           "Mock User",
+		username:       "mockuser",
+		privateKey:     privateKey,
+		authorizations: map[string]authorization{},
+	}
+	ts := httptest.NewServer(s.handler())
+
+	defer ts.Close()
+
+	s.issuer = ts.URL
+
+	tests := []struct {
+		name     string
+		verifier string
+		nonce    string
+		wantErr  error
+	}{
+		{
+			name:     "matching verifier",
+			verifier: "code-verifier",
+			nonce:    "nonce",
+		},
+		{
+			name:     "mismatched verifier",
+			verifier: "another-code-verifier",
+			nonce:    "nonce",
+			wantErr:  internal.ErrOIDCAuthenticationFailed,
+		},
+		{
+			name:     "mismatched nonce",
+			verifier: "code-verifier",
+			nonce:    "another-nonce",
+			wantErr:  internal.ErrOIDCAuthenticationFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			provider := repohttp.NewOIDCProvider(
+				ts.Client(),
+				fixedTimer{now: time.Now()},
+				ts.URL,
+				"jobs-search",
+				"",
+				"http://localhost:8080/callback",
+				[]string{"openid", "email", "profile"},
+				time.Minute,
+			)
+
+			authorizationURL, err := provider.AuthorizationURL(ctx, internal.OIDCAuthorizationURLRequest{
+				State:         "state",
+				Nonce:         "nonce",
+				CodeChallenge: internal.PKCECodeChallenge("code-verifier"),
+			})
+			if err != nil {
+				t.Fatalf("AuthorizationURL() error = %v", err)
+			}
+
+			client := ts.Client()
+			client.CheckRedirect = func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			}
+
+			res, err := client.Get(authorizationURL)
+			if err != nil {
+				t.Fatalf("requesting authorization url: %s", err)
+			}
+
+			res.Body.Close()
+
+			location, err := gourl.Parse(res.Header.Get("Location"))
+			if err != nil {
+				t.Fatalf("parsing authorization redirect: %s", err)
+			}
+
+			if state := location.Query().Get("state"); state != "state" {
+				t.Fatalf("authorization redirect state = %q, want %q", state, "state")
+			}
+
+			identity, err := provider.Exchange(ctx, internal.OIDCExchangeRequest{
+				Code:         location.Query().Get("code"),
+				CodeVerifier: tt.verifier,
+				Nonce:        tt.nonce,
+			})
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Exchange() error = %v, want %v", err, tt.wantErr)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Exchange() error = %v", err)
+			}
+
+			if identity.Subject != s.subject || identity.Email != s.email || !identity.EmailVerified {
+				t.Errorf("Exchange() identity = %+v, want subject %q and verified email %q", identity, s.subject, s.email)
+			}
+		})
+	}
+}
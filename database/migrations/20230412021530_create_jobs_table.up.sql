@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS `jobs` (
+    `id` CHAR(36) NOT NULL,
+    `company` VARCHAR(255) NOT NULL,
+    `company_url` VARCHAR(2048) NOT NULL,
+    `company_logo` VARCHAR(2048) NOT NULL,
+    `url` VARCHAR(2048) NOT NULL,
+    `type` VARCHAR(255) NOT NULL,
+    `location` VARCHAR(255) NOT NULL,
+    `title` VARCHAR(255) NOT NULL,
+    `description` MEDIUMTEXT NOT NULL,
+    `how_to_apply` TEXT NOT NULL,
+    `created_at` VARCHAR(255) NOT NULL,
+    `posted_at` DATETIME NULL,
+    `synced_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    INDEX `posted_at_id_idx` (`posted_at`, `id`)
+);
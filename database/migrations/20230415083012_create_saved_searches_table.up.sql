@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS `saved_searches` (
+    `id` SERIAL,
+    `user_id` BIGINT UNSIGNED NOT NULL,
+    `name` VARCHAR(255) NOT NULL,
+    `description` VARCHAR(255) NOT NULL DEFAULT '',
+    `location` VARCHAR(255) NOT NULL DEFAULT '',
+    `full_time` BOOLEAN NOT NULL DEFAULT FALSE,
+    `last_run_at` TIMESTAMP NULL,
+    `created_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    INDEX `user_id_idx` (`user_id`),
+    CONSTRAINT `saved_searches_user_id_fk` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON DELETE CASCADE
+);
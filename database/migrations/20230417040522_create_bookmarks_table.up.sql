@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS `bookmarks` (
+    `id` SERIAL,
+    `user_id` BIGINT UNSIGNED NOT NULL,
+    `job_id` CHAR(36) NOT NULL,
+    `note` TEXT NOT NULL,
+    `tags` JSON NOT NULL,
+    `snapshot` JSON NOT NULL,
+    `created_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    CONSTRAINT `bookmarks_user_id_job_id_uidx` UNIQUE (`user_id`, `job_id`),
+    CONSTRAINT `bookmarks_user_id_fk` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON DELETE CASCADE
+);
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS `applications`;
\ No newline at end of file
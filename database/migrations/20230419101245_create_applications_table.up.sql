@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS `applications` (
+    `id` SERIAL,
+    `user_id` BIGINT UNSIGNED NOT NULL,
+    `job_id` CHAR(36) NOT NULL,
+    `status` VARCHAR(32) NOT NULL,
+    `notes` TEXT NOT NULL,
+    `created_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    CONSTRAINT `applications_user_id_job_id_uidx` UNIQUE (`user_id`, `job_id`),
+    CONSTRAINT `applications_user_id_fk` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON DELETE CASCADE
+);
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS `application_status_changes`;
\ No newline at end of file
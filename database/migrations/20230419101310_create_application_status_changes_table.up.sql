@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS `application_status_changes` (
+    `id` SERIAL,
+    `application_id` BIGINT UNSIGNED NOT NULL,
+    `from_status` VARCHAR(32) NOT NULL DEFAULT '',
+    `to_status` VARCHAR(32) NOT NULL,
+    `note` TEXT NOT NULL,
+    `changed_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    INDEX `application_id_idx` (`application_id`),
+    CONSTRAINT `application_status_changes_application_id_fk` FOREIGN KEY (`application_id`) REFERENCES `applications` (`id`) ON DELETE CASCADE
+);
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS `refresh_tokens` (
+    `id` SERIAL,
+    `user_id` BIGINT UNSIGNED NOT NULL,
+    `family_id` CHAR(36) NOT NULL,
+    `token_hash` CHAR(64) NOT NULL,
+    `expires_at` TIMESTAMP NOT NULL,
+    `used_at` TIMESTAMP NULL,
+    `revoked_at` TIMESTAMP NULL,
+    `created_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    CONSTRAINT `token_hash_uidx` UNIQUE (`token_hash`),
+    INDEX `family_id_idx` (`family_id`),
+    CONSTRAINT `refresh_tokens_user_id_fk` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON DELETE CASCADE
+);
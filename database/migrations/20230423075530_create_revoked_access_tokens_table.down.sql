@@ -0,0 +1 @@
+DROP TABLE IF EXISTS `revoked_access_tokens`;
\ No newline at end of file
@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS `revoked_access_tokens` (
+    `jti` CHAR(36) NOT NULL,
+    `user_id` BIGINT UNSIGNED NOT NULL,
+    `expires_at` TIMESTAMP NOT NULL,
+    `revoked_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`jti`),
+    INDEX `expires_at_idx` (`expires_at`)
+);
@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS `login_throttles` (
+    `scope` VARCHAR(16) NOT NULL,
+    `throttle_key` VARCHAR(255) NOT NULL,
+    `failures` INT UNSIGNED NOT NULL DEFAULT 0,
+    `last_failed_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `locked_until` TIMESTAMP NULL,
+    PRIMARY KEY (`scope`, `throttle_key`)
+);
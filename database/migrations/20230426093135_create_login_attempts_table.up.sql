@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS `login_attempts` (
+    `id` SERIAL,
+    `username` VARCHAR(255) NOT NULL,
+    `ip` VARCHAR(45) NOT NULL,
+    `succeeded` BOOLEAN NOT NULL,
+    `reason` VARCHAR(32) NOT NULL DEFAULT '',
+    `attempted_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    INDEX `username_attempted_at_idx` (`username`, `attempted_at`),
+    INDEX `ip_attempted_at_idx` (`ip`, `attempted_at`)
+);
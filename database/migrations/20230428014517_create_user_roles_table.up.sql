@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS `user_roles` (
+    `user_id` BIGINT UNSIGNED NOT NULL,
+    `role` VARCHAR(32) NOT NULL,
+    `created_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`user_id`, `role`),
+    CONSTRAINT `user_roles_user_id_fk` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON DELETE CASCADE
+);
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS `api_keys` (
+    `id` SERIAL,
+    `user_id` BIGINT UNSIGNED NOT NULL,
+    `name` VARCHAR(100) NOT NULL,
+    `prefix` VARCHAR(16) NOT NULL,
+    `key_hash` CHAR(64) NOT NULL,
+    `scopes` JSON NOT NULL,
+    `expires_at` TIMESTAMP NULL,
+    `last_used_at` TIMESTAMP NULL,
+    `revoked_at` TIMESTAMP NULL,
+    `created_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    CONSTRAINT `prefix_uidx` UNIQUE (`prefix`),
+    INDEX `user_id_idx` (`user_id`),
+    CONSTRAINT `api_keys_user_id_fk` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON DELETE CASCADE
+);
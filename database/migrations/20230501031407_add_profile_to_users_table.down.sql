@@ -0,0 +1,6 @@
+ALTER TABLE `users`
+    DROP COLUMN `timezone`,
+    DROP COLUMN `preferred_job_type`,
+    DROP COLUMN `preferred_locations`,
+    DROP COLUMN `display_name`,
+    DROP COLUMN `email`;
\ No newline at end of file
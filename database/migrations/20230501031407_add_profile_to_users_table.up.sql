@@ -0,0 +1,6 @@
+ALTER TABLE `users`
+    ADD COLUMN `email` VARCHAR(255) NULL AFTER `password`,
+    ADD COLUMN `display_name` VARCHAR(100) NULL AFTER `email`,
+    ADD COLUMN `preferred_locations` JSON NULL AFTER `display_name`,
+    ADD COLUMN `preferred_job_type` VARCHAR(50) NULL AFTER `preferred_locations`,
+    ADD COLUMN `timezone` VARCHAR(64) NULL AFTER `preferred_job_type`;
@@ -0,0 +1 @@
+ALTER TABLE `users` DROP INDEX `email_uidx`;
\ No newline at end of file
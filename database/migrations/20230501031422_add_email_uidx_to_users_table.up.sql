@@ -0,0 +1 @@
+ALTER TABLE `users` ADD CONSTRAINT `email_uidx` UNIQUE (`email`);
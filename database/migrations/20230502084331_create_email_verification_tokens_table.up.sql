@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS `email_verification_tokens` (
+    `id` SERIAL,
+    `user_id` BIGINT UNSIGNED NOT NULL,
+    `email` VARCHAR(255) NOT NULL,
+    `token_hash` CHAR(64) NOT NULL,
+    `expires_at` TIMESTAMP NOT NULL,
+    `used_at` TIMESTAMP NULL,
+    `created_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    CONSTRAINT `token_hash_uidx` UNIQUE (`token_hash`),
+    CONSTRAINT `email_verification_tokens_user_id_fk` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON DELETE CASCADE
+);
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS `oidc_authorizations`;
\ No newline at end of file
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS `oidc_authorizations` (
+    `id` SERIAL,
+    `provider` VARCHAR(50) NOT NULL,
+    `state_hash` CHAR(64) NOT NULL,
+    `nonce` VARCHAR(255) NOT NULL,
+    `code_verifier` VARCHAR(255) NOT NULL,
+    `expires_at` TIMESTAMP NOT NULL,
+    `used_at` TIMESTAMP NULL,
+    `created_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    CONSTRAINT `state_hash_uidx` UNIQUE (`state_hash`)
+);
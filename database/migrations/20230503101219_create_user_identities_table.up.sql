@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS `user_identities` (
+    `id` SERIAL,
+    `user_id` BIGINT UNSIGNED NOT NULL,
+    `provider` VARCHAR(50) NOT NULL,
+    `subject` VARCHAR(255) NOT NULL,
+    `email` VARCHAR(255) NULL,
+    `last_login_at` TIMESTAMP NULL,
+    `created_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    CONSTRAINT `provider_subject_uidx` UNIQUE (`provider`, `subject`),
+    CONSTRAINT `user_identities_user_id_fk` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON DELETE CASCADE
+);
@@ -0,0 +1 @@
+ALTER TABLE `users` DROP COLUMN `two_factor_enabled_at`;
\ No newline at end of file
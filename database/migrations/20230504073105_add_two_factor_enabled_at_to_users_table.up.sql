@@ -0,0 +1 @@
+ALTER TABLE `users` ADD COLUMN `two_factor_enabled_at` TIMESTAMP NULL AFTER `timezone`;
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS `user_totp_secrets` (
+    `user_id` BIGINT UNSIGNED NOT NULL,
+    `secret` VARCHAR(255) NOT NULL,
+    `last_used_step` BIGINT NULL,
+    `created_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`user_id`),
+    CONSTRAINT `user_totp_secrets_user_id_fk` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON DELETE CASCADE
+);
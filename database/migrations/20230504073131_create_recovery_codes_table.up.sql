@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS `recovery_codes` (
+    `id` SERIAL,
+    `user_id` BIGINT UNSIGNED NOT NULL,
+    `code_hash` CHAR(64) NOT NULL,
+    `used_at` TIMESTAMP NULL,
+    `created_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    CONSTRAINT `user_id_code_hash_uidx` UNIQUE (`user_id`, `code_hash`),
+    CONSTRAINT `recovery_codes_user_id_fk` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON DELETE CASCADE
+);
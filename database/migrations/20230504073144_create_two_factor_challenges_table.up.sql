@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS `two_factor_challenges` (
+    `id` SERIAL,
+    `user_id` BIGINT UNSIGNED NOT NULL,
+    `token_hash` CHAR(64) NOT NULL,
+    `attempts` INT UNSIGNED NOT NULL DEFAULT 0,
+    `expires_at` TIMESTAMP NOT NULL,
+    `used_at` TIMESTAMP NULL,
+    `created_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    CONSTRAINT `token_hash_uidx` UNIQUE (`token_hash`),
+    CONSTRAINT `two_factor_challenges_user_id_fk` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON DELETE CASCADE
+);
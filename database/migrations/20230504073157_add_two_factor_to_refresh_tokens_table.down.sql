@@ -0,0 +1 @@
+ALTER TABLE `refresh_tokens` DROP COLUMN `two_factor`;
\ No newline at end of file
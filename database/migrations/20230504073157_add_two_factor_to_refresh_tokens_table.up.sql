@@ -0,0 +1 @@
+ALTER TABLE `refresh_tokens` ADD COLUMN `two_factor` BOOLEAN NOT NULL DEFAULT FALSE AFTER `family_id`;
@@ -0,0 +1 @@
+UPDATE `bookmarks` SET `snapshot` = JSON_OBJECT('ID', `snapshot`->>'$.id', 'Company', `snapshot`->>'$.company', 'CompanyURL', `snapshot`->>'$.company_url', 'CompanyLogo', `snapshot`->>'$.company_logo', 'URL', `snapshot`->>'$.url', 'Type', `snapshot`->>'$.type', 'Location', `snapshot`->>'$.location', 'Title', `snapshot`->>'$.title', 'Description', `snapshot`->>'$.description', 'HowToApply', `snapshot`->>'$.how_to_apply', 'CreatedAt', `snapshot`->>'$.created_at') WHERE JSON_CONTAINS_PATH(`snapshot`, 'one', '$.id');
\ No newline at end of file
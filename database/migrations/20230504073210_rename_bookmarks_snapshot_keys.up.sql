@@ -0,0 +1 @@
+UPDATE `bookmarks` SET `snapshot` = JSON_OBJECT('id', `snapshot`->>'$.ID', 'company', `snapshot`->>'$.Company', 'company_url', `snapshot`->>'$.CompanyURL', 'company_logo', `snapshot`->>'$.CompanyLogo', 'url', `snapshot`->>'$.URL', 'type', `snapshot`->>'$.Type', 'location', `snapshot`->>'$.Location', 'title', `snapshot`->>'$.Title', 'description', `snapshot`->>'$.Description', 'how_to_apply', `snapshot`->>'$.HowToApply', 'created_at', `snapshot`->>'$.CreatedAt') WHERE JSON_CONTAINS_PATH(`snapshot`, 'one', '$.ID');
@@ -0,0 +1,344 @@
+package internal
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+const apiKeyPrefix = "jsk"
+
+var (
+	ErrAPIKeyNotFound = errors.New("api key not found")
+	ErrAPIKeyInvalid  = errors.New("api key is invalid")
+)
+
+type APIKeyScope string
+
+const (
+	APIKeyScopeJobsRead          APIKeyScope = "jobs:read"
+	APIKeyScopeSearchesRead      APIKeyScope = "searches:read"
+	APIKeyScopeSearchesWrite     APIKeyScope = "searches:write"
+	APIKeyScopeBookmarksRead     APIKeyScope = "bookmarks:read"
+	APIKeyScopeBookmarksWrite    APIKeyScope = "bookmarks:write"
+	APIKeyScopeApplicationsRead  APIKeyScope = "applications:read"
+	APIKeyScopeApplicationsWrite APIKeyScope = "applications:write"
+	APIKeyScopeUsersRead         APIKeyScope = "users:read"
+	APIKeyScopeUsersWrite        APIKeyScope = "users:write"
+	APIKeyScopeCacheRead         APIKeyScope = "cache:read"
+)
+
+type APIKeyCreator interface {
+	CreateAPIKey(ctx context.Context, req APIKeyRequest) (APIKey, string, error)
+}
+
+type APIKeysListerByUserID interface {
+	ListAPIKeysByUserID(ctx context.Context, userID int64) ([]APIKey, error)
+}
+
+type APIKeyRevoker interface {
+	RevokeAPIKey(ctx context.Context, userID, apiKeyID int64) error
+}
+
+type APIKeyAuthenticator interface {
+	AuthenticateAPIKey(ctx context.Context, key string) (APIKey, User, error)
+}
+
+type APIKeyGetterByPrefix interface {
+	GetAPIKeyByPrefix(ctx context.Context, prefix string) (APIKey, error)
+}
+
+type APIKeyStorer interface {
+	StoreAPIKey(ctx context.Context, apiKey *APIKey) error
+}
+
+type APIKeyRevocationStorer interface {
+	StoreAPIKeyRevocation(ctx context.Context, userID, apiKeyID int64, revokedAt time.Time) error
+}
+
+type APIKeyUsageStorer interface {
+	StoreAPIKeyUsage(ctx context.Context, apiKeyID int64, usedAt time.Time) error
+}
+
+type APIKeyRequest struct {
+	UserID    int64
+	Name      string
+	Scopes    []APIKeyScope
+	ExpiresAt time.Time
+	TwoFactor bool
+}
+
+type APIKey struct {
+	ID         int64
+	UserID     int64
+	Name       string
+	Prefix     string
+	Hash       string
+	Scopes     []APIKeyScope
+	ExpiresAt  time.Time
+	LastUsedAt time.Time
+	RevokedAt  time.Time
+	CreatedAt  time.Time
+}
+
+func (k APIKey) HasScope(scope APIKeyScope) bool {
+	for _, each := range k.Scopes {
+		if each == scope {
+			return true
+		}
+	}
+
+	return false
+}
+
+type apiKeyCreator struct {
+	validator       Validator[APIKeyRequest]
+	timer           Timer
+	prefixGenerator TokenGenerator
+	secretGenerator TokenGenerator
+	userGetterByID  UserGetterByID
+	apiKeyStorer    APIKeyStorer
+}
+
+func (ac apiKeyCreator) CreateAPIKey(ctx context.Context, req APIKeyRequest) (APIKey, string, error) {
+	err := ac.validator.Validate(ctx, req)
+	if err != nil {
+		return APIKey{}, "", fmt.Errorf("validating api key request: %w", err)
+	}
+
+	now := ac.timer.Now()
+
+	if !req.ExpiresAt.IsZero() && !req.ExpiresAt.After(now) {
+		return APIKey{}, "", NewValidationError("expires_at", "gt=now")
+	}
+
+	user, err := ac.userGetterByID.GetUserByID(ctx, req.UserID)
+	if err != nil {
+		return APIKey{}, "", fmt.Errorf("getting user by id: %w", err)
+	}
+
+	if user.TwoFactorEnabled() && !req.TwoFactor {
+		return APIKey{}, "", ErrTwoFactorRequired
+	}
+
+	prefix, err := ac.prefixGenerator.GenerateToken()
+	if err != nil {
+		return APIKey{}, "", fmt.Errorf("generating api key prefix: %w", err)
+	}
+
+	secret, err := ac.secretGenerator.GenerateToken()
+	if err != nil {
+		return APIKey{}, "", fmt.Errorf("generating api key secret: %w", err)
+	}
+
+	prefix = strings.ReplaceAll(prefix, "_", "-")
+	key := fmt.Sprintf("%s_%s_%s", apiKeyPrefix, prefix, secret)
+	apiKey := APIKey{
+		UserID:    req.UserID,
+		Name:      req.Name,
+		Prefix:    prefix,
+		Hash:      HashToken(key),
+		Scopes:    req.Scopes,
+		ExpiresAt: req.ExpiresAt,
+		CreatedAt: now,
+	}
+	err = ac.apiKeyStorer.StoreAPIKey(ctx, &apiKey)
+	if err != nil {
+		return APIKey{}, "", fmt.Errorf("storing api key: %w", err)
+	}
+
+	return apiKey, key, nil
+}
+
+func NewAPIKeyCreator(
+	validator Validator[APIKeyRequest],
+	timer Timer,
+	prefixGenerator TokenGenerator,
+	secretGenerator TokenGenerator,
+	userGetterByID UserGetterByID,
+	apiKeyStorer APIKeyStorer,
+) *apiKeyCreator {
+	return &apiKeyCreator{
+		validator:       validator,
+		timer:           timer,
+		prefixGenerator: prefixGenerator,
+		secretGenerator: secretGenerator,
+		userGetterByID:  userGetterByID,
+		apiKeyStorer:    apiKeyStorer,
+	}
+}
+
+type apiKeyRevoker struct {
+	timer                  Timer
+	apiKeyRevocationStorer APIKeyRevocationStorer
+}
+
+func (ar apiKeyRevoker) RevokeAPIKey(ctx context.Context, userID, apiKeyID int64) error {
+	err := ar.apiKeyRevocationStorer.StoreAPIKeyRevocation(ctx, userID, apiKeyID, ar.timer.Now())
+	if err != nil {
+		return fmt.Errorf("storing api key revocation: %w", err)
+	}
+
+	return nil
+}
+
+func NewAPIKeyRevoker(timer Timer, apiKeyRevocationStorer APIKeyRevocationStorer) *apiKeyRevoker {
+	return &apiKeyRevoker{
+		timer:                  timer,
+		apiKeyRevocationStorer: apiKeyRevocationStorer,
+	}
+}
+
+type apiKeyAuthenticator struct {
+	timer                Timer
+	usageResolution      time.Duration
+	apiKeyGetterByPrefix APIKeyGetterByPrefix
+	apiKeyUsageStorer    APIKeyUsageStorer
+	userGetterByID       UserGetterByID
+}
+
+func (aa apiKeyAuthenticator) AuthenticateAPIKey(ctx context.Context, key string) (APIKey, User, error) {
+	comps := strings.SplitN(key, "_", 3)
+
+	if len(comps) != 3 || comps[0] != apiKeyPrefix {
+		return APIKey{}, User{}, ErrAPIKeyInvalid
+	}
+
+	apiKey, err := aa.apiKeyGetterByPrefix.GetAPIKeyByPrefix(ctx, comps[1])
+	if err != nil {
+		err = fmt.Errorf("getting api key by prefix: %w", err)
+
+		if errors.Is(err, ErrAPIKeyNotFound) {
+			err = ErrAPIKeyInvalid
+		}
+
+		return APIKey{}, User{}, err
+	}
+
+	if subtle.ConstantTimeCompare([]byte(apiKey.Hash), []byte(HashToken(key))) != 1 {
+		return APIKey{}, User{}, ErrAPIKeyInvalid
+	}
+
+	now := aa.timer.Now()
+
+	if !apiKey.RevokedAt.IsZero() || (!apiKey.ExpiresAt.IsZero() && !now.Before(apiKey.ExpiresAt)) {
+		return APIKey{}, User{}, ErrAPIKeyInvalid
+	}
+
+	user, err := aa.userGetterByID.GetUserByID(ctx, apiKey.UserID)
+	if err != nil {
+		err = fmt.Errorf("getting user by id: %w", err)
+
+		if errors.Is(err, ErrUserNotFound) {
+			err = ErrAPIKeyInvalid
+		}
+
+		return APIKey{}, User{}, err
+	}
+
+	if user.Disabled() {
+		return APIKey{}, User{}, ErrUserDisabled
+	}
+
+	if now.Sub(apiKey.LastUsedAt) >= aa.usageResolution {
+		err = aa.apiKeyUsageStorer.StoreAPIKeyUsage(ctx, apiKey.ID, now)
+		if err != nil {
+			return APIKey{}, User{}, fmt.Errorf("storing api key usage: %w", err)
+		}
+
+		apiKey.LastUsedAt = now
+	}
+
+	return apiKey, user, nil
+}
+
+func NewAPIKeyAuthenticator(
+	timer Timer,
+	usageResolution time.Duration,
+	apiKeyGetterByPrefix APIKeyGetterByPrefix,
+	apiKeyUsageStorer APIKeyUsageStorer,
+	userGetterByID UserGetterByID,
+) *apiKeyAuthenticator {
+	return &apiKeyAuthenticator{
+		timer:                timer,
+		usageResolution:      usageResolution,
+		apiKeyGetterByPrefix: apiKeyGetterByPrefix,
+		apiKeyUsageStorer:    apiKeyUsageStorer,
+		userGetterByID:       userGetterByID,
+	}
+}
+
+type apiKeyRequestValidator struct {
+	validate *validator.Validate
+}
+
+func (v apiKeyRequestValidator) EvaluateErrorAs(err, target error) error {
+	if errors.As(err, &validator.ValidationErrors{}) {
+		err = target
+	}
+
+	return err
+}
+
+func (v apiKeyRequestValidator) Validate(ctx context.Context, req APIKeyRequest) error {
+	validationErrors := ValidationErrors{}
+
+	err := validationErrors.Collect(v.validateName(ctx, req.Name))
+	if err != nil {
+		return err
+	}
+
+	err = validationErrors.Collect(v.validateScopes(ctx, req.Scopes))
+	if err != nil {
+		return err
+	}
+
+	return validationErrors.Err()
+}
+
+func (v apiKeyRequestValidator) validateName(ctx context.Context, name string) error {
+	err := v.validate.VarCtx(ctx, name, "required")
+	if err != nil {
+		return v.EvaluateErrorAs(err, NewValidationError("name", "required"))
+	}
+
+	err = v.validate.VarCtx(ctx, name, "max=100")
+	if err != nil {
+		return v.EvaluateErrorAs(err, NewValidationError("name", "max=100"))
+	}
+
+	return nil
+}
+
+func (v apiKeyRequestValidator) validateScopes(ctx context.Context, scopes []APIKeyScope) error {
+	if len(scopes) == 0 {
+		return NewValidationError("scopes", "required")
+	}
+
+	for _, each := range scopes {
+		err := v.validate.VarCtx(
+			ctx,
+			string(each),
+			"oneof=jobs:read searches:read searches:write bookmarks:read bookmarks:write applications:read applications:write users:read users:write cache:read",
+		)
+		if err != nil {
+			return v.EvaluateErrorAs(err, NewValidationError(
+				"scopes",
+				"oneof=jobs:read searches:read searches:write bookmarks:read bookmarks:write applications:read applications:write users:read users:write cache:read",
+			))
+		}
+	}
+
+	return nil
+}
+
+func NewAPIKeyRequestValidator(validate *validator.Validate) *apiKeyRequestValidator {
+	return &apiKeyRequestValidator{
+		validate: validate,
+	}
+}
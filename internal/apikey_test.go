@@ -0,0 +1,106 @@
+package internal_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/adystag/jobs-search/internal"
+
+	"github.com/go-playground/validator/v10"
+)
+
+func TestAPIKeyHasScope(t *testing.T) {
+	tests := []struct {
+		name   string
+		scopes []internal.APIKeyScope
+		scope  internal.APIKeyScope
+		want   bool
+	}{
+		{
+			name:   "granted scope",
+			scopes: []internal.APIKeyScope{internal.APIKeyScopeJobsRead, internal.APIKeyScopeUsersRead},
+			scope:  internal.APIKeyScopeUsersRead,
+			want:   true,
+		},
+		{
+			name:   "missing scope",
+			scopes: []internal.APIKeyScope{internal.APIKeyScopeJobsRead},
+			scope:  internal.APIKeyScopeUsersWrite,
+			want:   false,
+		},
+		{
+			name:   "no scopes",
+			scopes: nil,
+			scope:  internal.APIKeyScopeUsersRead,
+			want:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := internal.APIKey{Scopes: tt.scopes}.HasScope(tt.scope)
+			if got != tt.want {
+				t.Errorf("HasScope(%q) = %t, want %t", tt.scope, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAPIKeyRequestValidatorScopes(t *testing.T) {
+	tests := []struct {
+		name    string
+		scopes  []internal.APIKeyScope
+		wantTag string
+	}{
+		{
+			name:   "valid scopes",
+			scopes: []internal.APIKeyScope{internal.APIKeyScopeJobsRead},
+		},
+		{
+			name:    "nil scopes",
+			scopes:  nil,
+			wantTag: "required",
+		},
+		{
+			name:    "empty scopes",
+			scopes:  []internal.APIKeyScope{},
+			wantTag: "required",
+		},
+		{
+			name:    "unknown scope",
+			scopes:  []internal.APIKeyScope{"admin"},
+			wantTag: "oneof",
+		},
+	}
+
+	v := internal.NewAPIKeyRequestValidator(validator.New())
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := v.Validate(context.Background(), internal.APIKeyRequest{
+				Name:   "ci",
+				Scopes: tt.scopes,
+			})
+
+			if len(tt.wantTag) == 0 {
+				if err != nil {
+					t.Fatalf("Validate() error = %v, want nil", err)
+				}
+
+				return
+			}
+
+			var validationError internal.ValidationError
+
+			if !errors.As(err, &validationError) {
+				t.Fatalf("Validate() error = %v, want validation error", err)
+			}
+
+			if validationError.Field() != "scopes" || !strings.HasPrefix(validationError.Tag(), tt.wantTag) {
+				t.Errorf("Validate() error = %s:%s, want scopes:%s", validationError.Field(), validationError.Tag(), tt.wantTag)
+			}
+		})
+	}
+}
@@ -0,0 +1,425 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrApplicationNotFound       = errors.New("application not found")
+	ErrApplicationStatusConflict = errors.New("application status has been changed concurrently")
+)
+
+type ApplicationStatus string
+
+const (
+	ApplicationStatusInterested   ApplicationStatus = "interested"
+	ApplicationStatusApplied      ApplicationStatus = "applied"
+	ApplicationStatusInterviewing ApplicationStatus = "interviewing"
+	ApplicationStatusOffer        ApplicationStatus = "offer"
+	ApplicationStatusRejected     ApplicationStatus = "rejected"
+	ApplicationStatusWithdrawn    ApplicationStatus = "withdrawn"
+)
+
+var applicationStatusTransitions = map[ApplicationStatus][]ApplicationStatus{
+	ApplicationStatusInterested: {
+		ApplicationStatusApplied,
+		ApplicationStatusWithdrawn,
+	},
+	ApplicationStatusApplied: {
+		ApplicationStatusInterviewing,
+		ApplicationStatusOffer,
+		ApplicationStatusRejected,
+		ApplicationStatusWithdrawn,
+	},
+	ApplicationStatusInterviewing: {
+		ApplicationStatusOffer,
+		ApplicationStatusRejected,
+		ApplicationStatusWithdrawn,
+	},
+	ApplicationStatusOffer: {
+		ApplicationStatusWithdrawn,
+	},
+}
+
+func (s ApplicationStatus) CanTransitionTo(next ApplicationStatus) bool {
+	for _, each := range applicationStatusTransitions[s] {
+		if each == next {
+			return true
+		}
+	}
+
+	return false
+}
+
+type ApplicationCreator interface {
+	CreateApplication(ctx context.Context, req ApplicationRequest) (Application, error)
+}
+
+type ApplicationUpdater interface {
+	UpdateApplication(ctx context.Context, req ApplicationUpdateRequest) (Application, error)
+}
+
+type ApplicationStatusTransitioner interface {
+	TransitionApplicationStatus(ctx context.Context, req ApplicationStatusTransitionRequest) (Application, error)
+}
+
+type ApplicationsListerByUserID interface {
+	ListApplicationsByUserID(ctx context.Context, userID int64) ([]Application, error)
+}
+
+type ApplicationGetterByID interface {
+	GetApplicationByID(ctx context.Context, userID, applicationID int64) (Application, error)
+}
+
+type ApplicationStorer interface {
+	StoreApplication(ctx context.Context, application *Application) error
+}
+
+type ApplicationDeleter interface {
+	DeleteApplication(ctx context.Context, userID, applicationID int64) error
+}
+
+type ApplicationRequest struct {
+	UserID int64
+	JobID  string
+	Status ApplicationStatus
+	Notes  string
+}
+
+type ApplicationUpdateRequest struct {
+	UserID        int64
+	ApplicationID int64
+	Notes         string
+}
+
+type ApplicationStatusTransitionRequest struct {
+	UserID        int64
+	ApplicationID int64
+	Status        ApplicationStatus
+	Note          string
+}
+
+type Application struct {
+	ID        int64
+	UserID    int64
+	JobID     uuid.UUID
+	Status    ApplicationStatus
+	Notes     string
+	History   []ApplicationStatusChange
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type ApplicationStatusChange struct {
+	ID        int64
+	From      ApplicationStatus
+	To        ApplicationStatus
+	Note      string
+	ChangedAt time.Time
+}
+
+type applicationCreator struct {
+	validator         Validator[ApplicationRequest]
+	timer             Timer
+	jobGetterByID     JobGetterByID
+	applicationStorer ApplicationStorer
+}
+
+func (ac applicationCreator) CreateApplication(ctx context.Context, req ApplicationRequest) (Application, error) {
+	if len(req.Status) == 0 {
+		req.Status = ApplicationStatusInterested
+	}
+
+	err := ac.validator.Validate(ctx, req)
+	if err != nil {
+		return Application{}, fmt.Errorf("validating application request: %w", err)
+	}
+
+	job, err := ac.jobGetterByID.GetJobByID(ctx, req.JobID)
+	if err != nil {
+		return Application{}, fmt.Errorf("getting job by id: %w", err)
+	}
+
+	now := ac.timer.Now()
+	application := Application{
+		UserID: req.UserID,
+		JobID:  job.ID,
+		Status: req.Status,
+		Notes:  req.Notes,
+		History: []ApplicationStatusChange{
+			{
+				To:        req.Status,
+				ChangedAt: now,
+			},
+		},
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	err = ac.applicationStorer.StoreApplication(ctx, &application)
+	if err != nil {
+		return Application{}, fmt.Errorf("storing application: %w", err)
+	}
+
+	return application, nil
+}
+
+func NewApplicationCreator(
+	validator Validator[ApplicationRequest],
+	timer Timer,
+	jobGetterByID JobGetterByID,
+	applicationStorer ApplicationStorer,
+) *applicationCreator {
+	return &applicationCreator{
+		validator:         validator,
+		timer:             timer,
+		jobGetterByID:     jobGetterByID,
+		applicationStorer: applicationStorer,
+	}
+}
+
+type applicationUpdater struct {
+	validator             Validator[ApplicationUpdateRequest]
+	timer                 Timer
+	applicationGetterByID ApplicationGetterByID
+	applicationStorer     ApplicationStorer
+}
+
+func (au applicationUpdater) UpdateApplication(ctx context.Context, req ApplicationUpdateRequest) (Application, error) {
+	err := au.validator.Validate(ctx, req)
+	if err != nil {
+		return Application{}, fmt.Errorf("validating application update request: %w", err)
+	}
+
+	application, err := au.applicationGetterByID.GetApplicationByID(ctx, req.UserID, req.ApplicationID)
+	if err != nil {
+		return Application{}, fmt.Errorf("getting application by id: %w", err)
+	}
+
+	application.Notes = req.Notes
+	application.UpdatedAt = au.timer.Now()
+
+	err = au.applicationStorer.StoreApplication(ctx, &application)
+	if err != nil {
+		return Application{}, fmt.Errorf("storing application: %w", err)
+	}
+
+	return application, nil
+}
+
+func NewApplicationUpdater(
+	validator Validator[ApplicationUpdateRequest],
+	timer Timer,
+	applicationGetterByID ApplicationGetterByID,
+	applicationStorer ApplicationStorer,
+) *applicationUpdater {
+	return &applicationUpdater{
+		validator:             validator,
+		timer:                 timer,
+		applicationGetterByID: applicationGetterByID,
+		applicationStorer:     applicationStorer,
+	}
+}
+
+type applicationStatusTransitioner struct {
+	validator             Validator[ApplicationStatusTransitionRequest]
+	timer                 Timer
+	applicationGetterByID ApplicationGetterByID
+	applicationStorer     ApplicationStorer
+}
+
+func (at applicationStatusTransitioner) TransitionApplicationStatus(
+	ctx context.Context,
+	req ApplicationStatusTransitionRequest,
+) (Application, error) {
+	err := at.validator.Validate(ctx, req)
+	if err != nil {
+		return Application{}, fmt.Errorf("validating application status transition request: %w", err)
+	}
+
+	application, err := at.applicationGetterByID.GetApplicationByID(ctx, req.UserID, req.ApplicationID)
+	if err != nil {
+		return Application{}, fmt.Errorf("getting application by id: %w", err)
+	}
+
+	if !application.Status.CanTransitionTo(req.Status) {
+		return Application{}, NewValidationError("status", fmt.Sprintf("transition=%s", application.Status))
+	}
+
+	now := at.timer.Now()
+
+	application.History = append(application.History, ApplicationStatusChange{
+		From:      application.Status,
+		To:        req.Status,
+		Note:      req.Note,
+		ChangedAt: now,
+	})
+	application.Status = req.Status
+	application.UpdatedAt = now
+
+	err = at.applicationStorer.StoreApplication(ctx, &application)
+	if err != nil {
+		return Application{}, fmt.Errorf("storing application: %w", err)
+	}
+
+	return application, nil
+}
+
+func NewApplicationStatusTransitioner(
+	validator Validator[ApplicationStatusTransitionRequest],
+	timer Timer,
+	applicationGetterByID ApplicationGetterByID,
+	applicationStorer ApplicationStorer,
+) *applicationStatusTransitioner {
+	return &applicationStatusTransitioner{
+		validator:             validator,
+		timer:                 timer,
+		applicationGetterByID: applicationGetterByID,
+		applicationStorer:     applicationStorer,
+	}
+}
+
+type applicationRequestValidator struct {
+	validate *validator.Validate
+}
+
+func (v applicationRequestValidator) EvaluateErrorAs(err, target error) error {
+	if errors.As(err, &validator.ValidationErrors{}) {
+		err = target
+	}
+
+	return err
+}
+
+func (v applicationRequestValidator) Validate(ctx context.Context, req ApplicationRequest) error {
+	validationErrors := ValidationErrors{}
+
+	err := validationErrors.Collect(v.validateJobID(ctx, req.JobID))
+	if err != nil {
+		return err
+	}
+
+	err = v.validate.VarCtx(ctx, string(req.Status), "oneof=interested applied interviewing offer rejected withdrawn")
+	if err != nil {
+		err = validationErrors.Collect(v.EvaluateErrorAs(err, NewValidationError("status", "oneof=interested applied interviewing offer rejected withdrawn")))
+		if err != nil {
+			return err
+		}
+	}
+
+	err = v.validate.VarCtx(ctx, req.Notes, "max=5000")
+	if err != nil {
+		err = validationErrors.Collect(v.EvaluateErrorAs(err, NewValidationError("notes", "max=5000")))
+		if err != nil {
+			return err
+		}
+	}
+
+	return validationErrors.Err()
+}
+
+func (v applicationRequestValidator) validateJobID(ctx context.Context, jobID string) error {
+	err := v.validate.VarCtx(ctx, jobID, "required")
+	if err != nil {
+		return v.EvaluateErrorAs(err, NewValidationError("job_id", "required"))
+	}
+
+	err = v.validate.VarCtx(ctx, jobID, "uuid")
+	if err != nil {
+		return v.EvaluateErrorAs(err, NewValidationError("job_id", "uuid"))
+	}
+
+	return nil
+}
+
+func NewApplicationRequestValidator(validate *validator.Validate) *applicationRequestValidator {
+	return &applicationRequestValidator{
+		validate: validate,
+	}
+}
+
+type applicationUpdateRequestValidator struct {
+	validate *validator.Validate
+}
+
+func (v applicationUpdateRequestValidator) EvaluateErrorAs(err, target error) error {
+	if errors.As(err, &validator.ValidationErrors{}) {
+		err = target
+	}
+
+	return err
+}
+
+func (v applicationUpdateRequestValidator) Validate(ctx context.Context, req ApplicationUpdateRequest) error {
+	err := v.validate.VarCtx(ctx, req.Notes, "max=5000")
+	if err != nil {
+		return v.EvaluateErrorAs(err, NewValidationError("notes", "max=5000"))
+	}
+
+	return nil
+}
+
+func NewApplicationUpdateRequestValidator(validate *validator.Validate) *applicationUpdateRequestValidator {
+	return &applicationUpdateRequestValidator{
+		validate: validate,
+	}
+}
+
+type applicationStatusTransitionRequestValidator struct {
+	validate *validator.Validate
+}
+
+func (v applicationStatusTransitionRequestValidator) EvaluateErrorAs(err, target error) error {
+	if errors.As(err, &validator.ValidationErrors{}) {
+		err = target
+	}
+
+	return err
+}
+
+func (v applicationStatusTransitionRequestValidator) Validate(
+	ctx context.Context,
+	req ApplicationStatusTransitionRequest,
+) error {
+	validationErrors := ValidationErrors{}
+
+	err := validationErrors.Collect(v.validateStatus(ctx, req.Status))
+	if err != nil {
+		return err
+	}
+
+	err = v.validate.VarCtx(ctx, req.Note, "max=1000")
+	if err != nil {
+		err = validationErrors.Collect(v.EvaluateErrorAs(err, NewValidationError("note", "max=1000")))
+		if err != nil {
+			return err
+		}
+	}
+
+	return validationErrors.Err()
+}
+
+func (v applicationStatusTransitionRequestValidator) validateStatus(ctx context.Context, status ApplicationStatus) error {
+	err := v.validate.VarCtx(ctx, string(status), "required")
+	if err != nil {
+		return v.EvaluateErrorAs(err, NewValidationError("status", "required"))
+	}
+
+	err = v.validate.VarCtx(ctx, string(status), "oneof=interested applied interviewing offer rejected withdrawn")
+	if err != nil {
+		return v.EvaluateErrorAs(err, NewValidationError("status", "oneof=interested applied interviewing offer rejected withdrawn"))
+	}
+
+	return nil
+}
+
+func NewApplicationStatusTransitionRequestValidator(validate *validator.Validate) *applicationStatusTransitionRequestValidator {
+	return &applicationStatusTransitionRequestValidator{
+		validate: validate,
+	}
+}
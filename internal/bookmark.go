@@ -0,0 +1,235 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+var ErrBookmarkNotFound = errors.New("bookmark not found")
+
+type BookmarkCreator interface {
+	CreateBookmark(ctx context.Context, req BookmarkRequest) (Bookmark, error)
+}
+
+type BookmarksLister interface {
+	ListBookmarks(ctx context.Context, userID int64) ([]Bookmark, error)
+}
+
+type BookmarksListerByUserID interface {
+	ListBookmarksByUserID(ctx context.Context, userID int64) ([]Bookmark, error)
+}
+
+type BookmarkStorer interface {
+	StoreBookmark(ctx context.Context, bookmark *Bookmark) error
+}
+
+type BookmarkDeleter interface {
+	DeleteBookmark(ctx context.Context, userID int64, jobID string) error
+}
+
+type BookmarkRequest struct {
+	UserID int64
+	JobID  string
+	Note   string
+	Tags   []string
+}
+
+type Bookmark struct {
+	ID        int64
+	UserID    int64
+	JobID     uuid.UUID
+	Note      string
+	Tags      []string
+	Job       Job
+	Removed   bool
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type bookmarkCreator struct {
+	validator      Validator[BookmarkRequest]
+	timer          Timer
+	jobGetterByID  JobGetterByID
+	bookmarkStorer BookmarkStorer
+}
+
+func (bc bookmarkCreator) CreateBookmark(ctx context.Context, req BookmarkRequest) (Bookmark, error) {
+	err := bc.validator.Validate(ctx, req)
+	if err != nil {
+		return Bookmark{}, fmt.Errorf("validating bookmark request: %w", err)
+	}
+
+	job, err := bc.jobGetterByID.GetJobByID(ctx, req.JobID)
+	if err != nil {
+		return Bookmark{}, fmt.Errorf("getting job by id: %w", err)
+	}
+
+	now := bc.timer.Now()
+	bookmark := Bookmark{
+		UserID:    req.UserID,
+		JobID:     job.ID,
+		Note:      req.Note,
+		Tags:      req.Tags,
+		Job:       job,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	err = bc.bookmarkStorer.StoreBookmark(ctx, &bookmark)
+	if err != nil {
+		return Bookmark{}, fmt.Errorf("storing bookmark: %w", err)
+	}
+
+	return bookmark, nil
+}
+
+func NewBookmarkCreator(
+	validator Validator[BookmarkRequest],
+	timer Timer,
+	jobGetterByID JobGetterByID,
+	bookmarkStorer BookmarkStorer,
+) *bookmarkCreator {
+	return &bookmarkCreator{
+		validator:      validator,
+		timer:          timer,
+		jobGetterByID:  jobGetterByID,
+		bookmarkStorer: bookmarkStorer,
+	}
+}
+
+type bookmarksLister struct {
+	bookmarksListerByUserID BookmarksListerByUserID
+	jobGetterByID           JobGetterByID
+	concurrency             int
+}
+
+func (bl bookmarksLister) ListBookmarks(ctx context.Context, userID int64) ([]Bookmark, error) {
+	bookmarks, err := bl.bookmarksListerByUserID.ListBookmarksByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("listing bookmarks by user id: %w", err)
+	}
+
+	var wg sync.WaitGroup
+
+	semaphore := make(chan struct{}, bl.concurrency)
+
+	for index := range bookmarks {
+		wg.Add(1)
+
+		semaphore <- struct{}{}
+
+		go func(bookmark *Bookmark) {
+			defer func() {
+				<-semaphore
+				wg.Done()
+			}()
+
+			job, err := bl.jobGetterByID.GetJobByID(ctx, bookmark.JobID.String())
+			if err != nil {
+				bookmark.Removed = errors.Is(err, ErrJobNotFound)
+
+				return
+			}
+
+			bookmark.Job = job
+		}(&bookmarks[index])
+	}
+
+	wg.Wait()
+
+	return bookmarks, nil
+}
+
+func NewBookmarksLister(
+	bookmarksListerByUserID BookmarksListerByUserID,
+	jobGetterByID JobGetterByID,
+	concurrency int,
+) *bookmarksLister {
+	return &bookmarksLister{
+		bookmarksListerByUserID: bookmarksListerByUserID,
+		jobGetterByID:           jobGetterByID,
+		concurrency:             concurrency,
+	}
+}
+
+type bookmarkRequestValidator struct {
+	validate *validator.Validate
+}
+
+func (v bookmarkRequestValidator) EvaluateErrorAs(err, target error) error {
+	if errors.As(err, &validator.ValidationErrors{}) {
+		err = target
+	}
+
+	return err
+}
+
+func (v bookmarkRequestValidator) Validate(ctx context.Context, req BookmarkRequest) error {
+	validationErrors := ValidationErrors{}
+
+	err := validationErrors.Collect(v.validateJobID(ctx, req.JobID))
+	if err != nil {
+		return err
+	}
+
+	err = v.validate.VarCtx(ctx, req.Note, "max=1000")
+	if err != nil {
+		err = validationErrors.Collect(v.EvaluateErrorAs(err, NewValidationError("note", "max=1000")))
+		if err != nil {
+			return err
+		}
+	}
+
+	err = validationErrors.Collect(v.validateTags(ctx, req.Tags))
+	if err != nil {
+		return err
+	}
+
+	return validationErrors.Err()
+}
+
+func (v bookmarkRequestValidator) validateJobID(ctx context.Context, jobID string) error {
+	err := v.validate.VarCtx(ctx, jobID, "required")
+	if err != nil {
+		return v.EvaluateErrorAs(err, NewValidationError("job_id", "required"))
+	}
+
+	err = v.validate.VarCtx(ctx, jobID, "uuid")
+	if err != nil {
+		return v.EvaluateErrorAs(err, NewValidationError("job_id", "uuid"))
+	}
+
+	return nil
+}
+
+func (v bookmarkRequestValidator) validateTags(ctx context.Context, tags []string) error {
+	err := v.validate.VarCtx(ctx, tags, "max=10")
+	if err != nil {
+		return v.EvaluateErrorAs(err, NewValidationError("tags", "max=10"))
+	}
+
+	for _, tag := range tags {
+		err = v.validate.VarCtx(ctx, tag, "required")
+		if err != nil {
+			return v.EvaluateErrorAs(err, NewValidationError("tags", "dive,required"))
+		}
+
+		err = v.validate.VarCtx(ctx, tag, "max=30")
+		if err != nil {
+			return v.EvaluateErrorAs(err, NewValidationError("tags", "dive,max=30"))
+		}
+	}
+
+	return nil
+}
+
+func NewBookmarkRequestValidator(validate *validator.Validate) *bookmarkRequestValidator {
+	return &bookmarkRequestValidator{
+		validate: validate,
+	}
+}
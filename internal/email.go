@@ -0,0 +1,264 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	ErrEmailNotVerified               = errors.New("email is not verified")
+	ErrEmailVerificationTokenNotFound = errors.New("email verification token not found")
+	ErrEmailVerificationTokenInvalid  = errors.New("email verification token is invalid")
+	ErrEmailAlreadyVerified           = errors.New("email is already verified")
+)
+
+type EmailVerificationRequester interface {
+	RequestEmailVerification(ctx context.Context, req EmailVerificationRequest) error
+}
+
+type UserEmailVerificationRequester interface {
+	RequestUserEmailVerification(ctx context.Context, userID int64) error
+}
+
+type EmailVerifier interface {
+	VerifyEmail(ctx context.Context, token string) error
+}
+
+type EmailVerificationTokenGetterByHash interface {
+	GetEmailVerificationTokenByHash(ctx context.Context, hash string) (EmailVerificationToken, error)
+}
+
+type EmailVerificationTokenStorer interface {
+	StoreEmailVerificationToken(ctx context.Context, emailVerificationToken *EmailVerificationToken) error
+}
+
+type EmailVerificationTokenConsumer interface {
+	ConsumeEmailVerificationToken(ctx context.Context, emailVerificationTokenID int64, usedAt time.Time) error
+}
+
+type EmailVerificationRequest struct {
+	Username string
+}
+
+type EmailVerificationToken struct {
+	ID        int64
+	UserID    int64
+	Email     string
+	Hash      string
+	ExpiresAt time.Time
+	UsedAt    time.Time
+	CreatedAt time.Time
+}
+
+type emailVerificationRequester struct {
+	timer                        Timer
+	tokenGenerator               TokenGenerator
+	lifeTime                     time.Duration
+	userGetterByUsername         UserGetterByUsername
+	userGetterByID               UserGetterByID
+	emailVerificationTokenStorer EmailVerificationTokenStorer
+	mailer                       Mailer
+}
+
+func (er emailVerificationRequester) RequestEmailVerification(ctx context.Context, req EmailVerificationRequest) error {
+	if len(req.Username) == 0 {
+		return NewValidationError("username", "required")
+	}
+
+	user, err := er.userGetterByUsername.GetUserByUsername(ctx, req.Username)
+	if err != nil {
+		if errors.Is(err, ErrUserNotFound) {
+			return nil
+		}
+
+		return fmt.Errorf("getting user by username: %w", err)
+	}
+
+	if len(user.Email) == 0 || user.EmailVerified() {
+		return nil
+	}
+
+	return er.issue(ctx, user)
+}
+
+func (er emailVerificationRequester) RequestUserEmailVerification(ctx context.Context, userID int64) error {
+	user, err := er.userGetterByID.GetUserByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("getting user by id: %w", err)
+	}
+
+	if len(user.Email) == 0 {
+		return NewValidationError("email", "required")
+	}
+
+	if user.EmailVerified() {
+		return ErrEmailAlreadyVerified
+	}
+
+	return er.issue(ctx, user)
+}
+
+func (er emailVerificationRequester) issue(ctx context.Context, user User) error {
+	token, err := er.tokenGenerator.GenerateToken()
+	if err != nil {
+		return fmt.Errorf("generating email verification token: %w", err)
+	}
+
+	now := er.timer.Now()
+	emailVerificationToken := EmailVerificationToken{
+		UserID:    user.ID,
+		Email:     user.Email,
+		Hash:      HashToken(token),
+		ExpiresAt: now.Add(er.lifeTime),
+		CreatedAt: now,
+	}
+	err = er.emailVerificationTokenStorer.StoreEmailVerificationToken(ctx, &emailVerificationToken)
+	if err != nil {
+		return fmt.Errorf("storing email verification token: %w", err)
+	}
+
+	err = er.mailer.SendMail(ctx, Mail{
+		To:      user.Email,
+		Subject: "Verify your email",
+		Body: fmt.Sprintf(
+			"Hi %s, use the following token to verify your email, it expires at %s: %s",
+			user.Username,
+			emailVerificationToken.ExpiresAt.Format(time.RFC3339),
+			token,
+		),
+	})
+	if err != nil {
+		return fmt.Errorf("mailing email verification token: %w", err)
+	}
+
+	return nil
+}
+
+func NewEmailVerificationRequester(
+	timer Timer,
+	tokenGenerator TokenGenerator,
+	lifeTime time.Duration,
+	userGetterByUsername UserGetterByUsername,
+	userGetterByID UserGetterByID,
+	emailVerificationTokenStorer EmailVerificationTokenStorer,
+	mailer Mailer,
+) *emailVerificationRequester {
+	return &emailVerificationRequester{
+		timer:                        timer,
+		tokenGenerator:               tokenGenerator,
+		lifeTime:                     lifeTime,
+		userGetterByUsername:         userGetterByUsername,
+		userGetterByID:               userGetterByID,
+		emailVerificationTokenStorer: emailVerificationTokenStorer,
+		mailer:                       mailer,
+	}
+}
+
+type emailVerifier struct {
+	timer                              Timer
+	emailVerificationTokenGetterByHash EmailVerificationTokenGetterByHash
+	emailVerificationTokenConsumer     EmailVerificationTokenConsumer
+	userGetterByID                     UserGetterByID
+	userStorer                         UserStorer
+}
+
+func (ev emailVerifier) VerifyEmail(ctx context.Context, token string) error {
+	if len(token) == 0 {
+		return NewValidationError("token", "required")
+	}
+
+	emailVerificationToken, err := ev.emailVerificationTokenGetterByHash.GetEmailVerificationTokenByHash(
+		ctx,
+		HashToken(token),
+	)
+	if err != nil {
+		err = fmt.Errorf("getting email verification token by hash: %w", err)
+
+		if errors.Is(err, ErrEmailVerificationTokenNotFound) {
+			err = ErrEmailVerificationTokenInvalid
+		}
+
+		return err
+	}
+
+	now := ev.timer.Now()
+
+	if !emailVerificationToken.UsedAt.IsZero() || !now.Before(emailVerificationToken.ExpiresAt) {
+		return ErrEmailVerificationTokenInvalid
+	}
+
+	user, err := ev.userGetterByID.GetUserByID(ctx, emailVerificationToken.UserID)
+	if err != nil {
+		err = fmt.Errorf("getting user by id: %w", err)
+
+		if errors.Is(err, ErrUserNotFound) {
+			err = ErrEmailVerificationTokenInvalid
+		}
+
+		return err
+	}
+
+	if user.Email != emailVerificationToken.Email {
+		return ErrEmailVerificationTokenInvalid
+	}
+
+	err = ev.emailVerificationTokenConsumer.ConsumeEmailVerificationToken(ctx, emailVerificationToken.ID, now)
+	if err != nil {
+		return fmt.Errorf("consuming email verification token: %w", err)
+	}
+
+	if user.EmailVerified() {
+		return nil
+	}
+
+	user.EmailVerifiedAt = now
+	user.UpdatedAt = now
+
+	err = ev.userStorer.StoreUser(ctx, &user)
+	if err != nil {
+		return fmt.Errorf("storing user: %w", err)
+	}
+
+	return nil
+}
+
+func NewEmailVerifier(
+	timer Timer,
+	emailVerificationTokenGetterByHash EmailVerificationTokenGetterByHash,
+	emailVerificationTokenConsumer EmailVerificationTokenConsumer,
+	userGetterByID UserGetterByID,
+	userStorer UserStorer,
+) *emailVerifier {
+	return &emailVerifier{
+		timer:                              timer,
+		emailVerificationTokenGetterByHash: emailVerificationTokenGetterByHash,
+		emailVerificationTokenConsumer:     emailVerificationTokenConsumer,
+		userGetterByID:                     userGetterByID,
+		userStorer:                         userStorer,
+	}
+}
+
+type verifiedEmailUserAuthenticator struct {
+	userAuthenticator UserAuthenticator
+}
+
+func (va verifiedEmailUserAuthenticator) AuthenticateUser(ctx context.Context, req UserAuthenticationRequest) (User, error) {
+	user, err := va.userAuthenticator.AuthenticateUser(ctx, req)
+	if err != nil {
+		return User{}, err
+	}
+
+	if len(user.Email) > 0 && !user.EmailVerified() {
+		return User{}, ErrEmailNotVerified
+	}
+
+	return user, nil
+}
+
+func NewVerifiedEmailUserAuthenticator(userAuthenticator UserAuthenticator) *verifiedEmailUserAuthenticator {
+	return &verifiedEmailUserAuthenticator{
+		userAuthenticator: userAuthenticator,
+	}
+}
@@ -0,0 +1,169 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/adystag/jobs-search/internal"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type PresentableUser internal.User
+
+func (pu PresentableUser) MarshalJSON() ([]byte, error) {
+	roles := []string{}
+
+	for _, each := range pu.Roles {
+		roles = append(roles, string(each))
+	}
+
+	tmp := struct {
+		ID               int64      `json:"id"`
+		Username         string     `json:"username"`
+		Roles            []string   `json:"roles"`
+		TwoFactorEnabled bool       `json:"two_factor_enabled"`
+		Disabled         bool       `json:"disabled"`
+		DisabledAt       *time.Time `json:"disabled_at"`
+		CreatedAt        time.Time  `json:"created_at"`
+		UpdatedAt        time.Time  `json:"updated_at"`
+	}{
+		ID:               pu.ID,
+		Username:         pu.Username,
+		Roles:            roles,
+		TwoFactorEnabled: internal.User(pu).TwoFactorEnabled(),
+		Disabled:         internal.User(pu).Disabled(),
+		CreatedAt:        pu.CreatedAt,
+		UpdatedAt:        pu.UpdatedAt,
+	}
+
+	if tmp.Disabled {
+		tmp.DisabledAt = &pu.DisabledAt
+	}
+
+	b, err := json.Marshal(tmp)
+	if err != nil {
+		return nil, fmt.Errorf("marshalling user to json: %w", err)
+	}
+
+	return b, nil
+}
+
+func userIDFromParams(ctx *fiber.Ctx) (int64, error) {
+	userID, err := ctx.ParamsInt("userID")
+	if err != nil || userID <= 0 {
+		return 0, internal.NewValidationError("user_id", "numeric")
+	}
+
+	return int64(userID), nil
+}
+
+type UsersListingHandler struct {
+	usersLister internal.UsersLister
+}
+
+func (h UsersListingHandler) Handle(ctx *fiber.Ctx) error {
+	users, err := h.usersLister.ListUsers(ctx.Context())
+	if err != nil {
+		return fmt.Errorf("listing users: %w", err)
+	}
+
+	presentableUsers := []PresentableUser{}
+
+	for _, each := range users {
+		presentableUsers = append(presentableUsers, PresentableUser(each))
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(presentableUsers)
+}
+
+func NewUsersListingHandler(usersLister internal.UsersLister) *UsersListingHandler {
+	return &UsersListingHandler{
+		usersLister: usersLister,
+	}
+}
+
+type UserDisableHandler struct {
+	userDisabler internal.UserDisabler
+	disabled     bool
+}
+
+func (h UserDisableHandler) Handle(ctx *fiber.Ctx) error {
+	actorID, err := UserIDFromContext(ctx)
+	if err != nil {
+		return fmt.Errorf("getting user id from context: %w", err)
+	}
+
+	userID, err := userIDFromParams(ctx)
+	if err != nil {
+		return fmt.Errorf("getting user id from params: %w", err)
+	}
+
+	user, err := h.userDisabler.DisableUser(ctx.Context(), internal.UserDisableRequest{
+		ActorID:  actorID,
+		UserID:   userID,
+		Disabled: h.disabled,
+	})
+	if err != nil {
+		return fmt.Errorf("disabling user: %w", err)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(PresentableUser(user))
+}
+
+func NewUserDisableHandler(userDisabler internal.UserDisabler, disabled bool) *UserDisableHandler {
+	return &UserDisableHandler{
+		userDisabler: userDisabler,
+		disabled:     disabled,
+	}
+}
+
+type UserPasswordResetRequestHandler struct {
+	userPasswordResetRequester internal.UserPasswordResetRequester
+}
+
+func (h UserPasswordResetRequestHandler) Handle(ctx *fiber.Ctx) error {
+	userID, err := userIDFromParams(ctx)
+	if err != nil {
+		return fmt.Errorf("getting user id from params: %w", err)
+	}
+
+	err = h.userPasswordResetRequester.RequestUserPasswordReset(ctx.Context(), userID)
+	if err != nil {
+		return fmt.Errorf("requesting user password reset: %w", err)
+	}
+
+	return ctx.SendStatus(fiber.StatusAccepted)
+}
+
+func NewUserPasswordResetRequestHandler(
+	userPasswordResetRequester internal.UserPasswordResetRequester,
+) *UserPasswordResetRequestHandler {
+	return &UserPasswordResetRequestHandler{
+		userPasswordResetRequester: userPasswordResetRequester,
+	}
+}
+
+type JobCacheStatsHandler struct {
+	jobCacheStatsGetter internal.JobCacheStatsGetter
+}
+
+func (h JobCacheStatsHandler) Handle(ctx *fiber.Ctx) error {
+	jobCacheStats, err := h.jobCacheStatsGetter.GetJobCacheStats(ctx.Context())
+	if err != nil {
+		return fmt.Errorf("getting job cache stats: %w", err)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"hits":       jobCacheStats.Hits,
+		"stale_hits": jobCacheStats.StaleHits,
+		"misses":     jobCacheStats.Misses,
+	})
+}
+
+func NewJobCacheStatsHandler(jobCacheStatsGetter internal.JobCacheStatsGetter) *JobCacheStatsHandler {
+	return &JobCacheStatsHandler{
+		jobCacheStatsGetter: jobCacheStatsGetter,
+	}
+}
@@ -0,0 +1,173 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/adystag/jobs-search/internal"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type PresentableAPIKey internal.APIKey
+
+func (pk PresentableAPIKey) MarshalJSON() ([]byte, error) {
+	scopes := []string{}
+
+	for _, each := range pk.Scopes {
+		scopes = append(scopes, string(each))
+	}
+
+	tmp := struct {
+		ID         int64      `json:"id"`
+		Name       string     `json:"name"`
+		Prefix     string     `json:"prefix"`
+		Scopes     []string   `json:"scopes"`
+		ExpiresAt  *time.Time `json:"expires_at"`
+		LastUsedAt *time.Time `json:"last_used_at"`
+		CreatedAt  time.Time  `json:"created_at"`
+	}{
+		ID:        pk.ID,
+		Name:      pk.Name,
+		Prefix:    pk.Prefix,
+		Scopes:    scopes,
+		CreatedAt: pk.CreatedAt,
+	}
+
+	if !pk.ExpiresAt.IsZero() {
+		tmp.ExpiresAt = &pk.ExpiresAt
+	}
+
+	if !pk.LastUsedAt.IsZero() {
+		tmp.LastUsedAt = &pk.LastUsedAt
+	}
+
+	b, err := json.Marshal(tmp)
+	if err != nil {
+		return nil, fmt.Errorf("marshalling api key to json: %w", err)
+	}
+
+	return b, nil
+}
+
+func apiKeyIDFromParams(ctx *fiber.Ctx) (int64, error) {
+	apiKeyID, err := ctx.ParamsInt("apiKeyID")
+	if err != nil || apiKeyID <= 0 {
+		return 0, internal.NewValidationError("api_key_id", "numeric")
+	}
+
+	return int64(apiKeyID), nil
+}
+
+type APIKeysListingHandler struct {
+	apiKeysLister internal.APIKeysListerByUserID
+}
+
+func (h APIKeysListingHandler) Handle(ctx *fiber.Ctx) error {
+	userID, err := UserIDFromContext(ctx)
+	if err != nil {
+		return fmt.Errorf("getting user id from context: %w", err)
+	}
+
+	apiKeys, err := h.apiKeysLister.ListAPIKeysByUserID(ctx.Context(), userID)
+	if err != nil {
+		return fmt.Errorf("listing api keys by user id: %w", err)
+	}
+
+	presentableAPIKeys := []PresentableAPIKey{}
+
+	for _, each := range apiKeys {
+		presentableAPIKeys = append(presentableAPIKeys, PresentableAPIKey(each))
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(presentableAPIKeys)
+}
+
+func NewAPIKeysListingHandler(apiKeysLister internal.APIKeysListerByUserID) *APIKeysListingHandler {
+	return &APIKeysListingHandler{
+		apiKeysLister: apiKeysLister,
+	}
+}
+
+type APIKeyCreationHandler struct {
+	apiKeyCreator internal.APIKeyCreator
+}
+
+func (h APIKeyCreationHandler) Handle(ctx *fiber.Ctx) error {
+	userID, err := UserIDFromContext(ctx)
+	if err != nil {
+		return fmt.Errorf("getting user id from context: %w", err)
+	}
+
+	var apiKeyRequest struct {
+		Name      string     `json:"name"`
+		Scopes    []string   `json:"scopes"`
+		ExpiresAt *time.Time `json:"expires_at"`
+	}
+
+	err = ctx.BodyParser(&apiKeyRequest)
+	if err != nil {
+		return fmt.Errorf("parsing http api key request body: %w", err)
+	}
+
+	twoFactor, _ := ctx.Context().UserValue(TwoFactorContextValue).(bool)
+	req := internal.APIKeyRequest{
+		UserID:    userID,
+		Name:      apiKeyRequest.Name,
+		TwoFactor: twoFactor,
+	}
+
+	for _, each := range apiKeyRequest.Scopes {
+		req.Scopes = append(req.Scopes, internal.APIKeyScope(each))
+	}
+
+	if apiKeyRequest.ExpiresAt != nil {
+		req.ExpiresAt = *apiKeyRequest.ExpiresAt
+	}
+
+	apiKey, key, err := h.apiKeyCreator.CreateAPIKey(ctx.Context(), req)
+	if err != nil {
+		return fmt.Errorf("creating api key: %w", err)
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"api_key": PresentableAPIKey(apiKey),
+		"key":     key,
+	})
+}
+
+func NewAPIKeyCreationHandler(apiKeyCreator internal.APIKeyCreator) *APIKeyCreationHandler {
+	return &APIKeyCreationHandler{
+		apiKeyCreator: apiKeyCreator,
+	}
+}
+
+type APIKeyRevocationHandler struct {
+	apiKeyRevoker internal.APIKeyRevoker
+}
+
+func (h APIKeyRevocationHandler) Handle(ctx *fiber.Ctx) error {
+	userID, err := UserIDFromContext(ctx)
+	if err != nil {
+		return fmt.Errorf("getting user id from context: %w", err)
+	}
+
+	apiKeyID, err := apiKeyIDFromParams(ctx)
+	if err != nil {
+		return fmt.Errorf("getting api key id from params: %w", err)
+	}
+
+	err = h.apiKeyRevoker.RevokeAPIKey(ctx.Context(), userID, apiKeyID)
+	if err != nil {
+		return fmt.Errorf("revoking api key: %w", err)
+	}
+
+	return ctx.SendStatus(fiber.StatusNoContent)
+}
+
+func NewAPIKeyRevocationHandler(apiKeyRevoker internal.APIKeyRevoker) *APIKeyRevocationHandler {
+	return &APIKeyRevocationHandler{
+		apiKeyRevoker: apiKeyRevoker,
+	}
+}
@@ -0,0 +1,301 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/adystag/jobs-search/internal"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type PresentableApplicationStatusChange internal.ApplicationStatusChange
+
+func (pc PresentableApplicationStatusChange) MarshalJSON() ([]byte, error) {
+	tmp := struct {
+		From      *string   `json:"from"`
+		To        string    `json:"to"`
+		Note      string    `json:"note"`
+		ChangedAt time.Time `json:"changed_at"`
+	}{
+		To:        string(pc.To),
+		Note:      pc.Note,
+		ChangedAt: pc.ChangedAt,
+	}
+
+	if len(pc.From) > 0 {
+		from := string(pc.From)
+		tmp.From = &from
+	}
+
+	b, err := json.Marshal(tmp)
+	if err != nil {
+		return nil, fmt.Errorf("marshalling application status change to json: %w", err)
+	}
+
+	return b, nil
+}
+
+type PresentableApplication internal.Application
+
+func (pa PresentableApplication) MarshalJSON() ([]byte, error) {
+	history := []PresentableApplicationStatusChange{}
+
+	for _, each := range pa.History {
+		history = append(history, PresentableApplicationStatusChange(each))
+	}
+
+	tmp := struct {
+		ID        int64                                `json:"id"`
+		JobID     string                               `json:"job_id"`
+		Status    string                               `json:"status"`
+		Notes     string                               `json:"notes"`
+		History   []PresentableApplicationStatusChange `json:"history"`
+		CreatedAt time.Time                            `json:"created_at"`
+		UpdatedAt time.Time                            `json:"updated_at"`
+	}{
+		ID:        pa.ID,
+		JobID:     pa.JobID.String(),
+		Status:    string(pa.Status),
+		Notes:     pa.Notes,
+		History:   history,
+		CreatedAt: pa.CreatedAt,
+		UpdatedAt: pa.UpdatedAt,
+	}
+
+	b, err := json.Marshal(tmp)
+	if err != nil {
+		return nil, fmt.Errorf("marshalling application to json: %w", err)
+	}
+
+	return b, nil
+}
+
+func applicationIDFromParams(ctx *fiber.Ctx) (int64, error) {
+	applicationID, err := ctx.ParamsInt("applicationID")
+	if err != nil || applicationID <= 0 {
+		return 0, internal.NewValidationError("application_id", "numeric")
+	}
+
+	return int64(applicationID), nil
+}
+
+type ApplicationsListingHandler struct {
+	applicationsLister internal.ApplicationsListerByUserID
+}
+
+func (h ApplicationsListingHandler) Handle(ctx *fiber.Ctx) error {
+	userID, err := UserIDFromContext(ctx)
+	if err != nil {
+		return fmt.Errorf("getting user id from context: %w", err)
+	}
+
+	applications, err := h.applicationsLister.ListApplicationsByUserID(ctx.Context(), userID)
+	if err != nil {
+		return fmt.Errorf("listing applications by user id: %w", err)
+	}
+
+	presentableApplications := []PresentableApplication{}
+
+	for _, each := range applications {
+		presentableApplications = append(presentableApplications, PresentableApplication(each))
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(presentableApplications)
+}
+
+func NewApplicationsListingHandler(applicationsLister internal.ApplicationsListerByUserID) *ApplicationsListingHandler {
+	return &ApplicationsListingHandler{
+		applicationsLister: applicationsLister,
+	}
+}
+
+type ApplicationCreationHandler struct {
+	applicationCreator internal.ApplicationCreator
+}
+
+func (h ApplicationCreationHandler) Handle(ctx *fiber.Ctx) error {
+	userID, err := UserIDFromContext(ctx)
+	if err != nil {
+		return fmt.Errorf("getting user id from context: %w", err)
+	}
+
+	var applicationRequest struct {
+		JobID  string `json:"job_id"`
+		Status string `json:"status"`
+		Notes  string `json:"notes"`
+	}
+
+	err = ctx.BodyParser(&applicationRequest)
+	if err != nil {
+		return fmt.Errorf("parsing http application request body: %w", err)
+	}
+
+	application, err := h.applicationCreator.CreateApplication(ctx.Context(), internal.ApplicationRequest{
+		UserID: userID,
+		JobID:  applicationRequest.JobID,
+		Status: internal.ApplicationStatus(applicationRequest.Status),
+		Notes:  applicationRequest.Notes,
+	})
+	if err != nil {
+		return fmt.Errorf("creating application: %w", err)
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(PresentableApplication(application))
+}
+
+func NewApplicationCreationHandler(applicationCreator internal.ApplicationCreator) *ApplicationCreationHandler {
+	return &ApplicationCreationHandler{
+		applicationCreator: applicationCreator,
+	}
+}
+
+type ApplicationGetterByIDHandler struct {
+	applicationGetterByID internal.ApplicationGetterByID
+}
+
+func (h ApplicationGetterByIDHandler) Handle(ctx *fiber.Ctx) error {
+	userID, err := UserIDFromContext(ctx)
+	if err != nil {
+		return fmt.Errorf("getting user id from context: %w", err)
+	}
+
+	applicationID, err := applicationIDFromParams(ctx)
+	if err != nil {
+		return fmt.Errorf("getting application id from params: %w", err)
+	}
+
+	application, err := h.applicationGetterByID.GetApplicationByID(ctx.Context(), userID, applicationID)
+	if err != nil {
+		return fmt.Errorf("getting application by id: %w", err)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(PresentableApplication(application))
+}
+
+func NewApplicationGetterByIDHandler(applicationGetterByID internal.ApplicationGetterByID) *ApplicationGetterByIDHandler {
+	return &ApplicationGetterByIDHandler{
+		applicationGetterByID: applicationGetterByID,
+	}
+}
+
+type ApplicationUpdateHandler struct {
+	applicationUpdater internal.ApplicationUpdater
+}
+
+func (h ApplicationUpdateHandler) Handle(ctx *fiber.Ctx) error {
+	userID, err := UserIDFromContext(ctx)
+	if err != nil {
+		return fmt.Errorf("getting user id from context: %w", err)
+	}
+
+	applicationID, err := applicationIDFromParams(ctx)
+	if err != nil {
+		return fmt.Errorf("getting application id from params: %w", err)
+	}
+
+	var applicationUpdateRequest struct {
+		Notes string `json:"notes"`
+	}
+
+	err = ctx.BodyParser(&applicationUpdateRequest)
+	if err != nil {
+		return fmt.Errorf("parsing http application update request body: %w", err)
+	}
+
+	application, err := h.applicationUpdater.UpdateApplication(ctx.Context(), internal.ApplicationUpdateRequest{
+		UserID:        userID,
+		ApplicationID: applicationID,
+		Notes:         applicationUpdateRequest.Notes,
+	})
+	if err != nil {
+		return fmt.Errorf("updating application: %w", err)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(PresentableApplication(application))
+}
+
+func NewApplicationUpdateHandler(applicationUpdater internal.ApplicationUpdater) *ApplicationUpdateHandler {
+	return &ApplicationUpdateHandler{
+		applicationUpdater: applicationUpdater,
+	}
+}
+
+type ApplicationStatusTransitionHandler struct {
+	applicationStatusTransitioner internal.ApplicationStatusTransitioner
+}
+
+func (h ApplicationStatusTransitionHandler) Handle(ctx *fiber.Ctx) error {
+	userID, err := UserIDFromContext(ctx)
+	if err != nil {
+		return fmt.Errorf("getting user id from context: %w", err)
+	}
+
+	applicationID, err := applicationIDFromParams(ctx)
+	if err != nil {
+		return fmt.Errorf("getting application id from params: %w", err)
+	}
+
+	var applicationStatusTransitionRequest struct {
+		Status string `json:"status"`
+		Note   string `json:"note"`
+	}
+
+	err = ctx.BodyParser(&applicationStatusTransitionRequest)
+	if err != nil {
+		return fmt.Errorf("parsing http application status transition request body: %w", err)
+	}
+
+	application, err := h.applicationStatusTransitioner.TransitionApplicationStatus(
+		ctx.Context(),
+		internal.ApplicationStatusTransitionRequest{
+			UserID:        userID,
+			ApplicationID: applicationID,
+			Status:        internal.ApplicationStatus(applicationStatusTransitionRequest.Status),
+			Note:          applicationStatusTransitionRequest.Note,
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("transitioning application status: %w", err)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(PresentableApplication(application))
+}
+
+func NewApplicationStatusTransitionHandler(
+	applicationStatusTransitioner internal.ApplicationStatusTransitioner,
+) *ApplicationStatusTransitionHandler {
+	return &ApplicationStatusTransitionHandler{
+		applicationStatusTransitioner: applicationStatusTransitioner,
+	}
+}
+
+type ApplicationDeletionHandler struct {
+	applicationDeleter internal.ApplicationDeleter
+}
+
+func (h ApplicationDeletionHandler) Handle(ctx *fiber.Ctx) error {
+	userID, err := UserIDFromContext(ctx)
+	if err != nil {
+		return fmt.Errorf("getting user id from context: %w", err)
+	}
+
+	applicationID, err := applicationIDFromParams(ctx)
+	if err != nil {
+		return fmt.Errorf("getting application id from params: %w", err)
+	}
+
+	err = h.applicationDeleter.DeleteApplication(ctx.Context(), userID, applicationID)
+	if err != nil {
+		return fmt.Errorf("deleting application: %w", err)
+	}
+
+	return ctx.SendStatus(fiber.StatusNoContent)
+}
+
+func NewApplicationDeletionHandler(applicationDeleter internal.ApplicationDeleter) *ApplicationDeletionHandler {
+	return &ApplicationDeletionHandler{
+		applicationDeleter: applicationDeleter,
+	}
+}
@@ -1,14 +1,36 @@
 package http
 
 import (
+	"errors"
+	"fmt"
 	"strconv"
 	"strings"
+	"time"
+
+	"github.com/adystag/jobs-search/internal"
 
 	"github.com/dgrijalva/jwt-go"
 	"github.com/gofiber/fiber/v2"
 )
 
-var UserIDContextValue = ContextValueKey{"UserID"}
+var (
+	ErrMissingAccessToken = errors.New("access token is missing")
+	ErrInvalidAccessToken = errors.New("access token is invalid")
+	ErrForbidden          = errors.New("permission is not granted")
+	ErrInsufficientScope  = errors.New("api key scope is insufficient")
+	ErrTwoFactorRequired  = internal.ErrTwoFactorRequired
+)
+
+const HeaderAPIKey = "X-API-Key"
+
+var (
+	UserIDContextValue               = ContextValueKey{"UserID"}
+	AccessTokenIDContextValue        = ContextValueKey{"AccessTokenID"}
+	AccessTokenExpiresAtContextValue = ContextValueKey{"AccessTokenExpiresAt"}
+	RolesContextValue                = ContextValueKey{"Roles"}
+	APIKeyContextValue               = ContextValueKey{"APIKey"}
+	TwoFactorContextValue            = ContextValueKey{"TwoFactor"}
+)
 
 type ContextValueKey struct {
 	s string
@@ -18,51 +40,299 @@ func (c ContextValueKey) String() string {
 	return c.s
 }
 
+const AuthenticationMethodMFA = "mfa"
+
+type AccessTokenClaims struct {
+	jwt.StandardClaims
+	TokenVersion          int64    `json:"ver"`
+	Roles                 []string `json:"roles,omitempty"`
+	AuthenticationMethods []string `json:"amr,omitempty"`
+}
+
+func (c AccessTokenClaims) HasAuthenticationMethod(method string) bool {
+	for _, each := range c.AuthenticationMethods {
+		if each == method {
+			return true
+		}
+	}
+
+	return false
+}
+
 type JWTAuthenticationMiddleware struct {
-	secret []byte
+	timer                        internal.Timer
+	keySet                       *JWTKeySet
+	issuer                       string
+	leeway                       time.Duration
+	legacyAcceptedUntil          time.Time
+	userGetterByID               internal.UserGetterByID
+	accessTokenRevocationChecker internal.AccessTokenRevocationChecker
 }
 
 func (m JWTAuthenticationMiddleware) Handle(ctx *fiber.Ctx) error {
-	authHeader := ctx.GetReqHeaders()[fiber.HeaderAuthorization]
+	accessToken, err := m.bearerToken(ctx.Get(fiber.HeaderAuthorization))
+	if err != nil {
+		return err
+	}
 
-	if !strings.Contains(authHeader, "Bearer") {
-		return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-			"error": "unauthorized",
-		})
+	claims := AccessTokenClaims{}
+	parser := jwt.Parser{
+		ValidMethods:         m.keySet.ValidMethods(),
+		SkipClaimsValidation: true,
+	}
+	_, err = parser.ParseWithClaims(accessToken, &claims, m.keySet.Keyfunc)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidAccessToken, err)
 	}
 
-	authHeaderComps := strings.SplitN(authHeader, " ", 2)
+	now := m.timer.Now()
 
-	if len(authHeaderComps) < 2 {
-		return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-			"error": "unauthorized",
-		})
+	err = m.validateClaims(claims, now)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidAccessToken, err)
 	}
 
-	claims := jwt.StandardClaims{}
-	_, err := jwt.ParseWithClaims(authHeaderComps[1], &claims, func(t *jwt.Token) (interface{}, error) {
-		return m.secret, nil
-	})
+	userID, err := m.userID(claims, now)
 	if err != nil {
-		return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-			"error": "unauthorized",
-		})
+		return fmt.Errorf("%w: %w", ErrInvalidAccessToken, err)
 	}
 
-	userID, err := strconv.ParseInt(claims.Audience, 10, 64)
+	if len(claims.Id) > 0 {
+		revoked, err := m.accessTokenRevocationChecker.IsAccessTokenRevoked(ctx.Context(), claims.Id)
+		if err != nil {
+			return fmt.Errorf("checking access token revocation: %w", err)
+		}
+
+		if revoked {
+			return fmt.Errorf("%w: access token has been revoked", ErrInvalidAccessToken)
+		}
+	}
+
+	user, err := m.userGetterByID.GetUserByID(ctx.Context(), userID)
 	if err != nil {
-		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		if errors.Is(err, internal.ErrUserNotFound) {
+			err = fmt.Errorf("%w: %w", ErrInvalidAccessToken, err)
+		}
+
+		return fmt.Errorf("getting user by id: %w", err)
+	}
+
+	if user.TokenVersion != claims.TokenVersion {
+		return fmt.Errorf("%w: access token version is outdated", ErrInvalidAccessToken)
+	}
+
+	if user.Disabled() {
+		return internal.ErrUserDisabled
 	}
 
 	ctx.Context().SetUserValue(UserIDContextValue, userID)
+	ctx.Context().SetUserValue(AccessTokenIDContextValue, claims.Id)
+	ctx.Context().SetUserValue(AccessTokenExpiresAtContextValue, time.Unix(claims.ExpiresAt, 0))
+	ctx.Context().SetUserValue(RolesContextValue, user.Roles)
+	ctx.Context().SetUserValue(
+		TwoFactorContextValue,
+		user.TwoFactorEnabled() && claims.HasAuthenticationMethod(AuthenticationMethodMFA),
+	)
 
 	return ctx.Next()
 }
 
-func NewJWTAuthenticationMiddleware(secret []byte) *JWTAuthenticationMiddleware {
+func (m JWTAuthenticationMiddleware) bearerToken(authHeader string) (string, error) {
+	if len(strings.TrimSpace(authHeader)) == 0 {
+		return "", ErrMissingAccessToken
+	}
+
+	authHeaderComps := strings.Fields(authHeader)
+
+	if len(authHeaderComps) != 2 || !strings.EqualFold(authHeaderComps[0], "Bearer") {
+		return "", fmt.Errorf("%w: authorization header is not a bearer token", ErrInvalidAccessToken)
+	}
+
+	return authHeaderComps[1], nil
+}
+
+func (m JWTAuthenticationMiddleware) validateClaims(claims AccessTokenClaims, now time.Time) error {
+	if claims.ExpiresAt == 0 {
+		return errors.New("token has no expiration")
+	}
+
+	if now.After(time.Unix(claims.ExpiresAt, 0).Add(m.leeway)) {
+		return errors.New("token is expired")
+	}
+
+	if claims.NotBefore != 0 && now.Add(m.leeway).Before(time.Unix(claims.NotBefore, 0)) {
+		return errors.New("token is not valid yet")
+	}
+
+	if claims.IssuedAt != 0 && now.Add(m.leeway).Before(time.Unix(claims.IssuedAt, 0)) {
+		return errors.New("token is used before issued")
+	}
+
+	if claims.Issuer != m.issuer {
+		return errors.New("token issuer is unknown")
+	}
+
+	return nil
+}
+
+func (m JWTAuthenticationMiddleware) userID(claims AccessTokenClaims, now time.Time) (int64, error) {
+	subject := claims.Subject
+
+	if len(subject) == 0 {
+		if !now.Before(m.legacyAcceptedUntil) {
+			return 0, errors.New("token has no subject")
+		}
+
+		subject = claims.Audience
+	}
+
+	userID, err := strconv.ParseInt(subject, 10, 64)
+	if err != nil || userID <= 0 {
+		return 0, errors.New("token subject is not a user id")
+	}
+
+	return userID, nil
+}
+
+func UserIDFromContext(ctx *fiber.Ctx) (int64, error) {
+	userID, ok := ctx.Context().UserValue(UserIDContextValue).(int64)
+	if !ok {
+		return 0, ErrMissingAccessToken
+	}
+
+	return userID, nil
+}
+
+func NewJWTAuthenticationMiddleware(
+	timer internal.Timer,
+	keySet *JWTKeySet,
+	issuer string,
+	leeway time.Duration,
+	legacyWindow time.Duration,
+	userGetterByID internal.UserGetterByID,
+	accessTokenRevocationChecker internal.AccessTokenRevocationChecker,
+) *JWTAuthenticationMiddleware {
 	return &JWTAuthenticationMiddleware{
-		secret: secret,
+		timer:                        timer,
+		keySet:                       keySet,
+		issuer:                       issuer,
+		leeway:                       leeway,
+		legacyAcceptedUntil:          timer.Now().Add(legacyWindow),
+		userGetterByID:               userGetterByID,
+		accessTokenRevocationChecker: accessTokenRevocationChecker,
 	}
 }
+
+type PermissionMiddleware struct {
+	permission internal.Permission
+}
+
+func (m PermissionMiddleware) Handle(ctx *fiber.Ctx) error {
+	roles, _ := ctx.Context().UserValue(RolesContextValue).([]internal.Role)
+
+	for _, each := range roles {
+		if each.HasPermission(m.permission) {
+			return ctx.Next()
+		}
+	}
+
+	return fmt.Errorf("%w: %s", ErrForbidden, m.permission)
+}
+
+func RequirePermission(permission internal.Permission) fiber.Handler {
+	return NewPermissionMiddleware(permission).Handle
+}
+
+func NewPermissionMiddleware(permission internal.Permission) *PermissionMiddleware {
+	return &PermissionMiddleware{
+		permission: permission,
+	}
+}
+
+type AuthenticationMiddleware struct {
+	jwtAuthenticationMiddleware *JWTAuthenticationMiddleware
+	apiKeyAuthenticator         internal.APIKeyAuthenticator
+}
+
+func (m AuthenticationMiddleware) Handle(ctx *fiber.Ctx) error {
+	key := strings.TrimSpace(ctx.Get(HeaderAPIKey))
+
+	if len(key) == 0 {
+		return m.jwtAuthenticationMiddleware.Handle(ctx)
+	}
+
+	apiKey, user, err := m.apiKeyAuthenticator.AuthenticateAPIKey(ctx.Context(), key)
+	if err != nil {
+		return fmt.Errorf("authenticating api key: %w", err)
+	}
+
+	ctx.Context().SetUserValue(UserIDContextValue, user.ID)
+	ctx.Context().SetUserValue(RolesContextValue, user.Roles)
+	ctx.Context().SetUserValue(APIKeyContextValue, apiKey)
+	ctx.Context().SetUserValue(TwoFactorContextValue, false)
+
+	return ctx.Next()
+}
+
+func NewAuthenticationMiddleware(
+	jwtAuthenticationMiddleware *JWTAuthenticationMiddleware,
+	apiKeyAuthenticator internal.APIKeyAuthenticator,
+) *AuthenticationMiddleware {
+	return &AuthenticationMiddleware{
+		jwtAuthenticationMiddleware: jwtAuthenticationMiddleware,
+		apiKeyAuthenticator:         apiKeyAuthenticator,
+	}
+}
+
+type ScopeMiddleware struct {
+	resource string
+}
+
+func (m ScopeMiddleware) Handle(ctx *fiber.Ctx) error {
+	apiKey, ok := ctx.Context().UserValue(APIKeyContextValue).(internal.APIKey)
+	if !ok {
+		return ctx.Next()
+	}
+
+	scope := internal.APIKeyScope(m.resource + ":write")
+
+	if ctx.Method() == fiber.MethodGet || ctx.Method() == fiber.MethodHead {
+		scope = internal.APIKeyScope(m.resource + ":read")
+	}
+
+	if !apiKey.HasScope(scope) {
+		return fmt.Errorf("%w: %s", ErrInsufficientScope, scope)
+	}
+
+	return ctx.Next()
+}
+
+func RequireScope(resource string) fiber.Handler {
+	return NewScopeMiddleware(resource).Handle
+}
+
+func NewScopeMiddleware(resource string) *ScopeMiddleware {
+	return &ScopeMiddleware{
+		resource: resource,
+	}
+}
+
+type TwoFactorMiddleware struct{}
+
+func (m TwoFactorMiddleware) Handle(ctx *fiber.Ctx) error {
+	twoFactorEnabled, _ := ctx.Context().UserValue(TwoFactorContextValue).(bool)
+
+	if !twoFactorEnabled {
+		return ErrTwoFactorRequired
+	}
+
+	return ctx.Next()
+}
+
+func RequireTwoFactor() fiber.Handler {
+	return NewTwoFactorMiddleware().Handle
+}
+
+func NewTwoFactorMiddleware() *TwoFactorMiddleware {
+	return &TwoFactorMiddleware{}
+}
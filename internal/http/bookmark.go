@@ -0,0 +1,141 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/adystag/jobs-search/internal"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type PresentableBookmark internal.Bookmark
+
+func (pb PresentableBookmark) MarshalJSON() ([]byte, error) {
+	tags := pb.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+
+	tmp := struct {
+		ID        int64          `json:"id"`
+		JobID     string         `json:"job_id"`
+		Note      string         `json:"note"`
+		Tags      []string       `json:"tags"`
+		Job       PresentableJob `json:"job"`
+		Removed   bool           `json:"removed"`
+		CreatedAt time.Time      `json:"created_at"`
+		UpdatedAt time.Time      `json:"updated_at"`
+	}{
+		ID:        pb.ID,
+		JobID:     pb.JobID.String(),
+		Note:      pb.Note,
+		Tags:      tags,
+		Job:       PresentableJob(pb.Job),
+		Removed:   pb.Removed,
+		CreatedAt: pb.CreatedAt,
+		UpdatedAt: pb.UpdatedAt,
+	}
+
+	b, err := json.Marshal(tmp)
+	if err != nil {
+		return nil, fmt.Errorf("marshalling bookmark to json: %w", err)
+	}
+
+	return b, nil
+}
+
+type BookmarksListingHandler struct {
+	bookmarksLister internal.BookmarksLister
+}
+
+func (h BookmarksListingHandler) Handle(ctx *fiber.Ctx) error {
+	userID, err := UserIDFromContext(ctx)
+	if err != nil {
+		return fmt.Errorf("getting user id from context: %w", err)
+	}
+
+	bookmarks, err := h.bookmarksLister.ListBookmarks(ctx.Context(), userID)
+	if err != nil {
+		return fmt.Errorf("listing bookmarks: %w", err)
+	}
+
+	presentableBookmarks := []PresentableBookmark{}
+
+	for _, each := range bookmarks {
+		presentableBookmarks = append(presentableBookmarks, PresentableBookmark(each))
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(presentableBookmarks)
+}
+
+func NewBookmarksListingHandler(bookmarksLister internal.BookmarksLister) *BookmarksListingHandler {
+	return &BookmarksListingHandler{
+		bookmarksLister: bookmarksLister,
+	}
+}
+
+type BookmarkCreationHandler struct {
+	bookmarkCreator internal.BookmarkCreator
+}
+
+func (h BookmarkCreationHandler) Handle(ctx *fiber.Ctx) error {
+	userID, err := UserIDFromContext(ctx)
+	if err != nil {
+		return fmt.Errorf("getting user id from context: %w", err)
+	}
+
+	var bookmarkRequest struct {
+		JobID string   `json:"job_id"`
+		Note  string   `json:"note"`
+		Tags  []string `json:"tags"`
+	}
+
+	err = ctx.BodyParser(&bookmarkRequest)
+	if err != nil {
+		return fmt.Errorf("parsing http bookmark request body: %w", err)
+	}
+
+	bookmark, err := h.bookmarkCreator.CreateBookmark(ctx.Context(), internal.BookmarkRequest{
+		UserID: userID,
+		JobID:  bookmarkRequest.JobID,
+		Note:   bookmarkRequest.Note,
+		Tags:   bookmarkRequest.Tags,
+	})
+	if err != nil {
+		return fmt.Errorf("creating bookmark: %w", err)
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(PresentableBookmark(bookmark))
+}
+
+func NewBookmarkCreationHandler(bookmarkCreator internal.BookmarkCreator) *BookmarkCreationHandler {
+	return &BookmarkCreationHandler{
+		bookmarkCreator: bookmarkCreator,
+	}
+}
+
+type BookmarkDeletionHandler struct {
+	bookmarkDeleter internal.BookmarkDeleter
+}
+
+func (h BookmarkDeletionHandler) Handle(ctx *fiber.Ctx) error {
+	userID, err := UserIDFromContext(ctx)
+	if err != nil {
+		return fmt.Errorf("getting user id from context: %w", err)
+	}
+
+	err = h.bookmarkDeleter.DeleteBookmark(ctx.Context(), userID, ctx.Params("jobID"))
+	if err != nil {
+		return fmt.Errorf("deleting bookmark: %w", err)
+	}
+
+	return ctx.SendStatus(fiber.StatusNoContent)
+}
+
+func NewBookmarkDeletionHandler(bookmarkDeleter internal.BookmarkDeleter) *BookmarkDeletionHandler {
+	return &BookmarkDeletionHandler{
+		bookmarkDeleter: bookmarkDeleter,
+	}
+}
@@ -0,0 +1,69 @@
+package http
+
+import (
+	"fmt"
+
+	"github.com/adystag/jobs-search/internal"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type EmailVerificationRequestHandler struct {
+	emailVerificationRequester internal.EmailVerificationRequester
+}
+
+func (h EmailVerificationRequestHandler) Handle(ctx *fiber.Ctx) error {
+	var emailVerificationRequest struct {
+		Username string `json:"username"`
+	}
+
+	err := ctx.BodyParser(&emailVerificationRequest)
+	if err != nil {
+		return fmt.Errorf("parsing http email verification request body: %w", err)
+	}
+
+	err = h.emailVerificationRequester.RequestEmailVerification(ctx.Context(), internal.EmailVerificationRequest{
+		Username: emailVerificationRequest.Username,
+	})
+	if err != nil {
+		return fmt.Errorf("requesting email verification: %w", err)
+	}
+
+	return ctx.SendStatus(fiber.StatusAccepted)
+}
+
+func NewEmailVerificationRequestHandler(
+	emailVerificationRequester internal.EmailVerificationRequester,
+) *EmailVerificationRequestHandler {
+	return &EmailVerificationRequestHandler{
+		emailVerificationRequester: emailVerificationRequester,
+	}
+}
+
+type EmailVerificationHandler struct {
+	emailVerifier internal.EmailVerifier
+}
+
+func (h EmailVerificationHandler) Handle(ctx *fiber.Ctx) error {
+	var emailVerificationConfirmationRequest struct {
+		Token string `json:"token"`
+	}
+
+	err := ctx.BodyParser(&emailVerificationConfirmationRequest)
+	if err != nil {
+		return fmt.Errorf("parsing http email verification confirmation request body: %w", err)
+	}
+
+	err = h.emailVerifier.VerifyEmail(ctx.Context(), emailVerificationConfirmationRequest.Token)
+	if err != nil {
+		return fmt.Errorf("verifying email: %w", err)
+	}
+
+	return ctx.SendStatus(fiber.StatusNoContent)
+}
+
+func NewEmailVerificationHandler(emailVerifier internal.EmailVerifier) *EmailVerificationHandler {
+	return &EmailVerificationHandler{
+		emailVerifier: emailVerifier,
+	}
+}
@@ -0,0 +1,357 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/adystag/jobs-search/internal"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/utils"
+)
+
+type ErrorResponse struct {
+	Code    string               `json:"code"`
+	Message string               `json:"message"`
+	Fields  []FieldErrorResponse `json:"fields,omitempty"`
+}
+
+type FieldErrorResponse struct {
+	Field   string `json:"field"`
+	Tag     string `json:"tag"`
+	Message string `json:"message"`
+}
+
+type ErrorHandler struct {
+	messageCatalog *MessageCatalog
+}
+
+func (h ErrorHandler) Handle(ctx *fiber.Ctx, err error) error {
+	status, res := h.EvaluateError(err)
+
+	if len(res.Fields) > 0 {
+		locale := h.messageCatalog.Negotiate(ctx.Get(fiber.HeaderAcceptLanguage))
+
+		for index, each := range res.Fields {
+			res.Fields[index].Message = h.messageCatalog.Translate(locale, each.Field, each.Tag)
+		}
+
+		ctx.Set(fiber.HeaderContentLanguage, locale)
+		ctx.Vary(fiber.HeaderAcceptLanguage)
+	}
+
+	if status >= fiber.StatusInternalServerError {
+		log.Printf("%s %s: %s\n", ctx.Method(), ctx.OriginalURL(), err)
+	}
+
+	if errors.Is(err, ErrMissingAccessToken) {
+		ctx.Set(fiber.HeaderWWWAuthenticate, `Bearer`)
+	}
+
+	if errors.Is(err, ErrInvalidAccessToken) {
+		ctx.Set(fiber.HeaderWWWAuthenticate, `Bearer error="invalid_token"`)
+	}
+
+	var retryAfterError internal.RetryAfterError
+
+	if errors.As(err, &retryAfterError) {
+		ctx.Set(fiber.HeaderRetryAfter, strconv.Itoa(int(math.Ceil(retryAfterError.RetryAfter().Seconds()))))
+	}
+
+	return ctx.Status(status).JSON(fiber.Map{
+		"error": res,
+	})
+}
+
+func (h ErrorHandler) EvaluateError(err error) (int, ErrorResponse) {
+	var validationErrors internal.ValidationErrors
+
+	if errors.As(err, &validationErrors) {
+		fields := []FieldErrorResponse{}
+
+		for _, each := range validationErrors {
+			fields = append(fields, FieldErrorResponse{
+				Field: each.Field(),
+				Tag:   each.Tag(),
+			})
+		}
+
+		return fiber.StatusUnprocessableEntity, ErrorResponse{
+			Code:    "validation_failed",
+			Message: "request validation failed",
+			Fields:  fields,
+		}
+	}
+
+	if errors.Is(err, internal.ErrUnauthenticated) {
+		return fiber.StatusUnauthorized, ErrorResponse{
+			Code:    "unauthenticated",
+			Message: internal.ErrUnauthenticated.Error(),
+		}
+	}
+
+	if errors.Is(err, internal.ErrAccountLocked) {
+		return fiber.StatusLocked, ErrorResponse{
+			Code:    "account_locked",
+			Message: internal.ErrAccountLocked.Error(),
+		}
+	}
+
+	if errors.Is(err, internal.ErrLoginThrottled) {
+		return fiber.StatusTooManyRequests, ErrorResponse{
+			Code:    "login_throttled",
+			Message: internal.ErrLoginThrottled.Error(),
+		}
+	}
+
+	if errors.Is(err, internal.ErrRefreshTokenReused) {
+		return fiber.StatusUnauthorized, ErrorResponse{
+			Code:    "refresh_token_reused",
+			Message: internal.ErrRefreshTokenReused.Error(),
+		}
+	}
+
+	if errors.Is(err, internal.ErrRefreshTokenInvalid) {
+		return fiber.StatusUnauthorized, ErrorResponse{
+			Code:    "invalid_refresh_token",
+			Message: internal.ErrRefreshTokenInvalid.Error(),
+		}
+	}
+
+	if errors.Is(err, internal.ErrPasswordResetTokenInvalid) {
+		return fiber.StatusBadRequest, ErrorResponse{
+			Code:    "invalid_password_reset_token",
+			Message: internal.ErrPasswordResetTokenInvalid.Error(),
+		}
+	}
+
+	if errors.Is(err, ErrMissingAccessToken) {
+		return fiber.StatusUnauthorized, ErrorResponse{
+			Code:    "missing_access_token",
+			Message: ErrMissingAccessToken.Error(),
+		}
+	}
+
+	if errors.Is(err, ErrInvalidAccessToken) {
+		return fiber.StatusUnauthorized, ErrorResponse{
+			Code:    "invalid_access_token",
+			Message: ErrInvalidAccessToken.Error(),
+		}
+	}
+
+	if errors.Is(err, internal.ErrAPIKeyInvalid) {
+		return fiber.StatusUnauthorized, ErrorResponse{
+			Code:    "invalid_api_key",
+			Message: internal.ErrAPIKeyInvalid.Error(),
+		}
+	}
+
+	if errors.Is(err, internal.ErrEmailVerificationTokenInvalid) {
+		return fiber.StatusBadRequest, ErrorResponse{
+			Code:    "invalid_email_verification_token",
+			Message: internal.ErrEmailVerificationTokenInvalid.Error(),
+		}
+	}
+
+	if errors.Is(err, internal.ErrEmailNotVerified) {
+		return fiber.StatusForbidden, ErrorResponse{
+			Code:    "email_not_verified",
+			Message: internal.ErrEmailNotVerified.Error(),
+		}
+	}
+
+	if errors.Is(err, internal.ErrEmailAlreadyVerified) {
+		return fiber.StatusConflict, ErrorResponse{
+			Code:    "email_already_verified",
+			Message: internal.ErrEmailAlreadyVerified.Error(),
+		}
+	}
+
+	if errors.Is(err, internal.ErrOIDCAuthorizationInvalid) {
+		return fiber.StatusBadRequest, ErrorResponse{
+			Code:    "invalid_oidc_authorization",
+			Message: internal.ErrOIDCAuthorizationInvalid.Error(),
+		}
+	}
+
+	if errors.Is(err, internal.ErrOIDCAuthenticationFailed) {
+		return fiber.StatusUnauthorized, ErrorResponse{
+			Code:    "oidc_authentication_failed",
+			Message: internal.ErrOIDCAuthenticationFailed.Error(),
+		}
+	}
+
+	if errors.Is(err, internal.ErrTwoFactorChallengeInvalid) {
+		return fiber.StatusUnauthorized, ErrorResponse{
+			Code:    "invalid_two_factor_challenge",
+			Message: internal.ErrTwoFactorChallengeInvalid.Error(),
+		}
+	}
+
+	if errors.Is(err, internal.ErrTwoFactorCodeInvalid) {
+		return fiber.StatusUnauthorized, ErrorResponse{
+			Code:    "invalid_two_factor_code",
+			Message: internal.ErrTwoFactorCodeInvalid.Error(),
+		}
+	}
+
+	if errors.Is(err, internal.ErrTwoFactorAlreadyEnabled) {
+		return fiber.StatusConflict, ErrorResponse{
+			Code:    "two_factor_already_enabled",
+			Message: internal.ErrTwoFactorAlreadyEnabled.Error(),
+		}
+	}
+
+	if errors.Is(err, internal.ErrTwoFactorNotEnabled) {
+		return fiber.StatusConflict, ErrorResponse{
+			Code:    "two_factor_not_enabled",
+			Message: internal.ErrTwoFactorNotEnabled.Error(),
+		}
+	}
+
+	if errors.Is(err, internal.ErrTwoFactorNotEnrolled) {
+		return fiber.StatusConflict, ErrorResponse{
+			Code:    "two_factor_not_enrolled",
+			Message: internal.ErrTwoFactorNotEnrolled.Error(),
+		}
+	}
+
+	if errors.Is(err, internal.ErrUserDisabled) {
+		return fiber.StatusForbidden, ErrorResponse{
+			Code:    "user_disabled",
+			Message: internal.ErrUserDisabled.Error(),
+		}
+	}
+
+	if errors.Is(err, ErrForbidden) {
+		return fiber.StatusForbidden, ErrorResponse{
+			Code:    "forbidden",
+			Message: ErrForbidden.Error(),
+		}
+	}
+
+	if errors.Is(err, ErrTwoFactorRequired) {
+		return fiber.StatusForbidden, ErrorResponse{
+			Code:    "two_factor_required",
+			Message: ErrTwoFactorRequired.Error(),
+		}
+	}
+
+	if errors.Is(err, ErrInsufficientScope) {
+		return fiber.StatusForbidden, ErrorResponse{
+			Code:    "insufficient_scope",
+			Message: ErrInsufficientScope.Error(),
+		}
+	}
+
+	if errors.Is(err, internal.ErrUserNotFound) {
+		return fiber.StatusNotFound, ErrorResponse{
+			Code:    "user_not_found",
+			Message: internal.ErrUserNotFound.Error(),
+		}
+	}
+
+	if errors.Is(err, internal.ErrAPIKeyNotFound) {
+		return fiber.StatusNotFound, ErrorResponse{
+			Code:    "api_key_not_found",
+			Message: internal.ErrAPIKeyNotFound.Error(),
+		}
+	}
+
+	if errors.Is(err, internal.ErrOIDCProviderNotFound) {
+		return fiber.StatusNotFound, ErrorResponse{
+			Code:    "oidc_provider_not_found",
+			Message: internal.ErrOIDCProviderNotFound.Error(),
+		}
+	}
+
+	if errors.Is(err, internal.ErrJobNotFound) {
+		return fiber.StatusNotFound, ErrorResponse{
+			Code:    "job_not_found",
+			Message: internal.ErrJobNotFound.Error(),
+		}
+	}
+
+	if errors.Is(err, internal.ErrSavedSearchNotFound) {
+		return fiber.StatusNotFound, ErrorResponse{
+			Code:    "saved_search_not_found",
+			Message: internal.ErrSavedSearchNotFound.Error(),
+		}
+	}
+
+	if errors.Is(err, internal.ErrBookmarkNotFound) {
+		return fiber.StatusNotFound, ErrorResponse{
+			Code:    "bookmark_not_found",
+			Message: internal.ErrBookmarkNotFound.Error(),
+		}
+	}
+
+	if errors.Is(err, internal.ErrApplicationNotFound) {
+		return fiber.StatusNotFound, ErrorResponse{
+			Code:    "application_not_found",
+			Message: internal.ErrApplicationNotFound.Error(),
+		}
+	}
+
+	if errors.Is(err, internal.ErrApplicationStatusConflict) {
+		return fiber.StatusConflict, ErrorResponse{
+			Code:    "application_status_conflict",
+			Message: internal.ErrApplicationStatusConflict.Error(),
+		}
+	}
+
+	if errors.Is(err, internal.ErrUpstreamUnavailable) {
+		return fiber.StatusServiceUnavailable, ErrorResponse{
+			Code:    "upstream_unavailable",
+			Message: internal.ErrUpstreamUnavailable.Error(),
+		}
+	}
+
+	if errors.Is(err, internal.ErrUpstreamTimeout) {
+		return fiber.StatusGatewayTimeout, ErrorResponse{
+			Code:    "upstream_timeout",
+			Message: internal.ErrUpstreamTimeout.Error(),
+		}
+	}
+
+	if errors.Is(err, internal.ErrUpstreamFailed) {
+		return fiber.StatusBadGateway, ErrorResponse{
+			Code:    "upstream_failed",
+			Message: internal.ErrUpstreamFailed.Error(),
+		}
+	}
+
+	var syntaxError *json.SyntaxError
+	var unmarshalTypeError *json.UnmarshalTypeError
+
+	if errors.As(err, &syntaxError) || errors.As(err, &unmarshalTypeError) {
+		return fiber.StatusBadRequest, ErrorResponse{
+			Code:    "malformed_request",
+			Message: "request body is malformed",
+		}
+	}
+
+	var fiberError *fiber.Error
+
+	if errors.As(err, &fiberError) {
+		return fiberError.Code, ErrorResponse{
+			Code:    strings.ReplaceAll(strings.ToLower(utils.StatusMessage(fiberError.Code)), " ", "_"),
+			Message: fiberError.Message,
+		}
+	}
+
+	return fiber.StatusInternalServerError, ErrorResponse{
+		Code:    "internal_server_error",
+		Message: "internal server error",
+	}
+}
+
+func NewErrorHandler(messageCatalog *MessageCatalog) *ErrorHandler {
+	return &ErrorHandler{
+		messageCatalog: messageCatalog,
+	}
+}
@@ -39,39 +39,302 @@ func (s Server) Stop() error {
 	return nil
 }
 
-func NewServer(module *internal.Module) *Server {
-	app := fiber.New()
+func NewServer(module *internal.Module) (*Server, error) {
+	jwtKeySet, err := NewJWTKeySet(
+		module.Configuration.JWT.Algorithm,
+		module.Configuration.JWT.KeyID,
+		module.Configuration.Application.Secret,
+		module.Configuration.JWT.PrivateKey,
+		module.Configuration.JWT.VerificationKeys,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("initializing jwt key set: %w", err)
+	}
+
+	messageCatalog, err := NewEmbeddedMessageCatalog(module.Configuration.Application.Locale)
+	if err != nil {
+		return nil, fmt.Errorf("initializing message catalog: %w", err)
+	}
+
+	errorHandler := NewErrorHandler(messageCatalog)
+	app := fiber.New(fiber.Config{
+		ErrorHandler:            errorHandler.Handle,
+		ProxyHeader:             module.Configuration.Application.ProxyHeader,
+		EnableTrustedProxyCheck: true,
+		TrustedProxies:          module.Configuration.Application.TrustedProxies,
+		EnableIPValidation:      true,
+	})
 
 	pingHandler := NewPingHandler()
 
 	app.Get("/ping", pingHandler.Handle)
 
+	jwksHandler := NewJWKSHandler(jwtKeySet)
+
+	app.Get("/.well-known/jwks.json", jwksHandler.Handle)
+
 	api := app.Group("/api")
 	{
 		v1 := api.Group("/v1")
 		{
+			jwtAuthenticationMiddleware := NewJWTAuthenticationMiddleware(
+				module.Timer,
+				jwtKeySet,
+				module.Configuration.Application.URL,
+				module.Configuration.JWT.Leeway,
+				module.Configuration.JWT.LegacyWindow,
+				module.AuthenticatedUserGetterByID,
+				module.AccessTokenRevocationChecker,
+			)
+			authenticationMiddleware := NewAuthenticationMiddleware(jwtAuthenticationMiddleware, module.APIKeyAuthenticator)
+			jobsListPresenter := NewJobsListPresenter(module.Configuration.Application.URL, module.JobsCursorEncoder)
+
 			user := v1.Group("/user")
 			{
-				jwtUserPresenter := NewJWTUserPresenter(
+				jwtSessionPresenter := NewJWTSessionPresenter(
 					module.Timer,
 					module.Configuration.Application.URL,
 					module.Configuration.JWT.LifeTime,
-					module.Configuration.Application.Secret,
+					jwtKeySet,
+				)
+				jwtUserPresenter := NewJWTUserPresenter(module.SessionIssuer, jwtSessionPresenter, false)
+				twoFactorUserPresenter := NewTwoFactorUserPresenter(
+					module.Timer,
+					module.TwoFactorChallengeIssuer,
+					jwtUserPresenter,
 				)
-				userRegistrationHandler := NewUserRegistrationHandler(module.UserRegistrator, jwtUserPresenter)
+
+				var registeredUserPresenter Presenter[internal.User] = jwtUserPresenter
+
+				if module.Configuration.EmailVerification.Required {
+					registeredUserPresenter = NewUserProfilePresenter(fiber.StatusCreated)
+				}
+
+				userRegistrationHandler := NewUserRegistrationHandler(module.UserRegistrator, registeredUserPresenter)
 
 				user.Post("/registration", userRegistrationHandler.Handle)
 
-				userAuthenticationHandler := NewUserAuthenticationHandler(module.UserAuthenticator, jwtUserPresenter)
+				userAuthenticationHandler := NewUserAuthenticationHandler(module.UserAuthenticator, twoFactorUserPresenter)
 
 				user.Post("/login", userAuthenticationHandler.Handle)
+
+				twoFactorAuthenticationHandler := NewTwoFactorAuthenticationHandler(
+					module.TwoFactorAuthenticator,
+					NewJWTUserPresenter(module.SessionIssuer, jwtSessionPresenter, true),
+				)
+
+				user.Post("/login/2fa", twoFactorAuthenticationHandler.Handle)
+
+				sessionRefreshHandler := NewSessionRefreshHandler(module.SessionRefresher, jwtSessionPresenter)
+
+				user.Post("/token/refresh", sessionRefreshHandler.Handle)
+
+				sessionTerminationHandler := NewSessionTerminationHandler(module.SessionTerminator)
+
+				user.Post("/logout", jwtAuthenticationMiddleware.Handle, sessionTerminationHandler.Handle)
+
+				oidc := user.Group("/oidc/:provider")
+				{
+					oidcAuthorizationHandler := NewOIDCAuthorizationHandler(module.OIDCAuthorizer)
+
+					oidc.Get("/authorize", oidcAuthorizationHandler.Handle)
+
+					oidcCallbackHandler := NewOIDCCallbackHandler(module.OIDCAuthenticator, twoFactorUserPresenter)
+
+					oidc.Get("/callback", oidcCallbackHandler.Handle)
+				}
+
+				password := user.Group("/password")
+				{
+					userPasswordChangeHandler := NewUserPasswordChangeHandler(module.UserPasswordChanger)
+
+					password.Put("/", jwtAuthenticationMiddleware.Handle, userPasswordChangeHandler.Handle)
+
+					passwordResetRequestHandler := NewPasswordResetRequestHandler(module.PasswordResetRequester)
+
+					password.Post("/reset", passwordResetRequestHandler.Handle)
+
+					passwordResetHandler := NewPasswordResetHandler(module.PasswordResetter)
+
+					password.Post("/reset/confirm", passwordResetHandler.Handle)
+				}
+
+				verifyEmail := user.Group("/verify-email")
+				{
+					emailVerificationHandler := NewEmailVerificationHandler(module.EmailVerifier)
+
+					verifyEmail.Post("/", emailVerificationHandler.Handle)
+
+					emailVerificationRequestHandler := NewEmailVerificationRequestHandler(module.EmailVerificationRequester)
+
+					verifyEmail.Post("/request", emailVerificationRequestHandler.Handle)
+				}
+
+				twoFactor := user.Group("/2fa", jwtAuthenticationMiddleware.Handle)
+				{
+					twoFactorEnrollmentHandler := NewTwoFactorEnrollmentHandler(module.TwoFactorEnroller)
+
+					twoFactor.Post("/enroll", twoFactorEnrollmentHandler.Handle)
+
+					twoFactorConfirmationHandler := NewTwoFactorConfirmationHandler(module.TwoFactorConfirmer)
+
+					twoFactor.Post("/confirm", twoFactorConfirmationHandler.Handle)
+
+					twoFactorDisableHandler := NewTwoFactorDisableHandler(module.TwoFactorDisabler)
+
+					twoFactor.Post("/disable", twoFactorDisableHandler.Handle)
+				}
+
+				me := user.Group("/me", jwtAuthenticationMiddleware.Handle)
+				{
+					userProfileHandler := NewUserProfileHandler(module.UserGetterByID)
+
+					me.Get("/", userProfileHandler.Handle)
+
+					userProfileUpdateHandler := NewUserProfileUpdateHandler(module.UserProfileUpdater)
+
+					me.Patch("/", userProfileUpdateHandler.Handle)
+				}
+
+				apiKeys := user.Group("/api-keys", jwtAuthenticationMiddleware.Handle)
+				{
+					apiKeysListingHandler := NewAPIKeysListingHandler(module.APIKeysListerByUserID)
+
+					apiKeys.Get("/", apiKeysListingHandler.Handle)
+
+					apiKeyCreationHandler := NewAPIKeyCreationHandler(module.APIKeyCreator)
+
+					apiKeys.Post("/", apiKeyCreationHandler.Handle)
+
+					apiKeyRevocationHandler := NewAPIKeyRevocationHandler(module.APIKeyRevoker)
+
+					apiKeys.Delete("/:apiKeyID", apiKeyRevocationHandler.Handle)
+				}
+
+				searches := user.Group("/searches", authenticationMiddleware.Handle, RequireScope("searches"))
+				{
+					savedSearchesListingHandler := NewSavedSearchesListingHandler(module.SavedSearchesListerByUserID)
+
+					searches.Get("/", savedSearchesListingHandler.Handle)
+
+					savedSearchCreationHandler := NewSavedSearchCreationHandler(module.SavedSearchCreator)
+
+					searches.Post("/", savedSearchCreationHandler.Handle)
+
+					savedSearchGetterByIDHandler := NewSavedSearchGetterByIDHandler(module.SavedSearchGetterByID)
+
+					searches.Get("/:searchID", savedSearchGetterByIDHandler.Handle)
+
+					savedSearchUpdateHandler := NewSavedSearchUpdateHandler(module.SavedSearchUpdater)
+
+					searches.Put("/:searchID", savedSearchUpdateHandler.Handle)
+
+					savedSearchDeletionHandler := NewSavedSearchDeletionHandler(module.SavedSearchDeleter)
+
+					searches.Delete("/:searchID", savedSearchDeletionHandler.Handle)
+
+					savedSearchRunningHandler := NewSavedSearchRunningHandler(
+						module.SavedSearchRunner,
+						module.JobsCursorDecoder,
+						jobsListPresenter,
+					)
+
+					searches.Get("/:searchID/jobs", savedSearchRunningHandler.Handle)
+				}
+
+				bookmarks := user.Group("/bookmarks", authenticationMiddleware.Handle, RequireScope("bookmarks"))
+				{
+					bookmarksListingHandler := NewBookmarksListingHandler(module.BookmarksLister)
+
+					bookmarks.Get("/", bookmarksListingHandler.Handle)
+
+					bookmarkCreationHandler := NewBookmarkCreationHandler(module.BookmarkCreator)
+
+					bookmarks.Post("/", bookmarkCreationHandler.Handle)
+
+					bookmarkDeletionHandler := NewBookmarkDeletionHandler(module.BookmarkDeleter)
+
+					bookmarks.Delete("/:jobID", bookmarkDeletionHandler.Handle)
+				}
+
+				applications := user.Group("/applications", authenticationMiddleware.Handle, RequireScope("applications"))
+				{
+					applicationsListingHandler := NewApplicationsListingHandler(module.ApplicationsListerByUserID)
+
+					applications.Get("/", applicationsListingHandler.Handle)
+
+					applicationCreationHandler := NewApplicationCreationHandler(module.ApplicationCreator)
+
+					applications.Post("/", applicationCreationHandler.Handle)
+
+					applicationGetterByIDHandler := NewApplicationGetterByIDHandler(module.ApplicationGetterByID)
+
+					applications.Get("/:applicationID", applicationGetterByIDHandler.Handle)
+
+					applicationUpdateHandler := NewApplicationUpdateHandler(module.ApplicationUpdater)
+
+					applications.Patch("/:applicationID", applicationUpdateHandler.Handle)
+
+					applicationStatusTransitionHandler := NewApplicationStatusTransitionHandler(module.ApplicationStatusTransitioner)
+
+					applications.Post("/:applicationID/status", applicationStatusTransitionHandler.Handle)
+
+					applicationDeletionHandler := NewApplicationDeletionHandler(module.ApplicationDeleter)
+
+					applications.Delete("/:applicationID", applicationDeletionHandler.Handle)
+				}
+			}
+
+			adminMiddlewares := []fiber.Handler{authenticationMiddleware.Handle}
+
+			if module.Configuration.TwoFactor.RequiredForAdmin {
+				adminMiddlewares = append(adminMiddlewares, RequireTwoFactor())
 			}
 
-			jwtAuthenticationMiddleware := NewJWTAuthenticationMiddleware(module.Configuration.Application.Secret)
+			admin := v1.Group("/admin", adminMiddlewares...)
+			{
+				users := admin.Group("/users", RequireScope("users"))
+				{
+					usersListingHandler := NewUsersListingHandler(module.UsersLister)
+
+					users.Get("/", RequirePermission(internal.PermissionUsersRead), usersListingHandler.Handle)
+
+					userDisableHandler := NewUserDisableHandler(module.UserDisabler, true)
+
+					users.Post("/:userID/disable", RequirePermission(internal.PermissionUsersWrite), userDisableHandler.Handle)
+
+					userEnableHandler := NewUserDisableHandler(module.UserDisabler, false)
+
+					users.Post("/:userID/enable", RequirePermission(internal.PermissionUsersWrite), userEnableHandler.Handle)
+
+					userPasswordResetRequestHandler := NewUserPasswordResetRequestHandler(module.UserPasswordResetRequester)
 
-			job := v1.Group("/job", jwtAuthenticationMiddleware.Handle)
+					users.Post(
+						"/:userID/password/reset",
+						RequirePermission(internal.PermissionUsersWrite),
+						userPasswordResetRequestHandler.Handle,
+					)
+				}
+
+				if module.JobCacheStatsGetter != nil {
+					cache := admin.Group("/cache", RequireScope("cache"))
+					{
+						jobCacheStatsHandler := NewJobCacheStatsHandler(module.JobCacheStatsGetter)
+
+						cache.Get("/jobs", RequirePermission(internal.PermissionCacheRead), jobCacheStatsHandler.Handle)
+					}
+				}
+			}
+
+			job := v1.Group("/job", authenticationMiddleware.Handle, RequireScope("jobs"))
 			{
-				jobsListingHandler := NewJobsListingHandler(module.JobsLister, NewJobsListPresenter())
+				jobsListingHandler := NewJobsListingHandler(
+					module.JobsLister,
+					module.JobsListerOptionValidator,
+					module.JobsCursorDecoder,
+					module.Configuration.Job.StrictQuery,
+					jobsListPresenter,
+				)
 
 				job.Get("/", jobsListingHandler.Handle)
 
@@ -85,5 +348,5 @@ func NewServer(module *internal.Module) *Server {
 	return &Server{
 		app:  app,
 		addr: fmt.Sprintf(":%s", module.Configuration.Application.Port),
-	}
+	}, nil
 }
@@ -2,7 +2,12 @@ package http
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"strconv"
+	"strings"
+
+	gourl "net/url"
 
 	"github.com/adystag/jobs-search/internal"
 
@@ -46,30 +51,117 @@ func (pj PresentableJob) MarshalJSON() ([]byte, error) {
 	return b, nil
 }
 
-type JobsListPresenter struct{}
+type JobsListPresenter struct {
+	baseURL           string
+	jobsCursorEncoder internal.JobsCursorEncoder
+}
 
-func (JobsListPresenter) Present(ctx *fiber.Ctx, jobs []internal.Job) error {
+func (p JobsListPresenter) Present(ctx *fiber.Ctx, jobsListResult internal.JobsListResult) error {
 	presentableJobs := []PresentableJob{}
 
-	for _, each := range jobs {
+	for _, each := range jobsListResult.Jobs {
 		presentableJobs = append(presentableJobs, PresentableJob(each))
 	}
 
-	return ctx.Status(fiber.StatusOK).JSON(presentableJobs)
+	var next, prev, nextCursor *string
+	var perPage *int
+	var links []string
+
+	if jobsListResult.PerPage > 0 {
+		perPage = &jobsListResult.PerPage
+	}
+
+	if jobsListResult.HasNext && !jobsListResult.NextCursor.IsZero() {
+		token, err := p.jobsCursorEncoder.EncodeJobsCursor(jobsListResult.NextCursor)
+		if err != nil {
+			return fmt.Errorf("encoding next jobs cursor: %w", err)
+		}
+
+		url := p.url(ctx, "cursor", token)
+		next = &url
+		nextCursor = &token
+		links = append(links, fmt.Sprintf(`<%s>; rel="next"`, url))
+	} else if jobsListResult.HasNext {
+		url := p.url(ctx, "page", strconv.Itoa(jobsListResult.Page+1))
+		next = &url
+		links = append(links, fmt.Sprintf(`<%s>; rel="next"`, url))
+	}
+
+	if jobsListResult.Page > 1 {
+		url := p.url(ctx, "page", strconv.Itoa(jobsListResult.Page-1))
+		prev = &url
+		links = append(links, fmt.Sprintf(`<%s>; rel="prev"`, url))
+	}
+
+	if len(links) > 0 {
+		ctx.Set(fiber.HeaderLink, strings.Join(links, ", "))
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data": presentableJobs,
+		"meta": fiber.Map{
+			"page":        jobsListResult.Page,
+			"per_page":    perPage,
+			"has_next":    jobsListResult.HasNext,
+			"next_cursor": nextCursor,
+		},
+		"links": fiber.Map{
+			"next": next,
+			"prev": prev,
+		},
+	})
 }
 
-func NewJobsListPresenter() *JobsListPresenter {
-	return &JobsListPresenter{}
+func (p JobsListPresenter) url(ctx *fiber.Ctx, key, value string) string {
+	values := gourl.Values{}
+
+	ctx.Context().QueryArgs().VisitAll(func(key, value []byte) {
+		values.Add(string(key), string(value))
+	})
+
+	values.Del("page")
+	values.Del("cursor")
+	values.Set(key, value)
+
+	return fmt.Sprintf("%s%s?%s", strings.TrimSuffix(p.baseURL, "/"), ctx.Path(), values.Encode())
+}
+
+func NewJobsListPresenter(baseURL string, jobsCursorEncoder internal.JobsCursorEncoder) *JobsListPresenter {
+	return &JobsListPresenter{
+		baseURL:           baseURL,
+		jobsCursorEncoder: jobsCursorEncoder,
+	}
 }
 
 type JobsListingHandler struct {
-	jobsLister internal.JobsLister
-	presenter  Presenter[[]internal.Job]
+	jobsLister                internal.JobsLister
+	jobsListerOptionValidator internal.Validator[internal.JobsListerOption]
+	jobsCursorDecoder         internal.JobsCursorDecoder
+	strict                    bool
+	presenter                 Presenter[internal.JobsListResult]
 }
 
 func (h JobsListingHandler) Handle(ctx *fiber.Ctx) error {
+	validationErrors := internal.ValidationErrors{}
 	opts := []internal.Option[internal.JobsListerOption]{}
 
+	if h.strict {
+		unknown := map[string]bool{}
+
+		ctx.Context().QueryArgs().VisitAll(func(key, _ []byte) {
+			switch string(key) {
+			case "description", "location", "full_time", "page", "cursor":
+			default:
+				if unknown[string(key)] {
+					return
+				}
+
+				unknown[string(key)] = true
+				validationErrors = append(validationErrors, internal.NewValidationError(string(key), "unknown"))
+			}
+		})
+	}
+
 	description := ctx.Query("description")
 	if len(description) > 0 {
 		opts = append(opts, internal.WithJobsListerDescription(description))
@@ -80,32 +172,96 @@ func (h JobsListingHandler) Handle(ctx *fiber.Ctx) error {
 		opts = append(opts, internal.WithJobsListerLocation(location))
 	}
 
-	fullTime := ctx.QueryBool("full_time")
-	if fullTime {
-		opts = append(opts, internal.WithJobsListerFullTime(fullTime))
+	if rawFullTime := ctx.Query("full_time"); len(rawFullTime) > 0 {
+		fullTime, err := strconv.ParseBool(rawFullTime)
+		if err != nil && h.strict {
+			validationErrors = append(validationErrors, internal.NewValidationError("full_time", "boolean"))
+		}
+
+		if fullTime {
+			opts = append(opts, internal.WithJobsListerFullTime(fullTime))
+		}
+	}
+
+	page := 1
+
+	if len(ctx.Query("cursor")) > 0 {
+		cursor, err := jobsCursorFromQuery(ctx, h.jobsCursorDecoder)
+		if err != nil {
+			err = validationErrors.Collect(err)
+			if err != nil {
+				return fmt.Errorf("getting jobs cursor from query: %w", err)
+			}
+		} else {
+			page = 0
+			opts = append(opts, internal.WithJobsListerCursor(cursor))
+		}
 	}
 
-	page := ctx.QueryInt("page")
-	if page >= 1 {
+	if rawPage := ctx.Query("page"); len(rawPage) > 0 {
+		parsedPage, err := strconv.Atoi(rawPage)
+
+		switch {
+		case err != nil && h.strict:
+			validationErrors = append(validationErrors, internal.NewValidationError("page", "numeric"))
+		case err == nil && (h.strict || parsedPage >= 1):
+			page = parsedPage
+		}
+	}
+
+	if page != 0 {
 		opts = append(opts, internal.WithJobsListerPage(page))
 	}
 
-	jobs, err := h.jobsLister.ListJobs(ctx.Context(), opts...)
+	jobsListerOption := internal.JobsListerOption{}
+
+	internal.ApplyOptions(&jobsListerOption, opts...)
+
+	err := validationErrors.Collect(h.jobsListerOptionValidator.Validate(ctx.Context(), jobsListerOption))
+	if err != nil {
+		return fmt.Errorf("validating jobs lister option: %w", err)
+	}
+
+	err = validationErrors.Err()
+	if err != nil {
+		return fmt.Errorf("parsing jobs listing query: %w", err)
+	}
+
+	jobsListResult, err := h.jobsLister.ListJobs(ctx.Context(), opts...)
 	if err != nil {
 		return fmt.Errorf("listing jobs: %w", err)
 	}
 
-	return h.presenter.Present(ctx, jobs)
+	return h.presenter.Present(ctx, jobsListResult)
 }
 
 func NewJobsListingHandler(
 	jobsLister internal.JobsLister,
-	presenter Presenter[[]internal.Job],
+	jobsListerOptionValidator internal.Validator[internal.JobsListerOption],
+	jobsCursorDecoder internal.JobsCursorDecoder,
+	strict bool,
+	presenter Presenter[internal.JobsListResult],
 ) *JobsListingHandler {
 	return &JobsListingHandler{
-		jobsLister: jobsLister,
-		presenter:  presenter,
+		jobsLister:                jobsLister,
+		jobsListerOptionValidator: jobsListerOptionValidator,
+		jobsCursorDecoder:         jobsCursorDecoder,
+		strict:                    strict,
+		presenter:                 presenter,
+	}
+}
+
+func jobsCursorFromQuery(ctx *fiber.Ctx, jobsCursorDecoder internal.JobsCursorDecoder) (internal.JobsCursor, error) {
+	cursor, err := jobsCursorDecoder.DecodeJobsCursor(ctx.Query("cursor"))
+	if err != nil {
+		if errors.Is(err, internal.ErrJobsCursorInvalid) {
+			return internal.JobsCursor{}, internal.NewValidationError("cursor", "cursor")
+		}
+
+		return internal.JobsCursor{}, fmt.Errorf("decoding jobs cursor: %w", err)
 	}
+
+	return cursor, nil
 }
 
 type JobGetterByIDHandler struct {
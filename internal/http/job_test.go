@@ -0,0 +1,173 @@
+package http_test
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/adystag/jobs-search/internal"
+	"github.com/adystag/jobs-search/internal/http"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+)
+
+type fixedTimer struct {
+	now time.Time
+}
+
+func (t fixedTimer) Now() time.Time {
+	return t.now
+}
+
+type recordingJobsLister struct {
+	opt internal.JobsListerOption
+}
+
+func (l *recordingJobsLister) ListJobs(
+	ctx context.Context,
+	opts ...internal.Option[internal.JobsListerOption],
+) (internal.JobsListResult, error) {
+	l.opt = internal.JobsListerOption{}
+
+	internal.ApplyOptions(&l.opt, opts...)
+
+	return internal.JobsListResult{}, nil
+}
+
+type statusJobsListPresenter struct{}
+
+func (p statusJobsListPresenter) Present(ctx *fiber.Ctx, jobsListResult internal.JobsListResult) error {
+	return ctx.SendStatus(fiber.StatusOK)
+}
+
+func TestJobsListingHandlerQuery(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		strict   bool
+		wantErrs []string
+		wantOpt  internal.JobsListerOption
+	}{
+		{
+			name:    "valid query",
+			query:   "description=go&location=remote&full_time=true&page=2",
+			strict:  true,
+			wantOpt: internal.JobsListerOption{Description: "go", Location: "remote", FullTime: true, Page: 2},
+		},
+		{
+			name:     "unknown parameters reported once",
+			query:    "foo=1&foo=2&bar=3",
+			strict:   true,
+			wantErrs: []string{"foo:unknown", "bar:unknown"},
+		},
+		{
+			name:     "malformed values rejected in strict mode",
+			query:    "full_time=maybe&page=abc",
+			strict:   true,
+			wantErrs: []string{"full_time:boolean", "page:numeric"},
+		},
+		{
+			name:     "out of range page rejected in strict mode",
+			query:    "page=-3",
+			strict:   true,
+			wantErrs: []string{"page:min=1"},
+		},
+		{
+			name:    "unknown parameters ignored in lenient mode",
+			query:   "foo=1&page=2",
+			wantOpt: internal.JobsListerOption{Page: 2},
+		},
+		{
+			name:    "malformed values ignored in lenient mode",
+			query:   "full_time=maybe&page=abc",
+			wantOpt: internal.JobsListerOption{Page: 1},
+		},
+		{
+			name:    "out of range page ignored in lenient mode",
+			query:   "page=-3",
+			wantOpt: internal.JobsListerOption{Page: 1},
+		},
+		{
+			name:     "invalid cursor collected with other errors",
+			query:    "foo=1&cursor=invalid&page=abc",
+			strict:   true,
+			wantErrs: []string{"foo:unknown", "cursor:cursor", "page:numeric"},
+		},
+		{
+			name:     "invalid cursor rejected in lenient mode",
+			query:    "cursor=invalid&description=%3Cscript%3E",
+			wantErrs: []string{"cursor:cursor", "description:charset"},
+		},
+		{
+			name:     "invalid characters rejected in lenient mode",
+			query:    "description=%3Cscript%3E",
+			wantErrs: []string{"description:charset"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var handlerErr error
+
+			lister := &recordingJobsLister{}
+			jobsCursorCodec, err := internal.NewHMACJobsCursorCodec(fixedTimer{now: time.Now()}, []byte("secret"), time.Hour)
+			if err != nil {
+				t.Fatalf("NewHMACJobsCursorCodec() error = %v", err)
+			}
+
+			handler := http.NewJobsListingHandler(
+				lister,
+				internal.NewJobsListerOptionValidator(validator.New(), 1000),
+				jobsCursorCodec,
+				tt.strict,
+				statusJobsListPresenter{},
+			)
+			app := fiber.New(fiber.Config{
+				ErrorHandler: func(ctx *fiber.Ctx, err error) error {
+					handlerErr = err
+
+					return ctx.SendStatus(fiber.StatusBadRequest)
+				},
+			})
+
+			app.Get("/jobs", handler.Handle)
+
+			_, err = app.Test(httptest.NewRequest(fiber.MethodGet, "/jobs?"+tt.query, nil))
+			if err != nil {
+				t.Fatalf("requesting jobs listing: %s", err)
+			}
+
+			if len(tt.wantErrs) == 0 {
+				if handlerErr != nil {
+					t.Fatalf("Handle() error = %v, want nil", handlerErr)
+				}
+
+				if !reflect.DeepEqual(lister.opt, tt.wantOpt) {
+					t.Errorf("ListJobs() option = %+v, want %+v", lister.opt, tt.wantOpt)
+				}
+
+				return
+			}
+
+			var validationErrors internal.ValidationErrors
+
+			if !errors.As(handlerErr, &validationErrors) {
+				t.Fatalf("Handle() error = %v, want validation errors", handlerErr)
+			}
+
+			gotErrs := []string{}
+
+			for _, each := range validationErrors {
+				gotErrs = append(gotErrs, each.Field()+":"+each.Tag())
+			}
+
+			if !reflect.DeepEqual(gotErrs, tt.wantErrs) {
+				t.Errorf("Handle() validation errors = %q, want %q", gotErrs, tt.wantErrs)
+			}
+		})
+	}
+}
@@ -0,0 +1,270 @@
+package http
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/gofiber/fiber/v2"
+)
+
+var ErrUnknownJWTKey = errors.New("jwt key is unknown")
+
+var SigningMethodEdDSA = &signingMethodEdDSA{}
+
+func init() {
+	jwt.RegisterSigningMethod(SigningMethodEdDSA.Alg(), func() jwt.SigningMethod {
+		return SigningMethodEdDSA
+	})
+}
+
+type signingMethodEdDSA struct{}
+
+func (m signingMethodEdDSA) Alg() string {
+	return "EdDSA"
+}
+
+func (m signingMethodEdDSA) Verify(signingString, signature string, key interface{}) error {
+	publicKey, ok := key.(ed25519.PublicKey)
+	if !ok {
+		return jwt.ErrInvalidKeyType
+	}
+
+	sig, err := jwt.DecodeSegment(signature)
+	if err != nil {
+		return fmt.Errorf("decoding signature: %w", err)
+	}
+
+	if !ed25519.Verify(publicKey, []byte(signingString), sig) {
+		return jwt.ErrSignatureInvalid
+	}
+
+	return nil
+}
+
+func (m signingMethodEdDSA) Sign(signingString string, key interface{}) (string, error) {
+	privateKey, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return "", jwt.ErrInvalidKeyType
+	}
+
+	return jwt.EncodeSegment(ed25519.Sign(privateKey, []byte(signingString))), nil
+}
+
+type JWTKey struct {
+	ID     string
+	Method jwt.SigningMethod
+	Key    interface{}
+}
+
+type JWK struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	N         string `json:"n,omitempty"`
+	E         string `json:"e,omitempty"`
+	Curve     string `json:"crv,omitempty"`
+	X         string `json:"x,omitempty"`
+}
+
+type JWTKeySet struct {
+	signingKey       JWTKey
+	verificationKeys map[string]JWTKey
+}
+
+func (ks JWTKeySet) Sign(claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(ks.signingKey.Method, claims)
+
+	if len(ks.signingKey.ID) > 0 {
+		token.Header["kid"] = ks.signingKey.ID
+	}
+
+	signed, err := token.SignedString(ks.signingKey.Key)
+	if err != nil {
+		return "", fmt.Errorf("signing jwt token: %w", err)
+	}
+
+	return signed, nil
+}
+
+func (ks JWTKeySet) Keyfunc(t *jwt.Token) (interface{}, error) {
+	keyID, _ := t.Header["kid"].(string)
+
+	if len(keyID) == 0 {
+		keyID = ks.signingKey.ID
+	}
+
+	key, ok := ks.verificationKeys[keyID]
+	if !ok {
+		return nil, ErrUnknownJWTKey
+	}
+
+	if key.Method.Alg() != t.Method.Alg() {
+		return nil, fmt.Errorf("%w: unexpected signing method %s", ErrUnknownJWTKey, t.Method.Alg())
+	}
+
+	return key.Key, nil
+}
+
+func (ks JWTKeySet) ValidMethods() []string {
+	methods := map[string]struct{}{}
+
+	for _, each := range ks.verificationKeys {
+		methods[each.Method.Alg()] = struct{}{}
+	}
+
+	validMethods := []string{}
+
+	for each := range methods {
+		validMethods = append(validMethods, each)
+	}
+
+	sort.Strings(validMethods)
+
+	return validMethods
+}
+
+func (ks JWTKeySet) JWKS() []JWK {
+	keyIDs := []string{}
+
+	for each := range ks.verificationKeys {
+		keyIDs = append(keyIDs, each)
+	}
+
+	sort.Strings(keyIDs)
+
+	jwks := []JWK{}
+
+	for _, keyID := range keyIDs {
+		key := ks.verificationKeys[keyID]
+		jwk := JWK{
+			KeyID:     key.ID,
+			Use:       "sig",
+			Algorithm: key.Method.Alg(),
+		}
+
+		switch publicKey := key.Key.(type) {
+		case *rsa.PublicKey:
+			jwk.KeyType = "RSA"
+			jwk.N = jwt.EncodeSegment(publicKey.N.Bytes())
+			jwk.E = jwt.EncodeSegment(big.NewInt(int64(publicKey.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.KeyType = "OKP"
+			jwk.Curve = "Ed25519"
+			jwk.X = jwt.EncodeSegment(publicKey)
+		default:
+			continue
+		}
+
+		jwks = append(jwks, jwk)
+	}
+
+	return jwks
+}
+
+func NewJWTKeySet(
+	algorithm string,
+	keyID string,
+	secret []byte,
+	privateKey crypto.PrivateKey,
+	verificationKeys map[string]crypto.PublicKey,
+) (*JWTKeySet, error) {
+	ks := &JWTKeySet{
+		verificationKeys: map[string]JWTKey{},
+	}
+
+	switch algorithm {
+	case jwt.SigningMethodHS512.Alg():
+		if len(secret) == 0 {
+			return nil, errors.New("jwt secret is required for HS512")
+		}
+
+		ks.signingKey = JWTKey{
+			ID:     keyID,
+			Method: jwt.SigningMethodHS512,
+			Key:    secret,
+		}
+		ks.verificationKeys[keyID] = ks.signingKey
+	case jwt.SigningMethodRS256.Alg(), SigningMethodEdDSA.Alg():
+		signer, ok := privateKey.(crypto.Signer)
+		if !ok {
+			return nil, fmt.Errorf("jwt private key is required for %s", algorithm)
+		}
+
+		method, err := jwtSigningMethodForKey(signer.Public())
+		if err != nil {
+			return nil, fmt.Errorf("getting signing method for private key: %w", err)
+		}
+
+		if method.Alg() != algorithm {
+			return nil, fmt.Errorf("jwt private key does not match %s", algorithm)
+		}
+
+		ks.signingKey = JWTKey{
+			ID:     keyID,
+			Method: method,
+			Key:    privateKey,
+		}
+		ks.verificationKeys[keyID] = JWTKey{
+			ID:     keyID,
+			Method: method,
+			Key:    signer.Public(),
+		}
+	default:
+		return nil, fmt.Errorf("unsupported jwt algorithm %s", algorithm)
+	}
+
+	for id, publicKey := range verificationKeys {
+		if _, ok := ks.verificationKeys[id]; ok {
+			return nil, fmt.Errorf("duplicate jwt verification key id %s", id)
+		}
+
+		method, err := jwtSigningMethodForKey(publicKey)
+		if err != nil {
+			return nil, fmt.Errorf("getting signing method for verification key %s: %w", id, err)
+		}
+
+		ks.verificationKeys[id] = JWTKey{
+			ID:     id,
+			Method: method,
+			Key:    publicKey,
+		}
+	}
+
+	return ks, nil
+}
+
+func jwtSigningMethodForKey(publicKey crypto.PublicKey) (jwt.SigningMethod, error) {
+	switch publicKey.(type) {
+	case *rsa.PublicKey:
+		return jwt.SigningMethodRS256, nil
+	case ed25519.PublicKey:
+		return SigningMethodEdDSA, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %T", publicKey)
+	}
+}
+
+type JWKSHandler struct {
+	keySet *JWTKeySet
+}
+
+func (h JWKSHandler) Handle(ctx *fiber.Ctx) error {
+	ctx.Set(fiber.HeaderCacheControl, "public, max-age=300")
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"keys": h.keySet.JWKS(),
+	})
+}
+
+func NewJWKSHandler(keySet *JWTKeySet) *JWKSHandler {
+	return &JWKSHandler{
+		keySet: keySet,
+	}
+}
@@ -0,0 +1,182 @@
+package http
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/adystag/jobs-search/internal"
+)
+
+//go:embed locales/*.json
+var locales embed.FS
+
+type messageLocale struct {
+	Messages map[string]string `json:"messages"`
+	Fields   map[string]string `json:"fields"`
+}
+
+type MessageCatalog struct {
+	defaultLocale string
+	locales       map[string]messageLocale
+}
+
+func (c MessageCatalog) Negotiate(acceptLanguage string) string {
+	type languageRange struct {
+		tag     string
+		quality float64
+	}
+
+	languageRanges := []languageRange{}
+
+	for _, each := range strings.Split(acceptLanguage, ",") {
+		comps := strings.Split(each, ";")
+		tag := strings.ToLower(strings.TrimSpace(comps[0]))
+		quality := 1.0
+
+		for _, param := range comps[1:] {
+			value, ok := strings.CutPrefix(strings.TrimSpace(param), "q=")
+			if !ok {
+				continue
+			}
+
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				parsed = 0
+			}
+
+			quality = parsed
+		}
+
+		if len(tag) == 0 || quality <= 0 {
+			continue
+		}
+
+		languageRanges = append(languageRanges, languageRange{
+			tag:     tag,
+			quality: quality,
+		})
+	}
+
+	sort.SliceStable(languageRanges, func(i, j int) bool {
+		return languageRanges[i].quality > languageRanges[j].quality
+	})
+
+	for _, each := range languageRanges {
+		if each.tag == "*" {
+			return c.defaultLocale
+		}
+
+		if _, ok := c.locales[each.tag]; ok {
+			return each.tag
+		}
+
+		primary, _, _ := strings.Cut(each.tag, "-")
+
+		if _, ok := c.locales[primary]; ok {
+			return primary
+		}
+	}
+
+	return c.defaultLocale
+}
+
+func (c MessageCatalog) Translate(locale, field, tag string) string {
+	rule, dive := strings.CutPrefix(tag, "dive,")
+	name, param, _ := strings.Cut(rule, "=")
+	prefix := ""
+
+	if dive {
+		prefix = "dive."
+	}
+
+	keys := []string{field + "." + prefix + rule, field + "." + prefix + name, prefix + rule, prefix + name}
+
+	if dive {
+		keys = append(keys, rule, name)
+	}
+
+	keys = append(keys, "default")
+
+	for _, each := range []string{locale, c.defaultLocale} {
+		messageLocale, ok := c.locales[each]
+		if !ok {
+			continue
+		}
+
+		for _, key := range keys {
+			message, ok := messageLocale.Messages[key]
+			if !ok {
+				continue
+			}
+
+			switch name {
+			case "eqfield", "nefield":
+				param = messageLocale.field(param)
+			case "oneof":
+				param = strings.Join(strings.Fields(param), ", ")
+			}
+
+			return strings.NewReplacer("{field}", messageLocale.field(field), "{param}", param).Replace(message)
+		}
+	}
+
+	return internal.NewValidationError(field, tag).Error()
+}
+
+func (l messageLocale) field(field string) string {
+	label, ok := l.Fields[field]
+	if !ok {
+		label = strings.ReplaceAll(field, "_", " ")
+	}
+
+	return label
+}
+
+func NewMessageCatalog(fsys fs.FS, defaultLocale string) (*MessageCatalog, error) {
+	paths, err := fs.Glob(fsys, "*.json")
+	if err != nil {
+		return nil, fmt.Errorf("globbing message catalog files: %w", err)
+	}
+
+	catalog := MessageCatalog{
+		defaultLocale: strings.ToLower(defaultLocale),
+		locales:       map[string]messageLocale{},
+	}
+
+	for _, each := range paths {
+		b, err := fs.ReadFile(fsys, each)
+		if err != nil {
+			return nil, fmt.Errorf("reading message catalog file %s: %w", each, err)
+		}
+
+		var messageLocale messageLocale
+
+		err = json.Unmarshal(b, &messageLocale)
+		if err != nil {
+			return nil, fmt.Errorf("unmarshalling message catalog file %s from json: %w", each, err)
+		}
+
+		catalog.locales[strings.ToLower(strings.TrimSuffix(path.Base(each), ".json"))] = messageLocale
+	}
+
+	if _, ok := catalog.locales[catalog.defaultLocale]; !ok {
+		return nil, fmt.Errorf("message catalog for default locale %q is not found", defaultLocale)
+	}
+
+	return &catalog, nil
+}
+
+func NewEmbeddedMessageCatalog(defaultLocale string) (*MessageCatalog, error) {
+	fsys, err := fs.Sub(locales, "locales")
+	if err != nil {
+		return nil, fmt.Errorf("opening embedded locales directory: %w", err)
+	}
+
+	return NewMessageCatalog(fsys, defaultLocale)
+}
@@ -0,0 +1,194 @@
+package http_test
+
+import (
+	"testing"
+
+	"github.com/adystag/jobs-search/internal/http"
+)
+
+func TestMessageCatalogNegotiate(t *testing.T) {
+	tests := []struct {
+		name           string
+		acceptLanguage string
+		want           string
+	}{
+		{
+			name:           "empty header",
+			acceptLanguage: "",
+			want:           "en",
+		},
+		{
+			name:           "exact match",
+			acceptLanguage: "id",
+			want:           "id",
+		},
+		{
+			name:           "case insensitive",
+			acceptLanguage: "ID",
+			want:           "id",
+		},
+		{
+			name:           "region fallback",
+			acceptLanguage: "id-ID",
+			want:           "id",
+		},
+		{
+			name:           "unsupported locale skipped",
+			acceptLanguage: "fr-FR, id;q=0.5",
+			want:           "id",
+		},
+		{
+			name:           "highest quality wins",
+			acceptLanguage: "en;q=0.2, id;q=0.8",
+			want:           "id",
+		},
+		{
+			name:           "equal quality keeps order",
+			acceptLanguage: "en-US, id",
+			want:           "en",
+		},
+		{
+			name:           "zero quality excluded",
+			acceptLanguage: "id;q=0, fr",
+			want:           "en",
+		},
+		{
+			name:           "malformed quality excluded",
+			acceptLanguage: "id;q=high",
+			want:           "en",
+		},
+		{
+			name:           "wildcard",
+			acceptLanguage: "fr, *;q=0.1",
+			want:           "en",
+		},
+		{
+			name:           "wildcard ranked below locale",
+			acceptLanguage: "*;q=0.1, id;q=0.9",
+			want:           "id",
+		},
+	}
+
+	catalog, err := http.NewEmbeddedMessageCatalog("en")
+	if err != nil {
+		t.Fatalf("NewEmbeddedMessageCatalog() error = %v", err)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := catalog.Negotiate(tt.acceptLanguage)
+			if got != tt.want {
+				t.Errorf("Negotiate(%q) = %q, want %q", tt.acceptLanguage, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMessageCatalogTranslate(t *testing.T) {
+	tests := []struct {
+		name   string
+		locale string
+		field  string
+		tag    string
+		want   string
+	}{
+		{
+			name:   "generic rule",
+			locale: "en",
+			field:  "username",
+			tag:    "required",
+			want:   "The username field is required.",
+		},
+		{
+			name:   "field label",
+			locale: "id",
+			field:  "username",
+			tag:    "required",
+			want:   "Kolom nama pengguna wajib diisi.",
+		},
+		{
+			name:   "parameterized rule",
+			locale: "en",
+			field:  "password",
+			tag:    "min=8",
+			want:   "The password must be at least 8 characters.",
+		},
+		{
+			name:   "field specific rule",
+			locale: "en",
+			field:  "tags",
+			tag:    "max=10",
+			want:   "The tags must not have more than 10 items.",
+		},
+		{
+			name:   "dive max",
+			locale: "en",
+			field:  "tags",
+			tag:    "dive,max=30",
+			want:   "Each item in tags must not be greater than 30 characters.",
+		},
+		{
+			name:   "dive required",
+			locale: "id",
+			field:  "tags",
+			tag:    "dive,required",
+			want:   "Setiap isian tag wajib diisi.",
+		},
+		{
+			name:   "dive on another field",
+			locale: "id",
+			field:  "preferred_locations",
+			tag:    "dive,max=100",
+			want:   "Setiap isian lokasi pilihan maksimal 100 karakter.",
+		},
+		{
+			name:   "exact rule",
+			locale: "en",
+			field:  "expires_at",
+			tag:    "gt=now",
+			want:   "The expiration time must be in the future.",
+		},
+		{
+			name:   "field parameter",
+			locale: "id",
+			field:  "password_confirmation",
+			tag:    "eqfield=password",
+			want:   "Kolom konfirmasi kata sandi harus sama dengan kata sandi.",
+		},
+		{
+			name:   "list parameter",
+			locale: "en",
+			field:  "status",
+			tag:    "oneof=applied offer",
+			want:   "The status must be one of: applied, offer.",
+		},
+		{
+			name:   "unknown rule",
+			locale: "en",
+			field:  "username",
+			tag:    "lowercase",
+			want:   "The username field is invalid.",
+		},
+		{
+			name:   "unknown locale",
+			locale: "fr",
+			field:  "username",
+			tag:    "required",
+			want:   "The username field is required.",
+		},
+	}
+
+	catalog, err := http.NewEmbeddedMessageCatalog("en")
+	if err != nil {
+		t.Fatalf("NewEmbeddedMessageCatalog() error = %v", err)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := catalog.Translate(tt.locale, tt.field, tt.tag)
+			if got != tt.want {
+				t.Errorf("Translate(%q, %q, %q) = %q, want %q", tt.locale, tt.field, tt.tag, got, tt.want)
+			}
+		})
+	}
+}
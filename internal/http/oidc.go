@@ -0,0 +1,92 @@
+package http
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/adystag/jobs-search/internal"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type OIDCAuthorizationHandler struct {
+	oidcAuthorizer internal.OIDCAuthorizer
+}
+
+func (h OIDCAuthorizationHandler) Handle(ctx *fiber.Ctx) error {
+	redirect, err := h.oidcAuthorizer.AuthorizeOIDC(ctx.Context(), ctx.Params("provider"))
+	if err != nil {
+		return fmt.Errorf("authorizing oidc: %w", err)
+	}
+
+	ctx.Cookie(&fiber.Cookie{
+		Name:     OIDCStateCookieName,
+		Value:    redirect.State,
+		Path:     strings.TrimSuffix(ctx.Path(), "/authorize"),
+		Expires:  redirect.ExpiresAt,
+		Secure:   ctx.Secure(),
+		HTTPOnly: true,
+		SameSite: fiber.CookieSameSiteLaxMode,
+	})
+	ctx.Set(fiber.HeaderCacheControl, "no-store")
+
+	return ctx.Redirect(redirect.URL, fiber.StatusFound)
+}
+
+func NewOIDCAuthorizationHandler(oidcAuthorizer internal.OIDCAuthorizer) *OIDCAuthorizationHandler {
+	return &OIDCAuthorizationHandler{
+		oidcAuthorizer: oidcAuthorizer,
+	}
+}
+
+const OIDCStateCookieName = "oidc_state"
+
+type OIDCCallbackHandler struct {
+	oidcAuthenticator internal.OIDCAuthenticator
+	userPresenter     Presenter[internal.User]
+}
+
+func (h OIDCCallbackHandler) Handle(ctx *fiber.Ctx) error {
+	if oidcError := ctx.Query("error"); len(oidcError) > 0 {
+		return fmt.Errorf(
+			"%w: provider returns %s:%s",
+			internal.ErrOIDCAuthenticationFailed,
+			oidcError,
+			ctx.Query("error_description"),
+		)
+	}
+
+	cookieState := ctx.Cookies(OIDCStateCookieName)
+
+	ctx.Cookie(&fiber.Cookie{
+		Name:     OIDCStateCookieName,
+		Path:     strings.TrimSuffix(ctx.Path(), "/callback"),
+		Expires:  time.Unix(0, 0),
+		Secure:   ctx.Secure(),
+		HTTPOnly: true,
+		SameSite: fiber.CookieSameSiteLaxMode,
+	})
+
+	user, err := h.oidcAuthenticator.AuthenticateOIDC(ctx.Context(), internal.OIDCAuthenticationRequest{
+		Provider:    ctx.Params("provider"),
+		Code:        ctx.Query("code"),
+		State:       ctx.Query("state"),
+		CookieState: cookieState,
+	})
+	if err != nil {
+		return fmt.Errorf("authenticating oidc: %w", err)
+	}
+
+	return h.userPresenter.Present(ctx, user)
+}
+
+func NewOIDCCallbackHandler(
+	oidcAuthenticator internal.OIDCAuthenticator,
+	userPresenter Presenter[internal.User],
+) *OIDCCallbackHandler {
+	return &OIDCCallbackHandler{
+		oidcAuthenticator: oidcAuthenticator,
+		userPresenter:     userPresenter,
+	}
+}
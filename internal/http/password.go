@@ -0,0 +1,113 @@
+package http
+
+import (
+	"fmt"
+
+	"github.com/adystag/jobs-search/internal"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type UserPasswordChangeHandler struct {
+	userPasswordChanger internal.UserPasswordChanger
+}
+
+func (h UserPasswordChangeHandler) Handle(ctx *fiber.Ctx) error {
+	userID, err := UserIDFromContext(ctx)
+	if err != nil {
+		return fmt.Errorf("getting user id from context: %w", err)
+	}
+
+	var userPasswordChangeRequest struct {
+		CurrentPassword      string `json:"current_password"`
+		Password             string `json:"password"`
+		PasswordConfirmation string `json:"password_confirmation"`
+	}
+
+	err = ctx.BodyParser(&userPasswordChangeRequest)
+	if err != nil {
+		return fmt.Errorf("parsing http user password change request body: %w", err)
+	}
+
+	err = h.userPasswordChanger.ChangeUserPassword(ctx.Context(), internal.UserPasswordChangeRequest{
+		UserID:               userID,
+		CurrentPassword:      userPasswordChangeRequest.CurrentPassword,
+		Password:             userPasswordChangeRequest.Password,
+		PasswordConfirmation: userPasswordChangeRequest.PasswordConfirmation,
+	})
+	if err != nil {
+		return fmt.Errorf("changing user password: %w", err)
+	}
+
+	return ctx.SendStatus(fiber.StatusNoContent)
+}
+
+func NewUserPasswordChangeHandler(userPasswordChanger internal.UserPasswordChanger) *UserPasswordChangeHandler {
+	return &UserPasswordChangeHandler{
+		userPasswordChanger: userPasswordChanger,
+	}
+}
+
+type PasswordResetRequestHandler struct {
+	passwordResetRequester internal.PasswordResetRequester
+}
+
+func (h PasswordResetRequestHandler) Handle(ctx *fiber.Ctx) error {
+	var passwordResetRequest struct {
+		Username string `json:"username"`
+	}
+
+	err := ctx.BodyParser(&passwordResetRequest)
+	if err != nil {
+		return fmt.Errorf("parsing http password reset request body: %w", err)
+	}
+
+	err = h.passwordResetRequester.RequestPasswordReset(ctx.Context(), internal.PasswordResetRequest{
+		Username: passwordResetRequest.Username,
+	})
+	if err != nil {
+		return fmt.Errorf("requesting password reset: %w", err)
+	}
+
+	return ctx.SendStatus(fiber.StatusAccepted)
+}
+
+func NewPasswordResetRequestHandler(passwordResetRequester internal.PasswordResetRequester) *PasswordResetRequestHandler {
+	return &PasswordResetRequestHandler{
+		passwordResetRequester: passwordResetRequester,
+	}
+}
+
+type PasswordResetHandler struct {
+	passwordResetter internal.PasswordResetter
+}
+
+func (h PasswordResetHandler) Handle(ctx *fiber.Ctx) error {
+	var passwordResetConfirmationRequest struct {
+		Token                string `json:"token"`
+		Password             string `json:"password"`
+		PasswordConfirmation string `json:"password_confirmation"`
+	}
+
+	err := ctx.BodyParser(&passwordResetConfirmationRequest)
+	if err != nil {
+		return fmt.Errorf("parsing http password reset confirmation request body: %w", err)
+	}
+
+	err = h.passwordResetter.ResetPassword(ctx.Context(), internal.PasswordResetConfirmationRequest{
+		Token:                passwordResetConfirmationRequest.Token,
+		Password:             passwordResetConfirmationRequest.Password,
+		PasswordConfirmation: passwordResetConfirmationRequest.PasswordConfirmation,
+	})
+	if err != nil {
+		return fmt.Errorf("resetting password: %w", err)
+	}
+
+	return ctx.SendStatus(fiber.StatusNoContent)
+}
+
+func NewPasswordResetHandler(passwordResetter internal.PasswordResetter) *PasswordResetHandler {
+	return &PasswordResetHandler{
+		passwordResetter: passwordResetter,
+	}
+}
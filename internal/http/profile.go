@@ -0,0 +1,143 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/adystag/jobs-search/internal"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type PresentableUserProfile internal.User
+
+func (pp PresentableUserProfile) MarshalJSON() ([]byte, error) {
+	roles := []string{}
+
+	for _, each := range pp.Roles {
+		roles = append(roles, string(each))
+	}
+
+	preferredLocations := []string{}
+
+	if pp.PreferredLocations != nil {
+		preferredLocations = pp.PreferredLocations
+	}
+
+	tmp := struct {
+		ID                 int64     `json:"id"`
+		Username           string    `json:"username"`
+		Email              string    `json:"email"`
+		EmailVerified      bool      `json:"email_verified"`
+		TwoFactorEnabled   bool      `json:"two_factor_enabled"`
+		DisplayName        string    `json:"display_name"`
+		PreferredLocations []string  `json:"preferred_locations"`
+		PreferredJobType   string    `json:"preferred_job_type"`
+		Timezone           string    `json:"timezone"`
+		Roles              []string  `json:"roles"`
+		CreatedAt          time.Time `json:"created_at"`
+		UpdatedAt          time.Time `json:"updated_at"`
+	}{
+		ID:                 pp.ID,
+		Username:           pp.Username,
+		Email:              pp.Email,
+		EmailVerified:      internal.User(pp).EmailVerified(),
+		DisplayName:        pp.DisplayName,
+		PreferredLocations: preferredLocations,
+		PreferredJobType:   pp.PreferredJobType,
+		Timezone:           pp.Timezone,
+		Roles:              roles,
+		CreatedAt:          pp.CreatedAt,
+		UpdatedAt:          pp.UpdatedAt,
+	}
+
+	b, err := json.Marshal(tmp)
+	if err != nil {
+		return nil, fmt.Errorf("marshalling user profile to json: %w", err)
+	}
+
+	return b, nil
+}
+
+type UserProfilePresenter struct {
+	status int
+}
+
+func (p UserProfilePresenter) Present(ctx *fiber.Ctx, user internal.User) error {
+	return ctx.Status(p.status).JSON(PresentableUserProfile(user))
+}
+
+func NewUserProfilePresenter(status int) *UserProfilePresenter {
+	return &UserProfilePresenter{
+		status: status,
+	}
+}
+
+type UserProfileHandler struct {
+	userGetterByID internal.UserGetterByID
+}
+
+func (h UserProfileHandler) Handle(ctx *fiber.Ctx) error {
+	userID, err := UserIDFromContext(ctx)
+	if err != nil {
+		return fmt.Errorf("getting user id from context: %w", err)
+	}
+
+	user, err := h.userGetterByID.GetUserByID(ctx.Context(), userID)
+	if err != nil {
+		return fmt.Errorf("getting user by id: %w", err)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(PresentableUserProfile(user))
+}
+
+func NewUserProfileHandler(userGetterByID internal.UserGetterByID) *UserProfileHandler {
+	return &UserProfileHandler{
+		userGetterByID: userGetterByID,
+	}
+}
+
+type UserProfileUpdateHandler struct {
+	userProfileUpdater internal.UserProfileUpdater
+}
+
+func (h UserProfileUpdateHandler) Handle(ctx *fiber.Ctx) error {
+	userID, err := UserIDFromContext(ctx)
+	if err != nil {
+		return fmt.Errorf("getting user id from context: %w", err)
+	}
+
+	var userProfileUpdateRequest struct {
+		Email              *string   `json:"email"`
+		DisplayName        *string   `json:"display_name"`
+		PreferredLocations *[]string `json:"preferred_locations"`
+		PreferredJobType   *string   `json:"preferred_job_type"`
+		Timezone           *string   `json:"timezone"`
+	}
+
+	err = ctx.BodyParser(&userProfileUpdateRequest)
+	if err != nil {
+		return fmt.Errorf("parsing http user profile update request body: %w", err)
+	}
+
+	user, err := h.userProfileUpdater.UpdateUserProfile(ctx.Context(), internal.UserProfileUpdateRequest{
+		UserID:             userID,
+		Email:              userProfileUpdateRequest.Email,
+		DisplayName:        userProfileUpdateRequest.DisplayName,
+		PreferredLocations: userProfileUpdateRequest.PreferredLocations,
+		PreferredJobType:   userProfileUpdateRequest.PreferredJobType,
+		Timezone:           userProfileUpdateRequest.Timezone,
+	})
+	if err != nil {
+		return fmt.Errorf("updating user profile: %w", err)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(PresentableUserProfile(user))
+}
+
+func NewUserProfileUpdateHandler(userProfileUpdater internal.UserProfileUpdater) *UserProfileUpdateHandler {
+	return &UserProfileUpdateHandler{
+		userProfileUpdater: userProfileUpdater,
+	}
+}
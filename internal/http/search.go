@@ -0,0 +1,283 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/adystag/jobs-search/internal"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type PresentableSavedSearch internal.SavedSearch
+
+func (ps PresentableSavedSearch) MarshalJSON() ([]byte, error) {
+	tmp := struct {
+		ID          int64      `json:"id"`
+		Name        string     `json:"name"`
+		Description string     `json:"description"`
+		Location    string     `json:"location"`
+		FullTime    bool       `json:"full_time"`
+		LastRunAt   *time.Time `json:"last_run_at"`
+		CreatedAt   time.Time  `json:"created_at"`
+		UpdatedAt   time.Time  `json:"updated_at"`
+	}{
+		ID:          ps.ID,
+		Name:        ps.Name,
+		Description: ps.Description,
+		Location:    ps.Location,
+		FullTime:    ps.FullTime,
+		CreatedAt:   ps.CreatedAt,
+		UpdatedAt:   ps.UpdatedAt,
+	}
+
+	if !ps.LastRunAt.IsZero() {
+		tmp.LastRunAt = &ps.LastRunAt
+	}
+
+	b, err := json.Marshal(tmp)
+	if err != nil {
+		return nil, fmt.Errorf("marshalling saved search to json: %w", err)
+	}
+
+	return b, nil
+}
+
+type savedSearchRequestBody struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Location    string `json:"location"`
+	FullTime    bool   `json:"full_time"`
+}
+
+func savedSearchIDFromParams(ctx *fiber.Ctx) (int64, error) {
+	savedSearchID, err := ctx.ParamsInt("searchID")
+	if err != nil || savedSearchID <= 0 {
+		return 0, internal.NewValidationError("search_id", "numeric")
+	}
+
+	return int64(savedSearchID), nil
+}
+
+type SavedSearchesListingHandler struct {
+	savedSearchesLister internal.SavedSearchesListerByUserID
+}
+
+func (h SavedSearchesListingHandler) Handle(ctx *fiber.Ctx) error {
+	userID, err := UserIDFromContext(ctx)
+	if err != nil {
+		return fmt.Errorf("getting user id from context: %w", err)
+	}
+
+	savedSearches, err := h.savedSearchesLister.ListSavedSearchesByUserID(ctx.Context(), userID)
+	if err != nil {
+		return fmt.Errorf("listing saved searches by user id: %w", err)
+	}
+
+	presentableSavedSearches := []PresentableSavedSearch{}
+
+	for _, each := range savedSearches {
+		presentableSavedSearches = append(presentableSavedSearches, PresentableSavedSearch(each))
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(presentableSavedSearches)
+}
+
+func NewSavedSearchesListingHandler(savedSearchesLister internal.SavedSearchesListerByUserID) *SavedSearchesListingHandler {
+	return &SavedSearchesListingHandler{
+		savedSearchesLister: savedSearchesLister,
+	}
+}
+
+type SavedSearchCreationHandler struct {
+	savedSearchCreator internal.SavedSearchCreator
+}
+
+func (h SavedSearchCreationHandler) Handle(ctx *fiber.Ctx) error {
+	userID, err := UserIDFromContext(ctx)
+	if err != nil {
+		return fmt.Errorf("getting user id from context: %w", err)
+	}
+
+	var body savedSearchRequestBody
+
+	err = ctx.BodyParser(&body)
+	if err != nil {
+		return fmt.Errorf("parsing http saved search request body: %w", err)
+	}
+
+	savedSearch, err := h.savedSearchCreator.CreateSavedSearch(ctx.Context(), internal.SavedSearchRequest{
+		UserID:      userID,
+		Name:        body.Name,
+		Description: body.Description,
+		Location:    body.Location,
+		FullTime:    body.FullTime,
+	})
+	if err != nil {
+		return fmt.Errorf("creating saved search: %w", err)
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(PresentableSavedSearch(savedSearch))
+}
+
+func NewSavedSearchCreationHandler(savedSearchCreator internal.SavedSearchCreator) *SavedSearchCreationHandler {
+	return &SavedSearchCreationHandler{
+		savedSearchCreator: savedSearchCreator,
+	}
+}
+
+type SavedSearchGetterByIDHandler struct {
+	savedSearchGetterByID internal.SavedSearchGetterByID
+}
+
+func (h SavedSearchGetterByIDHandler) Handle(ctx *fiber.Ctx) error {
+	userID, err := UserIDFromContext(ctx)
+	if err != nil {
+		return fmt.Errorf("getting user id from context: %w", err)
+	}
+
+	savedSearchID, err := savedSearchIDFromParams(ctx)
+	if err != nil {
+		return fmt.Errorf("getting saved search id from params: %w", err)
+	}
+
+	savedSearch, err := h.savedSearchGetterByID.GetSavedSearchByID(ctx.Context(), userID, savedSearchID)
+	if err != nil {
+		return fmt.Errorf("getting saved search by id: %w", err)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(PresentableSavedSearch(savedSearch))
+}
+
+func NewSavedSearchGetterByIDHandler(savedSearchGetterByID internal.SavedSearchGetterByID) *SavedSearchGetterByIDHandler {
+	return &SavedSearchGetterByIDHandler{
+		savedSearchGetterByID: savedSearchGetterByID,
+	}
+}
+
+type SavedSearchUpdateHandler struct {
+	savedSearchUpdater internal.SavedSearchUpdater
+}
+
+func (h SavedSearchUpdateHandler) Handle(ctx *fiber.Ctx) error {
+	userID, err := UserIDFromContext(ctx)
+	if err != nil {
+		return fmt.Errorf("getting user id from context: %w", err)
+	}
+
+	savedSearchID, err := savedSearchIDFromParams(ctx)
+	if err != nil {
+		return fmt.Errorf("getting saved search id from params: %w", err)
+	}
+
+	var body savedSearchRequestBody
+
+	err = ctx.BodyParser(&body)
+	if err != nil {
+		return fmt.Errorf("parsing http saved search request body: %w", err)
+	}
+
+	savedSearch, err := h.savedSearchUpdater.UpdateSavedSearch(ctx.Context(), savedSearchID, internal.SavedSearchRequest{
+		UserID:      userID,
+		Name:        body.Name,
+		Description: body.Description,
+		Location:    body.Location,
+		FullTime:    body.FullTime,
+	})
+	if err != nil {
+		return fmt.Errorf("updating saved search: %w", err)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(PresentableSavedSearch(savedSearch))
+}
+
+func NewSavedSearchUpdateHandler(savedSearchUpdater internal.SavedSearchUpdater) *SavedSearchUpdateHandler {
+	return &SavedSearchUpdateHandler{
+		savedSearchUpdater: savedSearchUpdater,
+	}
+}
+
+type SavedSearchDeletionHandler struct {
+	savedSearchDeleter internal.SavedSearchDeleter
+}
+
+func (h SavedSearchDeletionHandler) Handle(ctx *fiber.Ctx) error {
+	userID, err := UserIDFromContext(ctx)
+	if err != nil {
+		return fmt.Errorf("getting user id from context: %w", err)
+	}
+
+	savedSearchID, err := savedSearchIDFromParams(ctx)
+	if err != nil {
+		return fmt.Errorf("getting saved search id from params: %w", err)
+	}
+
+	err = h.savedSearchDeleter.DeleteSavedSearch(ctx.Context(), userID, savedSearchID)
+	if err != nil {
+		return fmt.Errorf("deleting saved search: %w", err)
+	}
+
+	return ctx.SendStatus(fiber.StatusNoContent)
+}
+
+func NewSavedSearchDeletionHandler(savedSearchDeleter internal.SavedSearchDeleter) *SavedSearchDeletionHandler {
+	return &SavedSearchDeletionHandler{
+		savedSearchDeleter: savedSearchDeleter,
+	}
+}
+
+type SavedSearchRunningHandler struct {
+	savedSearchRunner internal.SavedSearchRunner
+	jobsCursorDecoder internal.JobsCursorDecoder
+	presenter         Presenter[internal.JobsListResult]
+}
+
+func (h SavedSearchRunningHandler) Handle(ctx *fiber.Ctx) error {
+	userID, err := UserIDFromContext(ctx)
+	if err != nil {
+		return fmt.Errorf("getting user id from context: %w", err)
+	}
+
+	savedSearchID, err := savedSearchIDFromParams(ctx)
+	if err != nil {
+		return fmt.Errorf("getting saved search id from params: %w", err)
+	}
+
+	opts := []internal.Option[internal.JobsListerOption]{}
+
+	if len(ctx.Query("cursor")) > 0 {
+		cursor, err := jobsCursorFromQuery(ctx, h.jobsCursorDecoder)
+		if err != nil {
+			return fmt.Errorf("getting jobs cursor from query: %w", err)
+		}
+
+		opts = append(opts, internal.WithJobsListerCursor(cursor))
+	} else {
+		page := ctx.QueryInt("page", 1)
+		if page < 1 {
+			page = 1
+		}
+
+		opts = append(opts, internal.WithJobsListerPage(page))
+	}
+
+	jobsListResult, err := h.savedSearchRunner.RunSavedSearch(ctx.Context(), userID, savedSearchID, opts...)
+	if err != nil {
+		return fmt.Errorf("running saved search: %w", err)
+	}
+
+	return h.presenter.Present(ctx, jobsListResult)
+}
+
+func NewSavedSearchRunningHandler(
+	savedSearchRunner internal.SavedSearchRunner,
+	jobsCursorDecoder internal.JobsCursorDecoder,
+	presenter Presenter[internal.JobsListResult],
+) *SavedSearchRunningHandler {
+	return &SavedSearchRunningHandler{
+		savedSearchRunner: savedSearchRunner,
+		jobsCursorDecoder: jobsCursorDecoder,
+		presenter:         presenter,
+	}
+}
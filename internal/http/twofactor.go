@@ -0,0 +1,198 @@
+package http
+
+import (
+	"fmt"
+
+	"github.com/adystag/jobs-search/internal"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type TwoFactorUserPresenter struct {
+	timer                    internal.Timer
+	twoFactorChallengeIssuer internal.TwoFactorChallengeIssuer
+	userPresenter            Presenter[internal.User]
+}
+
+func (p TwoFactorUserPresenter) Present(ctx *fiber.Ctx, user internal.User) error {
+	if !user.TwoFactorEnabled() {
+		return p.userPresenter.Present(ctx, user)
+	}
+
+	twoFactorChallenge, token, err := p.twoFactorChallengeIssuer.IssueTwoFactorChallenge(ctx.Context(), user)
+	if err != nil {
+		return fmt.Errorf("issuing two factor challenge for user: %w", err)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"two_factor_required": true,
+		"challenge_token":     token,
+		"expires_in":          twoFactorChallenge.ExpiresAt.Sub(p.timer.Now()).Seconds(),
+	})
+}
+
+func NewTwoFactorUserPresenter(
+	timer internal.Timer,
+	twoFactorChallengeIssuer internal.TwoFactorChallengeIssuer,
+	userPresenter Presenter[internal.User],
+) *TwoFactorUserPresenter {
+	return &TwoFactorUserPresenter{
+		timer:                    timer,
+		twoFactorChallengeIssuer: twoFactorChallengeIssuer,
+		userPresenter:            userPresenter,
+	}
+}
+
+type TwoFactorAuthenticationHandler struct {
+	twoFactorAuthenticator internal.TwoFactorAuthenticator
+	userPresenter          Presenter[internal.User]
+}
+
+func (h TwoFactorAuthenticationHandler) Handle(ctx *fiber.Ctx) error {
+	var twoFactorAuthenticationRequest struct {
+		ChallengeToken string `json:"challenge_token"`
+		Code           string `json:"code"`
+	}
+
+	err := ctx.BodyParser(&twoFactorAuthenticationRequest)
+	if err != nil {
+		return fmt.Errorf("parsing http two factor authentication request body: %w", err)
+	}
+
+	user, err := h.twoFactorAuthenticator.AuthenticateTwoFactor(ctx.Context(), internal.TwoFactorAuthenticationRequest{
+		ChallengeToken: twoFactorAuthenticationRequest.ChallengeToken,
+		Code:           twoFactorAuthenticationRequest.Code,
+	})
+	if err != nil {
+		return fmt.Errorf("authenticating two factor: %w", err)
+	}
+
+	return h.userPresenter.Present(ctx, user)
+}
+
+func NewTwoFactorAuthenticationHandler(
+	twoFactorAuthenticator internal.TwoFactorAuthenticator,
+	userPresenter Presenter[internal.User],
+) *TwoFactorAuthenticationHandler {
+	return &TwoFactorAuthenticationHandler{
+		twoFactorAuthenticator: twoFactorAuthenticator,
+		userPresenter:          userPresenter,
+	}
+}
+
+type TwoFactorEnrollmentHandler struct {
+	twoFactorEnroller internal.TwoFactorEnroller
+}
+
+func (h TwoFactorEnrollmentHandler) Handle(ctx *fiber.Ctx) error {
+	userID, err := UserIDFromContext(ctx)
+	if err != nil {
+		return fmt.Errorf("getting user id from context: %w", err)
+	}
+
+	var twoFactorEnrollmentRequest struct {
+		Password string `json:"password"`
+	}
+
+	err = ctx.BodyParser(&twoFactorEnrollmentRequest)
+	if err != nil {
+		return fmt.Errorf("parsing http two factor enrollment request body: %w", err)
+	}
+
+	twoFactorEnrollment, err := h.twoFactorEnroller.EnrollTwoFactor(ctx.Context(), internal.TwoFactorEnrollmentRequest{
+		UserID:   userID,
+		Password: twoFactorEnrollmentRequest.Password,
+	})
+	if err != nil {
+		return fmt.Errorf("enrolling two factor: %w", err)
+	}
+
+	ctx.Set(fiber.HeaderCacheControl, "no-store")
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"secret":      twoFactorEnrollment.Secret,
+		"otpauth_uri": twoFactorEnrollment.URI,
+	})
+}
+
+func NewTwoFactorEnrollmentHandler(twoFactorEnroller internal.TwoFactorEnroller) *TwoFactorEnrollmentHandler {
+	return &TwoFactorEnrollmentHandler{
+		twoFactorEnroller: twoFactorEnroller,
+	}
+}
+
+type TwoFactorConfirmationHandler struct {
+	twoFactorConfirmer internal.TwoFactorConfirmer
+}
+
+func (h TwoFactorConfirmationHandler) Handle(ctx *fiber.Ctx) error {
+	userID, err := UserIDFromContext(ctx)
+	if err != nil {
+		return fmt.Errorf("getting user id from context: %w", err)
+	}
+
+	var twoFactorConfirmationRequest struct {
+		Code string `json:"code"`
+	}
+
+	err = ctx.BodyParser(&twoFactorConfirmationRequest)
+	if err != nil {
+		return fmt.Errorf("parsing http two factor confirmation request body: %w", err)
+	}
+
+	recoveryCodes, err := h.twoFactorConfirmer.ConfirmTwoFactor(ctx.Context(), internal.TwoFactorConfirmationRequest{
+		UserID: userID,
+		Code:   twoFactorConfirmationRequest.Code,
+	})
+	if err != nil {
+		return fmt.Errorf("confirming two factor: %w", err)
+	}
+
+	ctx.Set(fiber.HeaderCacheControl, "no-store")
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"recovery_codes": recoveryCodes,
+	})
+}
+
+func NewTwoFactorConfirmationHandler(twoFactorConfirmer internal.TwoFactorConfirmer) *TwoFactorConfirmationHandler {
+	return &TwoFactorConfirmationHandler{
+		twoFactorConfirmer: twoFactorConfirmer,
+	}
+}
+
+type TwoFactorDisableHandler struct {
+	twoFactorDisabler internal.TwoFactorDisabler
+}
+
+func (h TwoFactorDisableHandler) Handle(ctx *fiber.Ctx) error {
+	userID, err := UserIDFromContext(ctx)
+	if err != nil {
+		return fmt.Errorf("getting user id from context: %w", err)
+	}
+
+	var twoFactorDisableRequest struct {
+		Password string `json:"password"`
+	}
+
+	err = ctx.BodyParser(&twoFactorDisableRequest)
+	if err != nil {
+		return fmt.Errorf("parsing http two factor disable request body: %w", err)
+	}
+
+	err = h.twoFactorDisabler.DisableTwoFactor(ctx.Context(), internal.TwoFactorDisableRequest{
+		UserID:   userID,
+		Password: twoFactorDisableRequest.Password,
+	})
+	if err != nil {
+		return fmt.Errorf("disabling two factor: %w", err)
+	}
+
+	return ctx.SendStatus(fiber.StatusNoContent)
+}
+
+func NewTwoFactorDisableHandler(twoFactorDisabler internal.TwoFactorDisabler) *TwoFactorDisableHandler {
+	return &TwoFactorDisableHandler{
+		twoFactorDisabler: twoFactorDisabler,
+	}
+}
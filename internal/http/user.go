@@ -9,44 +9,93 @@ import (
 
 	"github.com/dgrijalva/jwt-go"
 	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
 )
 
-type JWTUserPresenter struct {
+type JWTSessionPresenter struct {
 	timer    internal.Timer
 	url      string
 	lifeTime time.Duration
-	secret   []byte
+	keySet   *JWTKeySet
 }
 
-func (p JWTUserPresenter) Present(ctx *fiber.Ctx, user internal.User) error {
+func (p JWTSessionPresenter) Present(ctx *fiber.Ctx, session internal.Session) error {
+	roles := []string{}
+
+	for _, each := range session.User.Roles {
+		roles = append(roles, string(each))
+	}
+
+	var authenticationMethods []string
+
+	if session.TwoFactor {
+		authenticationMethods = append(authenticationMethods, AuthenticationMethodMFA)
+	}
+
 	now := p.timer.Now()
-	accessToken, err := jwt.NewWithClaims(jwt.SigningMethodHS512, jwt.StandardClaims{
-		Audience:  strconv.FormatInt(user.ID, 10),
-		Issuer:    p.url,
-		IssuedAt:  now.Unix(),
-		ExpiresAt: now.Add(p.lifeTime).Unix(),
-	}).SignedString(p.secret)
+	accessToken, err := p.keySet.Sign(AccessTokenClaims{
+		StandardClaims: jwt.StandardClaims{
+			Id:        uuid.New().String(),
+			Subject:   strconv.FormatInt(session.User.ID, 10),
+			Issuer:    p.url,
+			IssuedAt:  now.Unix(),
+			NotBefore: now.Unix(),
+			ExpiresAt: now.Add(p.lifeTime).Unix(),
+		},
+		TokenVersion:          session.User.TokenVersion,
+		Roles:                 roles,
+		AuthenticationMethods: authenticationMethods,
+	})
 	if err != nil {
 		return fmt.Errorf("generating jwt token from user: %w", err)
 	}
 
 	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
-		"access_token": accessToken,
-		"expires_in":   p.lifeTime.Seconds(),
+		"access_token":       accessToken,
+		"expires_in":         p.lifeTime.Seconds(),
+		"refresh_token":      session.RefreshToken,
+		"refresh_expires_in": session.RefreshTokenExpiresAt.Sub(now).Seconds(),
 	})
 }
 
-func NewJWTUserPresenter(
+func NewJWTSessionPresenter(
 	timer internal.Timer,
 	url string,
 	lifeTime time.Duration,
-	secret []byte,
-) *JWTUserPresenter {
-	return &JWTUserPresenter{
+	keySet *JWTKeySet,
+) *JWTSessionPresenter {
+	return &JWTSessionPresenter{
 		timer:    timer,
 		url:      url,
 		lifeTime: lifeTime,
-		secret:   secret,
+		keySet:   keySet,
+	}
+}
+
+type JWTUserPresenter struct {
+	sessionIssuer    internal.SessionIssuer
+	sessionPresenter Presenter[internal.Session]
+	twoFactor        bool
+}
+
+func (p JWTUserPresenter) Present(ctx *fiber.Ctx, user internal.User) error {
+	session, err := p.sessionIssuer.IssueSession(ctx.Context(), user, p.twoFactor)
+	if err != nil {
+		return fmt.Errorf("issuing session for user: %w", err)
+	}
+
+	return p.sessionPresenter.Present(ctx, session)
+}
+
+func NewJWTUserPresenter(
+	sessionIssuer internal.SessionIssuer,
+	sessionPresenter Presenter[internal.Session],
+	twoFactor bool,
+) *JWTUserPresenter {
+	return &JWTUserPresenter{
+		sessionIssuer:    sessionIssuer,
+		sessionPresenter: sessionPresenter,
+		twoFactor:        twoFactor,
 	}
 }
 
@@ -60,6 +109,7 @@ func (h UserRegistrationHandler) Handle(ctx *fiber.Ctx) error {
 		Username             string `json:"username"`
 		Password             string `json:"password"`
 		PasswordConfirmation string `json:"password_confirmation"`
+		Email                string `json:"email"`
 	}
 
 	err := ctx.BodyParser(&userRegistrationRequest)
@@ -73,6 +123,7 @@ func (h UserRegistrationHandler) Handle(ctx *fiber.Ctx) error {
 			Password: userRegistrationRequest.Password,
 		},
 		PasswordConfirmation: userRegistrationRequest.PasswordConfirmation,
+		Email:                userRegistrationRequest.Email,
 	})
 	if err != nil {
 		return fmt.Errorf("registering user: %w", err)
@@ -110,6 +161,7 @@ func (h UserAuthenticationHandler) Handle(ctx *fiber.Ctx) error {
 	user, err := h.userAuthenticator.AuthenticateUser(ctx.Context(), internal.UserAuthenticationRequest{
 		Username: userAuthenticationRequest.Username,
 		Password: userAuthenticationRequest.Password,
+		IP:       ctx.IP(),
 	})
 	if err != nil {
 		return fmt.Errorf("authenticating user: %w", err)
@@ -127,3 +179,81 @@ func NewUserAuthenticationHandler(
 		userPresenter:     userPresenter,
 	}
 }
+
+type SessionRefreshHandler struct {
+	sessionRefresher internal.SessionRefresher
+	sessionPresenter Presenter[internal.Session]
+}
+
+func (h SessionRefreshHandler) Handle(ctx *fiber.Ctx) error {
+	var sessionRefreshRequest struct {
+		RefreshToken string `json:"refresh_token"`
+	}
+
+	err := ctx.BodyParser(&sessionRefreshRequest)
+	if err != nil {
+		return fmt.Errorf("parsing http session refresh request body: %w", err)
+	}
+
+	session, err := h.sessionRefresher.RefreshSession(ctx.Context(), sessionRefreshRequest.RefreshToken)
+	if err != nil {
+		return fmt.Errorf("refreshing session: %w", err)
+	}
+
+	return h.sessionPresenter.Present(ctx, session)
+}
+
+func NewSessionRefreshHandler(
+	sessionRefresher internal.SessionRefresher,
+	sessionPresenter Presenter[internal.Session],
+) *SessionRefreshHandler {
+	return &SessionRefreshHandler{
+		sessionRefresher: sessionRefresher,
+		sessionPresenter: sessionPresenter,
+	}
+}
+
+type SessionTerminationHandler struct {
+	sessionTerminator internal.SessionTerminator
+}
+
+func (h SessionTerminationHandler) Handle(ctx *fiber.Ctx) error {
+	userID, err := UserIDFromContext(ctx)
+	if err != nil {
+		return fmt.Errorf("getting user id from context: %w", err)
+	}
+
+	var sessionTerminationRequest struct {
+		RefreshToken string `json:"refresh_token"`
+		All          bool   `json:"all"`
+	}
+
+	if len(ctx.Body()) > 0 {
+		err = ctx.BodyParser(&sessionTerminationRequest)
+		if err != nil {
+			return fmt.Errorf("parsing http session termination request body: %w", err)
+		}
+	}
+
+	accessTokenID, _ := ctx.Context().UserValue(AccessTokenIDContextValue).(string)
+	accessTokenExpiresAt, _ := ctx.Context().UserValue(AccessTokenExpiresAtContextValue).(time.Time)
+
+	err = h.sessionTerminator.TerminateSession(ctx.Context(), internal.SessionTerminationRequest{
+		UserID:               userID,
+		AccessTokenID:        accessTokenID,
+		AccessTokenExpiresAt: accessTokenExpiresAt,
+		RefreshToken:         sessionTerminationRequest.RefreshToken,
+		All:                  sessionTerminationRequest.All,
+	})
+	if err != nil {
+		return fmt.Errorf("terminating session: %w", err)
+	}
+
+	return ctx.SendStatus(fiber.StatusNoContent)
+}
+
+func NewSessionTerminationHandler(sessionTerminator internal.SessionTerminator) *SessionTerminationHandler {
+	return &SessionTerminationHandler{
+		sessionTerminator: sessionTerminator,
+	}
+}
@@ -1,24 +1,69 @@
 package internal
 
 import (
+	"bytes"
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
 
+	"github.com/go-playground/validator/v10"
 	"github.com/google/uuid"
 )
 
+var (
+	ErrJobNotFound     = errors.New("job not found")
+	ErrUpstreamFailed  = errors.New("upstream request failed")
+	ErrUpstreamTimeout = errors.New("upstream request timed out")
+
+	ErrUpstreamUnavailable = errors.New("upstream is unavailable")
+	ErrJobsCursorInvalid   = errors.New("jobs cursor is invalid")
+)
+
 type JobsLister interface {
-	ListJobs(ctx context.Context, opts ...Option[JobsListerOption]) ([]Job, error)
+	ListJobs(ctx context.Context, opts ...Option[JobsListerOption]) (JobsListResult, error)
 }
 
 type JobGetterByID interface {
 	GetJobByID(ctx context.Context, jobID string) (Job, error)
 }
 
+type JobsStorer interface {
+	StoreJobs(ctx context.Context, jobs []Job) error
+}
+
+type JobsSynchronizer interface {
+	SynchronizeJobs(ctx context.Context) (int, error)
+}
+
+type JobCacheStatsGetter interface {
+	GetJobCacheStats(ctx context.Context) (JobCacheStats, error)
+}
+
+type JobsCursorEncoder interface {
+	EncodeJobsCursor(cursor JobsCursor) (string, error)
+}
+
+type JobsCursorDecoder interface {
+	DecodeJobsCursor(token string) (JobsCursor, error)
+}
+
+const JobTypeFullTime = "Full Time"
+
+var jobsListerQueryPattern = regexp.MustCompile(`^[\p{L}\p{M}\p{N}\p{Zs}.,:;!?'"&+#@%/()_-]*$`)
+
 type JobsListerOption struct {
 	Description string
 	Location    string
 	FullTime    bool
 	Page        int
+	Cursor      JobsCursor
 }
 
 func WithJobsListerDescription(description string) Option[JobsListerOption] {
@@ -45,6 +90,35 @@ func WithJobsListerPage(page int) Option[JobsListerOption] {
 	}
 }
 
+func WithJobsListerCursor(cursor JobsCursor) Option[JobsListerOption] {
+	return func(opt *JobsListerOption) {
+		opt.Cursor = cursor
+	}
+}
+
+type JobsCursor struct {
+	PostedAt time.Time
+	ID       uuid.UUID
+}
+
+func (c JobsCursor) IsZero() bool {
+	return c.ID == uuid.Nil
+}
+
+type JobCacheStats struct {
+	Hits      int64
+	StaleHits int64
+	Misses    int64
+}
+
+type JobsListResult struct {
+	Jobs       []Job
+	Page       int
+	PerPage    int
+	HasNext    bool
+	NextCursor JobsCursor
+}
+
 type Job struct {
 	ID          uuid.UUID
 	Company     string
@@ -58,3 +132,224 @@ type Job struct {
 	HowToApply  string
 	CreatedAt   string
 }
+
+type jobsSynchronizer struct {
+	jobsLister JobsLister
+	jobsStorer JobsStorer
+	maxPages   int
+}
+
+func (js jobsSynchronizer) SynchronizeJobs(ctx context.Context) (int, error) {
+	synchronized := map[uuid.UUID]struct{}{}
+
+	for page := 1; page <= js.maxPages; page++ {
+		jobsListResult, err := js.jobsLister.ListJobs(ctx, WithJobsListerPage(page))
+		if err != nil {
+			return len(synchronized), fmt.Errorf("listing jobs page %d: %w", page, err)
+		}
+
+		var newJobs []Job
+
+		for _, each := range jobsListResult.Jobs {
+			if _, ok := synchronized[each.ID]; !ok {
+				newJobs = append(newJobs, each)
+			}
+		}
+
+		if len(newJobs) == 0 {
+			break
+		}
+
+		err = js.jobsStorer.StoreJobs(ctx, newJobs)
+		if err != nil {
+			return len(synchronized), fmt.Errorf("storing jobs page %d: %w", page, err)
+		}
+
+		for _, each := range newJobs {
+			synchronized[each.ID] = struct{}{}
+		}
+	}
+
+	return len(synchronized), nil
+}
+
+func NewJobsSynchronizer(jobsLister JobsLister, jobsStorer JobsStorer, maxPages int) *jobsSynchronizer {
+	return &jobsSynchronizer{
+		jobsLister: jobsLister,
+		jobsStorer: jobsStorer,
+		maxPages:   maxPages,
+	}
+}
+
+type jobsListerOptionValidator struct {
+	validate *validator.Validate
+	maxPage  int
+}
+
+func (v jobsListerOptionValidator) EvaluateErrorAs(err, target error) error {
+	if errors.As(err, &validator.ValidationErrors{}) {
+		err = target
+	}
+
+	return err
+}
+
+func (v jobsListerOptionValidator) Validate(ctx context.Context, opt JobsListerOption) error {
+	validationErrors := ValidationErrors{}
+
+	err := validationErrors.Collect(v.validateQuery(ctx, "description", opt.Description))
+	if err != nil {
+		return err
+	}
+
+	err = validationErrors.Collect(v.validateQuery(ctx, "location", opt.Location))
+	if err != nil {
+		return err
+	}
+
+	if opt.Cursor.IsZero() {
+		err = validationErrors.Collect(v.validatePage(ctx, opt.Page))
+		if err != nil {
+			return err
+		}
+	} else if opt.Page != 0 {
+		validationErrors = append(validationErrors, NewValidationError("page", "excluded_with=cursor"))
+	}
+
+	return validationErrors.Err()
+}
+
+func (v jobsListerOptionValidator) validateQuery(ctx context.Context, field, query string) error {
+	err := v.validate.VarCtx(ctx, query, "max=255")
+	if err != nil {
+		return v.EvaluateErrorAs(err, NewValidationError(field, "max=255"))
+	}
+
+	if !jobsListerQueryPattern.MatchString(query) {
+		return NewValidationError(field, "charset")
+	}
+
+	return nil
+}
+
+func (v jobsListerOptionValidator) validatePage(ctx context.Context, page int) error {
+	err := v.validate.VarCtx(ctx, page, "min=1")
+	if err != nil {
+		return v.EvaluateErrorAs(err, NewValidationError("page", "min=1"))
+	}
+
+	tag := fmt.Sprintf("max=%d", v.maxPage)
+
+	err = v.validate.VarCtx(ctx, page, tag)
+	if err != nil {
+		return v.EvaluateErrorAs(err, NewValidationError("page", tag))
+	}
+
+	return nil
+}
+
+func NewJobsListerOptionValidator(validate *validator.Validate, maxPage int) *jobsListerOptionValidator {
+	return &jobsListerOptionValidator{
+		validate: validate,
+		maxPage:  maxPage,
+	}
+}
+
+type jobsCursorPayload struct {
+	PostedAt  int64  `json:"p,omitempty"`
+	ID        string `json:"i"`
+	ExpiresAt int64  `json:"e"`
+}
+
+type hmacJobsCursorCodec struct {
+	timer    Timer
+	secret   []byte
+	lifeTime time.Duration
+}
+
+func (c hmacJobsCursorCodec) EncodeJobsCursor(cursor JobsCursor) (string, error) {
+	payload := jobsCursorPayload{
+		ID:        cursor.ID.String(),
+		ExpiresAt: c.timer.Now().Add(c.lifeTime).Unix(),
+	}
+
+	if !cursor.PostedAt.IsZero() {
+		payload.PostedAt = cursor.PostedAt.UnixNano()
+	}
+
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return "", fmt.Errorf("marshalling jobs cursor payload to json: %w", err)
+	}
+
+	encodedPayload := base64.RawURLEncoding.EncodeToString(b)
+
+	return encodedPayload + "." + base64.RawURLEncoding.EncodeToString(c.sign(encodedPayload)), nil
+}
+
+func (c hmacJobsCursorCodec) DecodeJobsCursor(token string) (JobsCursor, error) {
+	encodedPayload, encodedSignature, ok := strings.Cut(token, ".")
+	if !ok {
+		return JobsCursor{}, fmt.Errorf("%w: cursor is malformed", ErrJobsCursorInvalid)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(encodedSignature)
+	if err != nil {
+		return JobsCursor{}, fmt.Errorf("%w: %w", ErrJobsCursorInvalid, err)
+	}
+
+	if !hmac.Equal(signature, c.sign(encodedPayload)) {
+		return JobsCursor{}, fmt.Errorf("%w: cursor signature mismatch", ErrJobsCursorInvalid)
+	}
+
+	b, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil {
+		return JobsCursor{}, fmt.Errorf("%w: %w", ErrJobsCursorInvalid, err)
+	}
+
+	var payload jobsCursorPayload
+
+	err = json.Unmarshal(b, &payload)
+	if err != nil {
+		return JobsCursor{}, fmt.Errorf("%w: %w", ErrJobsCursorInvalid, err)
+	}
+
+	if !c.timer.Now().Before(time.Unix(payload.ExpiresAt, 0)) {
+		return JobsCursor{}, fmt.Errorf("%w: cursor has expired", ErrJobsCursorInvalid)
+	}
+
+	id, err := uuid.Parse(payload.ID)
+	if err != nil {
+		return JobsCursor{}, fmt.Errorf("%w: %w", ErrJobsCursorInvalid, err)
+	}
+
+	cursor := JobsCursor{
+		ID: id,
+	}
+
+	if payload.PostedAt != 0 {
+		cursor.PostedAt = time.Unix(0, payload.PostedAt).UTC()
+	}
+
+	return cursor, nil
+}
+
+func (c hmacJobsCursorCodec) sign(encodedPayload string) []byte {
+	mac := hmac.New(sha256.New, c.secret)
+
+	mac.Write(bytes.NewBufferString(encodedPayload).Bytes())
+
+	return mac.Sum(nil)
+}
+
+func NewHMACJobsCursorCodec(timer Timer, secret []byte, lifeTime time.Duration) (*hmacJobsCursorCodec, error) {
+	if len(secret) == 0 {
+		return nil, errors.New("jobs cursor secret is empty")
+	}
+
+	return &hmacJobsCursorCodec{
+		timer:    timer,
+		secret:   secret,
+		lifeTime: lifeTime,
+	}, nil
+}
@@ -0,0 +1,208 @@
+package internal_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/adystag/jobs-search/internal"
+
+	"github.com/google/uuid"
+)
+
+func TestHMACJobsCursorCodec(t *testing.T) {
+	now := time.Date(2023, time.May, 4, 7, 31, 44, 0, time.UTC)
+	cursor := internal.JobsCursor{
+		PostedAt: now.Add(-time.Hour),
+		ID:       uuid.New(),
+	}
+
+	encoder, err := internal.NewHMACJobsCursorCodec(fixedTimer{now: now}, []byte("secret"), time.Hour)
+	if err != nil {
+		t.Fatalf("NewHMACJobsCursorCodec() error = %v", err)
+	}
+
+	token, err := encoder.EncodeJobsCursor(cursor)
+	if err != nil {
+		t.Fatalf("EncodeJobsCursor() error = %v", err)
+	}
+
+	payload, signature, _ := strings.Cut(token, ".")
+	otherToken, err := encoder.EncodeJobsCursor(internal.JobsCursor{ID: uuid.New()})
+	if err != nil {
+		t.Fatalf("EncodeJobsCursor() error = %v", err)
+	}
+
+	otherPayload, _, _ := strings.Cut(otherToken, ".")
+
+	tests := []struct {
+		name    string
+		secret  string
+		now     time.Time
+		token   string
+		wantErr bool
+	}{
+		{
+			name:   "valid cursor",
+			secret: "secret",
+			now:    now.Add(59 * time.Minute),
+			token:  token,
+		},
+		{
+			name:    "expired cursor",
+			secret:  "secret",
+			now:     now.Add(time.Hour),
+			token:   token,
+			wantErr: true,
+		},
+		{
+			name:    "another secret",
+			secret:  "another-secret",
+			now:     now,
+			token:   token,
+			wantErr: true,
+		},
+		{
+			name:    "tampered payload",
+			secret:  "secret",
+			now:     now,
+			token:   otherPayload + "." + signature,
+			wantErr: true,
+		},
+		{
+			name:    "tampered signature",
+			secret:  "secret",
+			now:     now,
+			token:   payload + "." + strings.Repeat("A", len(signature)),
+			wantErr: true,
+		},
+		{
+			name:    "missing signature",
+			secret:  "secret",
+			now:     now,
+			token:   payload,
+			wantErr: true,
+		},
+		{
+			name:    "malformed signature",
+			secret:  "secret",
+			now:     now,
+			token:   payload + ".!",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decoder, err := internal.NewHMACJobsCursorCodec(fixedTimer{now: tt.now}, []byte(tt.secret), time.Hour)
+			if err != nil {
+				t.Fatalf("NewHMACJobsCursorCodec() error = %v", err)
+			}
+
+			got, err := decoder.DecodeJobsCursor(tt.token)
+			if tt.wantErr {
+				if !errors.Is(err, internal.ErrJobsCursorInvalid) {
+					t.Fatalf("DecodeJobsCursor() error = %v, want %v", err, internal.ErrJobsCursorInvalid)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("DecodeJobsCursor() error = %v", err)
+			}
+
+			if got.ID != cursor.ID || !got.PostedAt.Equal(cursor.PostedAt) {
+				t.Errorf("DecodeJobsCursor() = %+v, want %+v", got, cursor)
+			}
+		})
+	}
+}
+
+type pagedJobsLister struct {
+	pages map[int][]internal.Job
+	calls []int
+}
+
+func (l *pagedJobsLister) ListJobs(
+	ctx context.Context,
+	opts ...internal.Option[internal.JobsListerOption],
+) (internal.JobsListResult, error) {
+	opt := internal.JobsListerOption{}
+
+	internal.ApplyOptions(&opt, opts...)
+
+	l.calls = append(l.calls, opt.Page)
+
+	return internal.JobsListResult{
+		Jobs: l.pages[opt.Page],
+		Page: opt.Page,
+	}, nil
+}
+
+type recordingJobsStorer struct {
+	jobs []internal.Job
+}
+
+func (s *recordingJobsStorer) StoreJobs(ctx context.Context, jobs []internal.Job) error {
+	s.jobs = append(s.jobs, jobs...)
+
+	return nil
+}
+
+func TestJobsSynchronizerPaging(t *testing.T) {
+	jobs := []internal.Job{{ID: uuid.New()}, {ID: uuid.New()}, {ID: uuid.New()}}
+
+	tests := []struct {
+		name      string
+		pages     map[int][]internal.Job
+		maxPages  int
+		wantCalls []int
+		wantJobs  int
+	}{
+		{
+			name:      "stops at empty page",
+			pages:     map[int][]internal.Job{1: jobs[:2], 2: jobs[2:]},
+			maxPages:  10,
+			wantCalls: []int{1, 2, 3},
+			wantJobs:  3,
+		},
+		{
+			name:      "stops at repeated page",
+			pages:     map[int][]internal.Job{1: jobs, 2: jobs},
+			maxPages:  10,
+			wantCalls: []int{1, 2},
+			wantJobs:  3,
+		},
+		{
+			name:      "stops at max pages",
+			pages:     map[int][]internal.Job{1: jobs[:1], 2: jobs[1:2], 3: jobs[2:]},
+			maxPages:  2,
+			wantCalls: []int{1, 2},
+			wantJobs:  2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lister := &pagedJobsLister{pages: tt.pages}
+			storer := &recordingJobsStorer{}
+
+			synchronized, err := internal.NewJobsSynchronizer(lister, storer, tt.maxPages).SynchronizeJobs(context.Background())
+			if err != nil {
+				t.Fatalf("SynchronizeJobs() error = %v", err)
+			}
+
+			if synchronized != tt.wantJobs || len(storer.jobs) != tt.wantJobs {
+				t.Errorf("SynchronizeJobs() = %d, stored %d, want %d", synchronized, len(storer.jobs), tt.wantJobs)
+			}
+
+			if !reflect.DeepEqual(lister.calls, tt.wantCalls) {
+				t.Errorf("listed pages = %v, want %v", lister.calls, tt.wantCalls)
+			}
+		})
+	}
+}
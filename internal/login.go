@@ -0,0 +1,329 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var (
+	ErrLoginThrottled = errors.New("too many failed login attempts")
+	ErrAccountLocked  = errors.New("account is temporarily locked")
+)
+
+type LoginThrottleScope string
+
+const (
+	LoginThrottleScopeUsername LoginThrottleScope = "username"
+	LoginThrottleScopeIP       LoginThrottleScope = "ip"
+)
+
+type LoginThrottleGetter interface {
+	GetLoginThrottle(ctx context.Context, key LoginThrottleKey) (LoginThrottle, error)
+}
+
+type LoginFailureRecorder interface {
+	RecordLoginFailure(ctx context.Context, key LoginThrottleKey, failedAt, windowStart time.Time) (LoginThrottle, error)
+}
+
+type LoginLocker interface {
+	LockLogin(ctx context.Context, key LoginThrottleKey, lockedUntil time.Time) error
+}
+
+type LoginThrottleResetter interface {
+	ResetLoginThrottle(ctx context.Context, key LoginThrottleKey) error
+}
+
+type UserLoginThrottler interface {
+	CheckUserLoginThrottle(ctx context.Context, username string) error
+	RecordUserLoginFailure(ctx context.Context, username string) error
+	ResetUserLoginThrottle(ctx context.Context, username string) error
+}
+
+type LoginAttemptAuditor interface {
+	AuditLoginAttempt(ctx context.Context, attempt LoginAttempt) error
+}
+
+type LoginThrottleKey struct {
+	Scope LoginThrottleScope
+	Value string
+}
+
+type LoginThrottle struct {
+	Key          LoginThrottleKey
+	Failures     int
+	LastFailedAt time.Time
+	LockedUntil  time.Time
+}
+
+type LoginAttempt struct {
+	Username    string
+	IP          string
+	Succeeded   bool
+	Reason      string
+	AttemptedAt time.Time
+}
+
+type LoginPolicy struct {
+	MaxUsernameFailures int
+	MaxIPFailures       int
+	FailureWindow       time.Duration
+	LockoutDuration     time.Duration
+	DelayBase           time.Duration
+	DelayMax            time.Duration
+}
+
+type RetryAfterError struct {
+	err        error
+	retryAfter time.Duration
+}
+
+func (e RetryAfterError) Error() string {
+	return fmt.Sprintf("%s, retry after %s", e.err, e.retryAfter)
+}
+
+func (e RetryAfterError) Unwrap() error {
+	return e.err
+}
+
+func (e RetryAfterError) RetryAfter() time.Duration {
+	return e.retryAfter
+}
+
+func NewRetryAfterError(err error, retryAfter time.Duration) RetryAfterError {
+	return RetryAfterError{
+		err:        err,
+		retryAfter: retryAfter,
+	}
+}
+
+type throttledUserAuthenticator struct {
+	timer                 Timer
+	policy                LoginPolicy
+	userAuthenticator     UserAuthenticator
+	loginThrottleGetter   LoginThrottleGetter
+	loginFailureRecorder  LoginFailureRecorder
+	loginLocker           LoginLocker
+	loginThrottleResetter LoginThrottleResetter
+	loginAttemptAuditor   LoginAttemptAuditor
+}
+
+func (ta throttledUserAuthenticator) AuthenticateUser(ctx context.Context, req UserAuthenticationRequest) (User, error) {
+	now := ta.timer.Now()
+	keys := ta.keys(req)
+
+	for _, key := range keys {
+		throttle, err := ta.loginThrottleGetter.GetLoginThrottle(ctx, key)
+		if err != nil {
+			return User{}, fmt.Errorf("getting login throttle: %w", err)
+		}
+
+		err = ta.evaluateThrottle(throttle, now)
+		if err != nil {
+			auditErr := ta.audit(ctx, req, false, err, now)
+			if auditErr != nil {
+				return User{}, auditErr
+			}
+
+			return User{}, err
+		}
+	}
+
+	user, err := ta.userAuthenticator.AuthenticateUser(ctx, req)
+	if err != nil {
+		if !errors.Is(err, ErrUnauthenticated) {
+			return User{}, err
+		}
+
+		for _, key := range keys {
+			recordErr := ta.recordFailure(ctx, key, now)
+			if recordErr != nil {
+				return User{}, recordErr
+			}
+		}
+
+		auditErr := ta.audit(ctx, req, false, err, now)
+		if auditErr != nil {
+			return User{}, auditErr
+		}
+
+		return User{}, err
+	}
+
+	if !user.TwoFactorEnabled() {
+		err = ta.ResetUserLoginThrottle(ctx, user.Username)
+		if err != nil {
+			return User{}, err
+		}
+	}
+
+	err = ta.audit(ctx, req, true, nil, now)
+	if err != nil {
+		return User{}, err
+	}
+
+	return user, nil
+}
+
+func (ta throttledUserAuthenticator) CheckUserLoginThrottle(ctx context.Context, username string) error {
+	throttle, err := ta.loginThrottleGetter.GetLoginThrottle(ctx, usernameLoginThrottleKey(username))
+	if err != nil {
+		return fmt.Errorf("getting login throttle: %w", err)
+	}
+
+	return ta.evaluateThrottle(throttle, ta.timer.Now())
+}
+
+func (ta throttledUserAuthenticator) RecordUserLoginFailure(ctx context.Context, username string) error {
+	return ta.recordFailure(ctx, usernameLoginThrottleKey(username), ta.timer.Now())
+}
+
+func (ta throttledUserAuthenticator) ResetUserLoginThrottle(ctx context.Context, username string) error {
+	err := ta.loginThrottleResetter.ResetLoginThrottle(ctx, usernameLoginThrottleKey(username))
+	if err != nil {
+		return fmt.Errorf("resetting login throttle: %w", err)
+	}
+
+	return nil
+}
+
+func (ta throttledUserAuthenticator) keys(req UserAuthenticationRequest) []LoginThrottleKey {
+	keys := []LoginThrottleKey{}
+
+	if key := usernameLoginThrottleKey(req.Username); len(key.Value) > 0 {
+		keys = append(keys, key)
+	}
+
+	if len(req.IP) > 0 {
+		keys = append(keys, LoginThrottleKey{
+			Scope: LoginThrottleScopeIP,
+			Value: req.IP,
+		})
+	}
+
+	return keys
+}
+
+func (ta throttledUserAuthenticator) evaluateThrottle(throttle LoginThrottle, now time.Time) error {
+	if now.Before(throttle.LockedUntil) {
+		err := ErrLoginThrottled
+
+		if throttle.Key.Scope == LoginThrottleScopeUsername {
+			err = ErrAccountLocked
+		}
+
+		return NewRetryAfterError(err, throttle.LockedUntil.Sub(now))
+	}
+
+	if throttle.Failures <= 0 || throttle.LastFailedAt.Before(now.Add(-ta.policy.FailureWindow)) {
+		return nil
+	}
+
+	allowedAt := throttle.LastFailedAt.Add(ta.delay(throttle.Failures))
+
+	if now.Before(allowedAt) {
+		return NewRetryAfterError(ErrLoginThrottled, allowedAt.Sub(now))
+	}
+
+	return nil
+}
+
+func (ta throttledUserAuthenticator) delay(failures int) time.Duration {
+	delay := ta.policy.DelayBase
+
+	for i := 1; i < failures && delay < ta.policy.DelayMax; i++ {
+		delay *= 2
+	}
+
+	if delay > ta.policy.DelayMax {
+		delay = ta.policy.DelayMax
+	}
+
+	return delay
+}
+
+func (ta throttledUserAuthenticator) recordFailure(ctx context.Context, key LoginThrottleKey, now time.Time) error {
+	throttle, err := ta.loginFailureRecorder.RecordLoginFailure(ctx, key, now, now.Add(-ta.policy.FailureWindow))
+	if err != nil {
+		return fmt.Errorf("recording login failure: %w", err)
+	}
+
+	maxFailures := ta.policy.MaxIPFailures
+
+	if key.Scope == LoginThrottleScopeUsername {
+		maxFailures = ta.policy.MaxUsernameFailures
+	}
+
+	if maxFailures <= 0 || throttle.Failures < maxFailures {
+		return nil
+	}
+
+	err = ta.loginLocker.LockLogin(ctx, key, now.Add(ta.policy.LockoutDuration))
+	if err != nil {
+		return fmt.Errorf("locking login: %w", err)
+	}
+
+	return nil
+}
+
+func (ta throttledUserAuthenticator) audit(
+	ctx context.Context,
+	req UserAuthenticationRequest,
+	succeeded bool,
+	reason error,
+	now time.Time,
+) error {
+	attempt := LoginAttempt{
+		Username:    req.Username,
+		IP:          req.IP,
+		Succeeded:   succeeded,
+		AttemptedAt: now,
+	}
+
+	switch {
+	case errors.Is(reason, ErrAccountLocked):
+		attempt.Reason = "locked"
+	case errors.Is(reason, ErrLoginThrottled):
+		attempt.Reason = "throttled"
+	case errors.Is(reason, ErrUnauthenticated):
+		attempt.Reason = "invalid_credentials"
+	}
+
+	err := ta.loginAttemptAuditor.AuditLoginAttempt(ctx, attempt)
+	if err != nil {
+		return fmt.Errorf("auditing login attempt: %w", err)
+	}
+
+	return nil
+}
+
+func usernameLoginThrottleKey(username string) LoginThrottleKey {
+	return LoginThrottleKey{
+		Scope: LoginThrottleScopeUsername,
+		Value: strings.ToLower(strings.TrimSpace(username)),
+	}
+}
+
+func NewThrottledUserAuthenticator(
+	timer Timer,
+	policy LoginPolicy,
+	userAuthenticator UserAuthenticator,
+	loginThrottleGetter LoginThrottleGetter,
+	loginFailureRecorder LoginFailureRecorder,
+	loginLocker LoginLocker,
+	loginThrottleResetter LoginThrottleResetter,
+	loginAttemptAuditor LoginAttemptAuditor,
+) *throttledUserAuthenticator {
+	return &throttledUserAuthenticator{
+		timer:                 timer,
+		policy:                policy,
+		userAuthenticator:     userAuthenticator,
+		loginThrottleGetter:   loginThrottleGetter,
+		loginFailureRecorder:  loginFailureRecorder,
+		loginLocker:           loginLocker,
+		loginThrottleResetter: loginThrottleResetter,
+		loginAttemptAuditor:   loginAttemptAuditor,
+	}
+}
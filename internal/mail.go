@@ -0,0 +1,13 @@
+package internal
+
+import "context"
+
+type Mailer interface {
+	SendMail(ctx context.Context, mail Mail) error
+}
+
+type Mail struct {
+	To      string
+	Subject string
+	Body    string
+}
@@ -0,0 +1,35 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"strings"
+
+	"github.com/adystag/jobs-search/internal"
+)
+
+type logMailer struct {
+	logger *log.Logger
+}
+
+func (m logMailer) SendMail(ctx context.Context, mail internal.Mail) error {
+	err := m.logger.Output(2, fmt.Sprintf(
+		"mail to %s: %s\n%s\n",
+		mail.To,
+		mail.Subject,
+		strings.TrimSpace(mail.Body),
+	))
+	if err != nil {
+		return fmt.Errorf("writing mail log: %w", err)
+	}
+
+	return nil
+}
+
+func NewLogMailer(w io.Writer) *logMailer {
+	return &logMailer{
+		logger: log.New(w, "", log.LstdFlags),
+	}
+}
@@ -0,0 +1,127 @@
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strings"
+	"time"
+
+	"github.com/adystag/jobs-search/internal"
+)
+
+type smtpMailer struct {
+	timer    internal.Timer
+	host     string
+	port     string
+	username string
+	password string
+	from     string
+}
+
+func (m smtpMailer) SendMail(ctx context.Context, mail internal.Mail) error {
+	var dialer net.Dialer
+
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(m.host, m.port))
+	if err != nil {
+		return fmt.Errorf("dialing smtp server: %w", err)
+	}
+
+	deadline, ok := ctx.Deadline()
+	if ok {
+		err = conn.SetDeadline(deadline)
+		if err != nil {
+			conn.Close()
+
+			return fmt.Errorf("setting smtp connection deadline: %w", err)
+		}
+	}
+
+	client, err := smtp.NewClient(conn, m.host)
+	if err != nil {
+		conn.Close()
+
+		return fmt.Errorf("initializing smtp client: %w", err)
+	}
+
+	defer client.Close()
+
+	ok, _ = client.Extension("STARTTLS")
+	if ok {
+		err = client.StartTLS(&tls.Config{ServerName: m.host})
+		if err != nil {
+			return fmt.Errorf("starting smtp tls: %w", err)
+		}
+	}
+
+	if len(m.username) > 0 {
+		err = client.Auth(smtp.PlainAuth("", m.username, m.password, m.host))
+		if err != nil {
+			return fmt.Errorf("authenticating smtp client: %w", err)
+		}
+	}
+
+	err = client.Mail(m.from)
+	if err != nil {
+		return fmt.Errorf("setting smtp sender: %w", err)
+	}
+
+	err = client.Rcpt(mail.To)
+	if err != nil {
+		return fmt.Errorf("setting smtp recipient: %w", err)
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("opening smtp data writer: %w", err)
+	}
+
+	_, err = w.Write(m.message(mail))
+	if err != nil {
+		w.Close()
+
+		return fmt.Errorf("writing smtp message: %w", err)
+	}
+
+	err = w.Close()
+	if err != nil {
+		return fmt.Errorf("closing smtp data writer: %w", err)
+	}
+
+	err = client.Quit()
+	if err != nil {
+		return fmt.Errorf("quitting smtp session: %w", err)
+	}
+
+	return nil
+}
+
+func (m smtpMailer) message(mail internal.Mail) []byte {
+	var b bytes.Buffer
+
+	fmt.Fprintf(&b, "From: %s\r\n", m.from)
+	fmt.Fprintf(&b, "To: %s\r\n", mail.To)
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", mail.Subject))
+	fmt.Fprintf(&b, "Date: %s\r\n", m.timer.Now().Format(time.RFC1123Z))
+	fmt.Fprintf(&b, "MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&b, "Content-Type: text/plain; charset=utf-8\r\n")
+	fmt.Fprintf(&b, "\r\n")
+	fmt.Fprintf(&b, "%s\r\n", strings.ReplaceAll(strings.TrimSpace(mail.Body), "\n", "\r\n"))
+
+	return b.Bytes()
+}
+
+func NewSMTPMailer(timer internal.Timer, host, port, username, password, from string) *smtpMailer {
+	return &smtpMailer{
+		timer:    timer,
+		host:     host,
+		port:     port,
+		username: username,
+		password: password,
+		from:     from,
+	}
+}
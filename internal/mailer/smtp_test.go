@@ -0,0 +1,194 @@
+package mailer_test
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/adystag/jobs-search/internal"
+	"github.com/adystag/jobs-search/internal/mailer"
+)
+
+type fixedTimer struct {
+	now time.Time
+}
+
+func (t fixedTimer) Now() time.Time {
+	return t.now
+}
+
+type smtpSession struct {
+	commands []string
+	data     string
+}
+
+func serveSMTP(t *testing.T, rcptReply string) (string, string, <-chan smtpSession) {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listening smtp stub: %s", err)
+	}
+
+	t.Cleanup(func() {
+		listener.Close()
+	})
+
+	sessions := make(chan smtpSession, 1)
+
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+
+		defer conn.Close()
+
+		conn.SetDeadline(time.Now().Add(5 * time.Second))
+
+		var session smtpSession
+
+		defer func() {
+			sessions <- session
+		}()
+
+		r := bufio.NewReader(conn)
+		reply := func(line string) {
+			conn.Write([]byte(line + "\r\n"))
+		}
+
+		reply("220 localhost ESMTP stub")
+
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+
+			command := strings.TrimRight(line, "\r\n")
+			session.commands = append(session.commands, command)
+
+			switch verb := strings.ToUpper(strings.Fields(command)[0]); verb {
+			case "EHLO", "HELO":
+				reply("250 localhost")
+			case "MAIL":
+				reply("250 ok")
+			case "RCPT":
+				reply(rcptReply)
+			case "DATA":
+				reply("354 end data with <CR><LF>.<CR><LF>")
+
+				var data strings.Builder
+
+				for {
+					line, err := r.ReadString('\n')
+					if err != nil {
+						return
+					}
+
+					if line == ".\r\n" {
+						break
+					}
+
+					data.WriteString(line)
+				}
+
+				session.data = data.String()
+
+				reply("250 queued")
+			case "RSET", "NOOP":
+				reply("250 ok")
+			case "QUIT":
+				reply("221 bye")
+
+				return
+			default:
+				reply("502 unknown command")
+			}
+		}
+	}()
+
+	host, port, err := net.SplitHostPort(listener.Addr().String())
+	if err != nil {
+		t.Fatalf("splitting smtp stub address: %s", err)
+	}
+
+	return host, port, sessions
+}
+
+func TestSMTPMailerSendMail(t *testing.T) {
+	now := time.Date(2023, time.May, 2, 8, 43, 31, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		rcptReply    string
+		wantErr      bool
+		wantCommands []string
+		wantData     []string
+	}{
+		{
+			name:      "delivers message",
+			rcptReply: "250 ok",
+			wantCommands: []string{
+				"MAIL FROM:<noreply@example.com>",
+				"RCPT TO:<jane@example.com>",
+				"DATA",
+				"QUIT",
+			},
+			wantData: []string{
+				"From: noreply@example.com\r\n",
+				"To: jane@example.com\r\n",
+				"Subject: Verify your email\r\n",
+				"Date: " + now.Format(time.RFC1123Z) + "\r\n",
+				"Content-Type: text/plain; charset=utf-8\r\n",
+				"\r\nHi jane,\r\nverify your email.\r\n",
+			},
+		},
+		{
+			name:      "rejected recipient",
+			rcptReply: "550 mailbox unavailable",
+			wantErr:   true,
+			wantCommands: []string{
+				"MAIL FROM:<noreply@example.com>",
+				"RCPT TO:<jane@example.com>",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			host, port, sessions := serveSMTP(t, tt.rcptReply)
+			m := mailer.NewSMTPMailer(fixedTimer{now: now}, host, port, "", "", "noreply@example.com")
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			err := m.SendMail(ctx, internal.Mail{
+				To:      "jane@example.com",
+				Subject: "Verify your email",
+				Body:    "Hi jane,\nverify your email.\n",
+			})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SendMail() error = %v, want error %t", err, tt.wantErr)
+			}
+
+			session := <-sessions
+			commands := strings.Join(session.commands, "\n")
+
+			for _, each := range tt.wantCommands {
+				if !strings.Contains(commands, each) {
+					t.Errorf("smtp commands %q do not contain %q", session.commands, each)
+				}
+			}
+
+			for _, each := range tt.wantData {
+				if !strings.Contains(session.data, each) {
+					t.Errorf("smtp data %q does not contain %q", session.data, each)
+				}
+			}
+		})
+	}
+}
@@ -1,7 +1,10 @@
 package internal
 
 import (
+	"crypto"
+	"errors"
 	"fmt"
+	"io"
 	"time"
 
 	"github.com/jmoiron/sqlx"
@@ -11,6 +14,15 @@ type Provider interface {
 	Provide(module *Module) error
 }
 
+type OIDCProviderConfiguration struct {
+	Name         string
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+}
+
 type Module struct {
 	Configuration struct {
 		Application struct {
@@ -18,10 +30,80 @@ type Module struct {
 			Port   string
 			URL    string
 			Secret []byte
+			Locale string
+
+			ProxyHeader    string
+			TrustedProxies []string
 		}
 		JWT struct {
+			Algorithm           string
+			KeyID               string
+			PrivateKey          crypto.PrivateKey
+			VerificationKeys    map[string]crypto.PublicKey
+			LifeTime            time.Duration
+			RefreshLifeTime     time.Duration
+			RevocationCacheSize int
+			RevocationCacheTTL  time.Duration
+			UserCacheSize       int
+			UserCacheTTL        time.Duration
+			Leeway              time.Duration
+			LegacyWindow        time.Duration
+		}
+		Password struct {
+			HashAlgorithm     string
+			BcryptCost        int
+			Argon2Memory      uint32
+			Argon2Iterations  uint32
+			Argon2Parallelism uint8
+			Argon2SaltLength  uint32
+			Argon2KeyLength   uint32
+		}
+		APIKey struct {
+			UsageResolution time.Duration
+		}
+		Login struct {
+			MaxUsernameFailures int
+			MaxIPFailures       int
+			FailureWindow       time.Duration
+			LockoutDuration     time.Duration
+			DelayBase           time.Duration
+			DelayMax            time.Duration
+		}
+		PasswordReset struct {
 			LifeTime time.Duration
 		}
+		Notifier struct {
+			Driver   string
+			FilePath string
+		}
+		Mailer struct {
+			Driver       string
+			FilePath     string
+			SMTPHost     string
+			SMTPPort     string
+			SMTPUsername string
+			SMTPPassword string
+			From         string
+		}
+		EmailVerification struct {
+			LifeTime time.Duration
+			Required bool
+		}
+		TwoFactor struct {
+			Issuer               string
+			Secret               []byte
+			ChallengeLifeTime    time.Duration
+			ChallengeMaxAttempts int
+			RecoveryCodes        int
+			RequiredForAdmin     bool
+		}
+		OIDC struct {
+			StateLifeTime  time.Duration
+			Leeway         time.Duration
+			ConnectTimeout time.Duration
+			ReadTimeout    time.Duration
+			Providers      []OIDCProviderConfiguration
+		}
 		DB struct {
 			Host        string
 			Port        string
@@ -31,19 +113,106 @@ type Module struct {
 			AutoMigrate bool
 		}
 		DANS struct {
-			BaseURL string
+			BaseURL                 string
+			PerPage                 int
+			ConnectTimeout          time.Duration
+			ReadTimeout             time.Duration
+			MaxRetries              int
+			RetryBaseDelay          time.Duration
+			RetryMaxDelay           time.Duration
+			CircuitBreakerThreshold int
+			CircuitBreakerCooldown  time.Duration
+		}
+		Job struct {
+			Store          string
+			PerPage        int
+			SyncInterval   time.Duration
+			SyncMaxPages   int
+			MaxPage        int
+			StrictQuery    bool
+			CursorSecret   []byte
+			CursorLifeTime time.Duration
+		}
+		JobCache struct {
+			Enabled  bool
+			Size     int
+			TTL      time.Duration
+			StaleTTL time.Duration
+		}
+		Bookmark struct {
+			JobLookupConcurrency int
 		}
 	}
 
 	DB *sqlx.DB
 
+	Closers []io.Closer
+
 	Timer Timer
 
-	UserRegistrator   UserRegistrator
-	UserAuthenticator UserAuthenticator
+	UserRegistrator             UserRegistrator
+	UserAuthenticator           UserAuthenticator
+	UserGetterByID              UserGetterByID
+	AuthenticatedUserGetterByID UserGetterByID
+	UserProfileUpdater          UserProfileUpdater
 
-	JobsLister    JobsLister
-	JobGetterByID JobGetterByID
+	EmailVerificationRequester     EmailVerificationRequester
+	UserEmailVerificationRequester UserEmailVerificationRequester
+	EmailVerifier                  EmailVerifier
+
+	TwoFactorEnroller        TwoFactorEnroller
+	TwoFactorConfirmer       TwoFactorConfirmer
+	TwoFactorDisabler        TwoFactorDisabler
+	TwoFactorChallengeIssuer TwoFactorChallengeIssuer
+	TwoFactorAuthenticator   TwoFactorAuthenticator
+
+	OIDCAuthorizer    OIDCAuthorizer
+	OIDCAuthenticator OIDCAuthenticator
+	UsersLister       UsersLister
+	UserDisabler      UserDisabler
+	UserRoleGranter   UserRoleGranter
+
+	UserPasswordChanger        UserPasswordChanger
+	PasswordResetRequester     PasswordResetRequester
+	UserPasswordResetRequester UserPasswordResetRequester
+	PasswordResetter           PasswordResetter
+
+	SessionIssuer                SessionIssuer
+	SessionRefresher             SessionRefresher
+	SessionTerminator            SessionTerminator
+	UserSessionsRevoker          UserSessionsRevoker
+	AccessTokenRevocationChecker AccessTokenRevocationChecker
+
+	APIKeyCreator         APIKeyCreator
+	APIKeysListerByUserID APIKeysListerByUserID
+	APIKeyRevoker         APIKeyRevoker
+	APIKeyAuthenticator   APIKeyAuthenticator
+
+	JobsLister                JobsLister
+	JobsListerOptionValidator Validator[JobsListerOption]
+	JobsCursorEncoder         JobsCursorEncoder
+	JobsCursorDecoder         JobsCursorDecoder
+	JobGetterByID             JobGetterByID
+	JobsSynchronizer          JobsSynchronizer
+	JobCacheStatsGetter       JobCacheStatsGetter
+
+	SavedSearchCreator          SavedSearchCreator
+	SavedSearchUpdater          SavedSearchUpdater
+	SavedSearchRunner           SavedSearchRunner
+	SavedSearchesListerByUserID SavedSearchesListerByUserID
+	SavedSearchGetterByID       SavedSearchGetterByID
+	SavedSearchDeleter          SavedSearchDeleter
+
+	BookmarkCreator BookmarkCreator
+	BookmarksLister BookmarksLister
+	BookmarkDeleter BookmarkDeleter
+
+	ApplicationCreator            ApplicationCreator
+	ApplicationUpdater            ApplicationUpdater
+	ApplicationStatusTransitioner ApplicationStatusTransitioner
+	ApplicationsListerByUserID    ApplicationsListerByUserID
+	ApplicationGetterByID         ApplicationGetterByID
+	ApplicationDeleter            ApplicationDeleter
 }
 
 func NewModule(providers ...Provider) (*Module, error) {
@@ -52,9 +221,24 @@ func NewModule(providers ...Provider) (*Module, error) {
 	for _, each := range providers {
 		err := each.Provide(&module)
 		if err != nil {
-			return nil, fmt.Errorf("providing module: %w", err)
+			return nil, errors.Join(fmt.Errorf("providing module: %w", err), module.Close())
 		}
 	}
 
 	return &module, nil
 }
+
+func (m *Module) Close() error {
+	var errs []error
+
+	for i := len(m.Closers) - 1; i >= 0; i-- {
+		err := m.Closers[i].Close()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("closing module resource: %w", err))
+		}
+	}
+
+	m.Closers = nil
+
+	return errors.Join(errs...)
+}
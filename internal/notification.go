@@ -0,0 +1,13 @@
+package internal
+
+import "context"
+
+type Notifier interface {
+	Notify(ctx context.Context, notification Notification) error
+}
+
+type Notification struct {
+	User    User
+	Subject string
+	Body    string
+}
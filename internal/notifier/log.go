@@ -0,0 +1,36 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"strings"
+
+	"github.com/adystag/jobs-search/internal"
+)
+
+type logNotifier struct {
+	logger *log.Logger
+}
+
+func (n logNotifier) Notify(ctx context.Context, notification internal.Notification) error {
+	err := n.logger.Output(2, fmt.Sprintf(
+		"notification to user %d (%s): %s\n%s\n",
+		notification.User.ID,
+		notification.User.Username,
+		notification.Subject,
+		strings.TrimSpace(notification.Body),
+	))
+	if err != nil {
+		return fmt.Errorf("writing notification log: %w", err)
+	}
+
+	return nil
+}
+
+func NewLogNotifier(w io.Writer) *logNotifier {
+	return &logNotifier{
+		logger: log.New(w, "", log.LstdFlags),
+	}
+}
@@ -0,0 +1,428 @@
+package internal
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var (
+	ErrOIDCProviderNotFound      = errors.New("oidc provider not found")
+	ErrOIDCAuthorizationNotFound = errors.New("oidc authorization not found")
+	ErrOIDCAuthorizationInvalid  = errors.New("oidc authorization is invalid")
+	ErrOIDCAuthenticationFailed  = errors.New("oidc authentication failed")
+	ErrUserIdentityNotFound      = errors.New("user identity not found")
+)
+
+var nonAlphanumeric = regexp.MustCompile(`[^a-zA-Z0-9]+`)
+
+type OIDCProvider interface {
+	AuthorizationURL(ctx context.Context, req OIDCAuthorizationURLRequest) (string, error)
+	Exchange(ctx context.Context, req OIDCExchangeRequest) (OIDCIdentity, error)
+}
+
+type OIDCAuthorizer interface {
+	AuthorizeOIDC(ctx context.Context, provider string) (OIDCAuthorizationRedirect, error)
+}
+
+type OIDCAuthenticator interface {
+	AuthenticateOIDC(ctx context.Context, req OIDCAuthenticationRequest) (User, error)
+}
+
+type OIDCAuthorizationGetterByStateHash interface {
+	GetOIDCAuthorizationByStateHash(ctx context.Context, stateHash string) (OIDCAuthorization, error)
+}
+
+type OIDCAuthorizationStorer interface {
+	StoreOIDCAuthorization(ctx context.Context, oidcAuthorization *OIDCAuthorization) error
+}
+
+type OIDCAuthorizationConsumer interface {
+	ConsumeOIDCAuthorization(ctx context.Context, oidcAuthorizationID int64, usedAt time.Time) error
+}
+
+type UserIdentityGetterBySubject interface {
+	GetUserIdentityBySubject(ctx context.Context, provider, subject string) (UserIdentity, error)
+}
+
+type UserIdentityStorer interface {
+	StoreUserIdentity(ctx context.Context, userIdentity *UserIdentity) error
+}
+
+type OIDCAuthorizationURLRequest struct {
+	State         string
+	Nonce         string
+	CodeChallenge string
+}
+
+type OIDCExchangeRequest struct {
+	Code         string
+	CodeVerifier string
+	Nonce        string
+}
+
+type OIDCAuthorizationRedirect struct {
+	URL       string
+	State     string
+	ExpiresAt time.Time
+}
+
+type OIDCAuthenticationRequest struct {
+	Provider    string
+	Code        string
+	State       string
+	CookieState string
+}
+
+type OIDCIdentity struct {
+	Subject           string
+	Email             string
+	EmailVerified     bool
+	Name              string
+	PreferredUsername string
+}
+
+type OIDCAuthorization struct {
+	ID           int64
+	Provider     string
+	StateHash    string
+	Nonce        string
+	CodeVerifier string
+	ExpiresAt    time.Time
+	UsedAt       time.Time
+	CreatedAt    time.Time
+}
+
+type UserIdentity struct {
+	ID          int64
+	UserID      int64
+	Provider    string
+	Subject     string
+	Email       string
+	LastLoginAt time.Time
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+func PKCECodeChallenge(codeVerifier string) string {
+	sum := sha256.Sum256([]byte(codeVerifier))
+
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+type oidcAuthorizer struct {
+	timer                   Timer
+	tokenGenerator          TokenGenerator
+	lifeTime                time.Duration
+	providers               map[string]OIDCProvider
+	oidcAuthorizationStorer OIDCAuthorizationStorer
+}
+
+func (oa oidcAuthorizer) AuthorizeOIDC(ctx context.Context, provider string) (OIDCAuthorizationRedirect, error) {
+	oidcProvider, ok := oa.providers[provider]
+	if !ok {
+		return OIDCAuthorizationRedirect{}, ErrOIDCProviderNotFound
+	}
+
+	state, err := oa.tokenGenerator.GenerateToken()
+	if err != nil {
+		return OIDCAuthorizationRedirect{}, fmt.Errorf("generating oidc state: %w", err)
+	}
+
+	nonce, err := oa.tokenGenerator.GenerateToken()
+	if err != nil {
+		return OIDCAuthorizationRedirect{}, fmt.Errorf("generating oidc nonce: %w", err)
+	}
+
+	codeVerifier, err := oa.tokenGenerator.GenerateToken()
+	if err != nil {
+		return OIDCAuthorizationRedirect{}, fmt.Errorf("generating pkce code verifier: %w", err)
+	}
+
+	now := oa.timer.Now()
+	oidcAuthorization := OIDCAuthorization{
+		Provider:     provider,
+		StateHash:    HashToken(state),
+		Nonce:        nonce,
+		CodeVerifier: codeVerifier,
+		ExpiresAt:    now.Add(oa.lifeTime),
+		CreatedAt:    now,
+	}
+	err = oa.oidcAuthorizationStorer.StoreOIDCAuthorization(ctx, &oidcAuthorization)
+	if err != nil {
+		return OIDCAuthorizationRedirect{}, fmt.Errorf("storing oidc authorization: %w", err)
+	}
+
+	authorizationURL, err := oidcProvider.AuthorizationURL(ctx, OIDCAuthorizationURLRequest{
+		State:         state,
+		Nonce:         nonce,
+		CodeChallenge: PKCECodeChallenge(codeVerifier),
+	})
+	if err != nil {
+		return OIDCAuthorizationRedirect{}, fmt.Errorf("building oidc authorization url: %w", err)
+	}
+
+	return OIDCAuthorizationRedirect{
+		URL:       authorizationURL,
+		State:     state,
+		ExpiresAt: oidcAuthorization.ExpiresAt,
+	}, nil
+}
+
+func NewOIDCAuthorizer(
+	timer Timer,
+	tokenGenerator TokenGenerator,
+	lifeTime time.Duration,
+	providers map[string]OIDCProvider,
+	oidcAuthorizationStorer OIDCAuthorizationStorer,
+) *oidcAuthorizer {
+	return &oidcAuthorizer{
+		timer:                   timer,
+		tokenGenerator:          tokenGenerator,
+		lifeTime:                lifeTime,
+		providers:               providers,
+		oidcAuthorizationStorer: oidcAuthorizationStorer,
+	}
+}
+
+type oidcAuthenticator struct {
+	timer                              Timer
+	tokenGenerator                     TokenGenerator
+	hasher                             Hasher
+	providers                          map[string]OIDCProvider
+	oidcAuthorizationGetterByStateHash OIDCAuthorizationGetterByStateHash
+	oidcAuthorizationConsumer          OIDCAuthorizationConsumer
+	userIdentityGetterBySubject        UserIdentityGetterBySubject
+	userIdentityStorer                 UserIdentityStorer
+	userGetterByID                     UserGetterByID
+	userGetterByUsername               UserGetterByUsername
+	userGetterByEmail                  UserGetterByEmail
+	userStorer                         UserStorer
+}
+
+func (oa oidcAuthenticator) AuthenticateOIDC(ctx context.Context, req OIDCAuthenticationRequest) (User, error) {
+	oidcProvider, ok := oa.providers[req.Provider]
+	if !ok {
+		return User{}, ErrOIDCProviderNotFound
+	}
+
+	if len(req.State) == 0 {
+		return User{}, NewValidationError("state", "required")
+	}
+
+	if len(req.Code) == 0 {
+		return User{}, NewValidationError("code", "required")
+	}
+
+	if subtle.ConstantTimeCompare([]byte(req.State), []byte(req.CookieState)) != 1 {
+		return User{}, ErrOIDCAuthorizationInvalid
+	}
+
+	oidcAuthorization, err := oa.oidcAuthorizationGetterByStateHash.GetOIDCAuthorizationByStateHash(
+		ctx,
+		HashToken(req.State),
+	)
+	if err != nil {
+		err = fmt.Errorf("getting oidc authorization by state hash: %w", err)
+
+		if errors.Is(err, ErrOIDCAuthorizationNotFound) {
+			err = ErrOIDCAuthorizationInvalid
+		}
+
+		return User{}, err
+	}
+
+	now := oa.timer.Now()
+
+	if oidcAuthorization.Provider != req.Provider ||
+		!oidcAuthorization.UsedAt.IsZero() ||
+		!now.Before(oidcAuthorization.ExpiresAt) {
+		return User{}, ErrOIDCAuthorizationInvalid
+	}
+
+	err = oa.oidcAuthorizationConsumer.ConsumeOIDCAuthorization(ctx, oidcAuthorization.ID, now)
+	if err != nil {
+		return User{}, fmt.Errorf("consuming oidc authorization: %w", err)
+	}
+
+	identity, err := oidcProvider.Exchange(ctx, OIDCExchangeRequest{
+		Code:         req.Code,
+		CodeVerifier: oidcAuthorization.CodeVerifier,
+		Nonce:        oidcAuthorization.Nonce,
+	})
+	if err != nil {
+		return User{}, fmt.Errorf("exchanging oidc authorization code: %w", err)
+	}
+
+	userIdentity, err := oa.userIdentityGetterBySubject.GetUserIdentityBySubject(ctx, req.Provider, identity.Subject)
+	if err != nil && !errors.Is(err, ErrUserIdentityNotFound) {
+		return User{}, fmt.Errorf("getting user identity by subject: %w", err)
+	}
+
+	var user User
+
+	if userIdentity.ID > 0 {
+		user, err = oa.userGetterByID.GetUserByID(ctx, userIdentity.UserID)
+		if err != nil {
+			return User{}, fmt.Errorf("getting user by id: %w", err)
+		}
+	} else {
+		user, err = oa.register(ctx, identity)
+		if err != nil {
+			return User{}, fmt.Errorf("registering oidc identity as user: %w", err)
+		}
+
+		userIdentity = UserIdentity{
+			UserID:    user.ID,
+			Provider:  req.Provider,
+			Subject:   identity.Subject,
+			CreatedAt: now,
+		}
+	}
+
+	if user.Disabled() {
+		return User{}, ErrUserDisabled
+	}
+
+	userIdentity.Email = identity.Email
+	userIdentity.LastLoginAt = now
+	userIdentity.UpdatedAt = now
+
+	err = oa.userIdentityStorer.StoreUserIdentity(ctx, &userIdentity)
+	if err != nil {
+		return User{}, fmt.Errorf("storing user identity: %w", err)
+	}
+
+	return user, nil
+}
+
+func (oa oidcAuthenticator) register(ctx context.Context, identity OIDCIdentity) (User, error) {
+	username, err := oa.username(ctx, identity)
+	if err != nil {
+		return User{}, fmt.Errorf("generating username: %w", err)
+	}
+
+	password, err := oa.tokenGenerator.GenerateToken()
+	if err != nil {
+		return User{}, fmt.Errorf("generating user password: %w", err)
+	}
+
+	password, err = oa.hasher.Hash(password)
+	if err != nil {
+		return User{}, fmt.Errorf("hashing plain user password: %w", err)
+	}
+
+	now := oa.timer.Now()
+	user := User{
+		Username:    username,
+		Password:    password,
+		Email:       identity.Email,
+		DisplayName: identity.Name,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	if len(user.Email) > 0 {
+		existing, err := oa.userGetterByEmail.GetUserByEmail(ctx, user.Email)
+		if err != nil && !errors.Is(err, ErrUserNotFound) {
+			return User{}, fmt.Errorf("getting user by email: %w", err)
+		}
+
+		if existing.ID > 0 {
+			user.Email = ""
+		} else if identity.EmailVerified {
+			user.EmailVerifiedAt = now
+		}
+	}
+
+	err = oa.userStorer.StoreUser(ctx, &user)
+	if err != nil {
+		return User{}, fmt.Errorf("storing user: %w", err)
+	}
+
+	return user, nil
+}
+
+func (oa oidcAuthenticator) username(ctx context.Context, identity OIDCIdentity) (string, error) {
+	base := identity.PreferredUsername
+
+	if len(base) == 0 {
+		base, _, _ = strings.Cut(identity.Email, "@")
+	}
+
+	base = nonAlphanumeric.ReplaceAllString(base, "")
+
+	if len(base) > 10 {
+		base = base[:10]
+	}
+
+	if len(base) < 3 {
+		base = "user"
+	}
+
+	for attempt := 0; attempt < 5; attempt++ {
+		username := base
+
+		if attempt > 0 {
+			suffix, err := oa.tokenGenerator.GenerateToken()
+			if err != nil {
+				return "", fmt.Errorf("generating username suffix: %w", err)
+			}
+
+			suffix = nonAlphanumeric.ReplaceAllString(suffix, "")
+
+			if len(suffix) > 5 {
+				suffix = suffix[:5]
+			}
+
+			username += suffix
+		}
+
+		_, err := oa.userGetterByUsername.GetUserByUsername(ctx, username)
+		if err != nil {
+			if errors.Is(err, ErrUserNotFound) {
+				return username, nil
+			}
+
+			return "", fmt.Errorf("getting user by username: %w", err)
+		}
+	}
+
+	return "", fmt.Errorf("%w: no available username for %s", ErrOIDCAuthenticationFailed, base)
+}
+
+func NewOIDCAuthenticator(
+	timer Timer,
+	tokenGenerator TokenGenerator,
+	hasher Hasher,
+	providers map[string]OIDCProvider,
+	oidcAuthorizationGetterByStateHash OIDCAuthorizationGetterByStateHash,
+	oidcAuthorizationConsumer OIDCAuthorizationConsumer,
+	userIdentityGetterBySubject UserIdentityGetterBySubject,
+	userIdentityStorer UserIdentityStorer,
+	userGetterByID UserGetterByID,
+	userGetterByUsername UserGetterByUsername,
+	userGetterByEmail UserGetterByEmail,
+	userStorer UserStorer,
+) *oidcAuthenticator {
+	return &oidcAuthenticator{
+		timer:                              timer,
+		tokenGenerator:                     tokenGenerator,
+		hasher:                             hasher,
+		providers:                          providers,
+		oidcAuthorizationGetterByStateHash: oidcAuthorizationGetterByStateHash,
+		oidcAuthorizationConsumer:          oidcAuthorizationConsumer,
+		userIdentityGetterBySubject:        userIdentityGetterBySubject,
+		userIdentityStorer:                 userIdentityStorer,
+		userGetterByID:                     userGetterByID,
+		userGetterByUsername:               userGetterByUsername,
+		userGetterByEmail:                  userGetterByEmail,
+		userStorer:                         userStorer,
+	}
+}
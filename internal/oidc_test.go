@@ -0,0 +1,82 @@
+package internal_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/adystag/jobs-search/internal"
+)
+
+type recordingOIDCAuthorizationGetter struct {
+	calls int
+}
+
+func (g *recordingOIDCAuthorizationGetter) GetOIDCAuthorizationByStateHash(
+	ctx context.Context,
+	stateHash string,
+) (internal.OIDCAuthorization, error) {
+	g.calls++
+
+	return internal.OIDCAuthorization{}, internal.ErrOIDCAuthorizationNotFound
+}
+
+func TestOIDCAuthenticatorStateCookie(t *testing.T) {
+	tests := []struct {
+		name        string
+		state       string
+		cookieState string
+		wantLookup  bool
+	}{
+		{
+			name:        "matching cookie",
+			state:       "state",
+			cookieState: "state",
+			wantLookup:  true,
+		},
+		{
+			name:        "missing cookie",
+			state:       "state",
+			cookieState: "",
+		},
+		{
+			name:        "mismatched cookie",
+			state:       "state",
+			cookieState: "another-state",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			getter := &recordingOIDCAuthorizationGetter{}
+			authenticator := internal.NewOIDCAuthenticator(
+				nil,
+				nil,
+				nil,
+				map[string]internal.OIDCProvider{"mock": nil},
+				getter,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+
+			_, err := authenticator.AuthenticateOIDC(context.Background(), internal.OIDCAuthenticationRequest{
+				Provider:    "mock",
+				Code:        "code",
+				State:       tt.state,
+				CookieState: tt.cookieState,
+			})
+			if !errors.Is(err, internal.ErrOIDCAuthorizationInvalid) {
+				t.Fatalf("AuthenticateOIDC() error = %v, want %v", err, internal.ErrOIDCAuthorizationInvalid)
+			}
+
+			if lookedUp := getter.calls > 0; lookedUp != tt.wantLookup {
+				t.Errorf("authorization looked up = %t, want %t", lookedUp, tt.wantLookup)
+			}
+		})
+	}
+}
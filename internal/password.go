@@ -0,0 +1,434 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var (
+	ErrPasswordResetTokenNotFound = errors.New("password reset token not found")
+	ErrPasswordResetTokenInvalid  = errors.New("password reset token is invalid")
+)
+
+type UserPasswordChanger interface {
+	ChangeUserPassword(ctx context.Context, req UserPasswordChangeRequest) error
+}
+
+type PasswordResetRequester interface {
+	RequestPasswordReset(ctx context.Context, req PasswordResetRequest) error
+}
+
+type UserPasswordResetRequester interface {
+	RequestUserPasswordReset(ctx context.Context, userID int64) error
+}
+
+type PasswordResetter interface {
+	ResetPassword(ctx context.Context, req PasswordResetConfirmationRequest) error
+}
+
+type PasswordResetTokenGetterByHash interface {
+	GetPasswordResetTokenByHash(ctx context.Context, hash string) (PasswordResetToken, error)
+}
+
+type PasswordResetTokenStorer interface {
+	StorePasswordResetToken(ctx context.Context, passwordResetToken *PasswordResetToken) error
+}
+
+type PasswordResetTokenConsumer interface {
+	ConsumePasswordResetToken(ctx context.Context, passwordResetTokenID int64, user User) error
+}
+
+type UserPasswordChangeRequest struct {
+	UserID               int64
+	CurrentPassword      string
+	Password             string
+	PasswordConfirmation string
+}
+
+type PasswordResetRequest struct {
+	Username string
+}
+
+type PasswordResetConfirmationRequest struct {
+	Token                string
+	Password             string
+	PasswordConfirmation string
+}
+
+type PasswordResetToken struct {
+	ID        int64
+	UserID    int64
+	Hash      string
+	ExpiresAt time.Time
+	UsedAt    time.Time
+	CreatedAt time.Time
+}
+
+type userPasswordChanger struct {
+	validator           Validator[UserPasswordChangeRequest]
+	timer               Timer
+	hasher              Hasher
+	comparator          Comparator
+	userGetterByID      UserGetterByID
+	userPasswordUpdater UserPasswordUpdater
+	userSessionsRevoker UserSessionsRevoker
+}
+
+func (pc userPasswordChanger) ChangeUserPassword(ctx context.Context, req UserPasswordChangeRequest) error {
+	err := pc.validator.Validate(ctx, req)
+	if err != nil {
+		return fmt.Errorf("validating user password change request: %w", err)
+	}
+
+	user, err := pc.userGetterByID.GetUserByID(ctx, req.UserID)
+	if err != nil {
+		return fmt.Errorf("getting user by id: %w", err)
+	}
+
+	err = pc.comparator.Compare(user.Password, req.CurrentPassword)
+	if err != nil {
+		if errors.Is(err, ErrHashMismatched) {
+			return NewValidationError("current_password", "password")
+		}
+
+		return fmt.Errorf("comparing hashed with plain user password: %w", err)
+	}
+
+	hashed, err := pc.hasher.Hash(req.Password)
+	if err != nil {
+		return fmt.Errorf("hashing plain user password: %w", err)
+	}
+
+	err = pc.userPasswordUpdater.UpdateUserPassword(ctx, user.ID, hashed, pc.timer.Now())
+	if err != nil {
+		return fmt.Errorf("updating user password: %w", err)
+	}
+
+	err = pc.userSessionsRevoker.RevokeUserSessions(ctx, user.ID)
+	if err != nil {
+		return fmt.Errorf("revoking user sessions: %w", err)
+	}
+
+	return nil
+}
+
+func NewUserPasswordChanger(
+	validator Validator[UserPasswordChangeRequest],
+	timer Timer,
+	hasher Hasher,
+	comparator Comparator,
+	userGetterByID UserGetterByID,
+	userPasswordUpdater UserPasswordUpdater,
+	userSessionsRevoker UserSessionsRevoker,
+) *userPasswordChanger {
+	return &userPasswordChanger{
+		validator:           validator,
+		timer:               timer,
+		hasher:              hasher,
+		comparator:          comparator,
+		userGetterByID:      userGetterByID,
+		userPasswordUpdater: userPasswordUpdater,
+		userSessionsRevoker: userSessionsRevoker,
+	}
+}
+
+type passwordResetRequester struct {
+	timer                    Timer
+	tokenGenerator           TokenGenerator
+	lifeTime                 time.Duration
+	userGetterByUsername     UserGetterByUsername
+	userGetterByID           UserGetterByID
+	passwordResetTokenStorer PasswordResetTokenStorer
+	notifier                 Notifier
+}
+
+func (pr passwordResetRequester) RequestPasswordReset(ctx context.Context, req PasswordResetRequest) error {
+	if len(req.Username) == 0 {
+		return NewValidationError("username", "required")
+	}
+
+	user, err := pr.userGetterByUsername.GetUserByUsername(ctx, req.Username)
+	if err != nil {
+		if errors.Is(err, ErrUserNotFound) {
+			return nil
+		}
+
+		return fmt.Errorf("getting user by username: %w", err)
+	}
+
+	return pr.issue(ctx, user)
+}
+
+func (pr passwordResetRequester) RequestUserPasswordReset(ctx context.Context, userID int64) error {
+	user, err := pr.userGetterByID.GetUserByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("getting user by id: %w", err)
+	}
+
+	return pr.issue(ctx, user)
+}
+
+func (pr passwordResetRequester) issue(ctx context.Context, user User) error {
+	token, err := pr.tokenGenerator.GenerateToken()
+	if err != nil {
+		return fmt.Errorf("generating password reset token: %w", err)
+	}
+
+	now := pr.timer.Now()
+	passwordResetToken := PasswordResetToken{
+		UserID:    user.ID,
+		Hash:      HashToken(token),
+		ExpiresAt: now.Add(pr.lifeTime),
+		CreatedAt: now,
+	}
+	err = pr.passwordResetTokenStorer.StorePasswordResetToken(ctx, &passwordResetToken)
+	if err != nil {
+		return fmt.Errorf("storing password reset token: %w", err)
+	}
+
+	err = pr.notifier.Notify(ctx, Notification{
+		User:    user,
+		Subject: "Password reset",
+		Body: fmt.Sprintf(
+			"Use the following token to reset your password, it expires at %s: %s",
+			passwordResetToken.ExpiresAt.Format(time.RFC3339),
+			token,
+		),
+	})
+	if err != nil {
+		return fmt.Errorf("notifying password reset token: %w", err)
+	}
+
+	return nil
+}
+
+func NewPasswordResetRequester(
+	timer Timer,
+	tokenGenerator TokenGenerator,
+	lifeTime time.Duration,
+	userGetterByUsername UserGetterByUsername,
+	userGetterByID UserGetterByID,
+	passwordResetTokenStorer PasswordResetTokenStorer,
+	notifier Notifier,
+) *passwordResetRequester {
+	return &passwordResetRequester{
+		timer:                    timer,
+		tokenGenerator:           tokenGenerator,
+		lifeTime:                 lifeTime,
+		userGetterByUsername:     userGetterByUsername,
+		userGetterByID:           userGetterByID,
+		passwordResetTokenStorer: passwordResetTokenStorer,
+		notifier:                 notifier,
+	}
+}
+
+type passwordResetter struct {
+	validator                      Validator[PasswordResetConfirmationRequest]
+	timer                          Timer
+	hasher                         Hasher
+	passwordResetTokenGetterByHash PasswordResetTokenGetterByHash
+	passwordResetTokenConsumer     PasswordResetTokenConsumer
+	userGetterByID                 UserGetterByID
+	userSessionsRevoker            UserSessionsRevoker
+}
+
+func (pr passwordResetter) ResetPassword(ctx context.Context, req PasswordResetConfirmationRequest) error {
+	err := pr.validator.Validate(ctx, req)
+	if err != nil {
+		return fmt.Errorf("validating password reset confirmation request: %w", err)
+	}
+
+	passwordResetToken, err := pr.passwordResetTokenGetterByHash.GetPasswordResetTokenByHash(ctx, HashToken(req.Token))
+	if err != nil {
+		err = fmt.Errorf("getting password reset token by hash: %w", err)
+
+		if errors.Is(err, ErrPasswordResetTokenNotFound) {
+			err = ErrPasswordResetTokenInvalid
+		}
+
+		return err
+	}
+
+	now := pr.timer.Now()
+
+	if !passwordResetToken.UsedAt.IsZero() || !now.Before(passwordResetToken.ExpiresAt) {
+		return ErrPasswordResetTokenInvalid
+	}
+
+	user, err := pr.userGetterByID.GetUserByID(ctx, passwordResetToken.UserID)
+	if err != nil {
+		err = fmt.Errorf("getting user by id: %w", err)
+
+		if errors.Is(err, ErrUserNotFound) {
+			err = ErrPasswordResetTokenInvalid
+		}
+
+		return err
+	}
+
+	user.Password, err = pr.hasher.Hash(req.Password)
+	if err != nil {
+		return fmt.Errorf("hashing plain user password: %w", err)
+	}
+
+	user.UpdatedAt = now
+
+	err = pr.passwordResetTokenConsumer.ConsumePasswordResetToken(ctx, passwordResetToken.ID, user)
+	if err != nil {
+		return fmt.Errorf("consuming password reset token: %w", err)
+	}
+
+	err = pr.userSessionsRevoker.RevokeUserSessions(ctx, user.ID)
+	if err != nil {
+		return fmt.Errorf("revoking user sessions: %w", err)
+	}
+
+	return nil
+}
+
+func NewPasswordResetter(
+	validator Validator[PasswordResetConfirmationRequest],
+	timer Timer,
+	hasher Hasher,
+	passwordResetTokenGetterByHash PasswordResetTokenGetterByHash,
+	passwordResetTokenConsumer PasswordResetTokenConsumer,
+	userGetterByID UserGetterByID,
+	userSessionsRevoker UserSessionsRevoker,
+) *passwordResetter {
+	return &passwordResetter{
+		validator:                      validator,
+		timer:                          timer,
+		hasher:                         hasher,
+		passwordResetTokenGetterByHash: passwordResetTokenGetterByHash,
+		passwordResetTokenConsumer:     passwordResetTokenConsumer,
+		userGetterByID:                 userGetterByID,
+		userSessionsRevoker:            userSessionsRevoker,
+	}
+}
+
+type userPasswordChangeRequestValidator struct {
+	validate *validator.Validate
+}
+
+func (v userPasswordChangeRequestValidator) EvaluateErrorAs(err, target error) error {
+	if errors.As(err, &validator.ValidationErrors{}) {
+		err = target
+	}
+
+	return err
+}
+
+func (v userPasswordChangeRequestValidator) Validate(ctx context.Context, req UserPasswordChangeRequest) error {
+	validationErrors := ValidationErrors{}
+
+	err := v.validate.VarCtx(ctx, req.CurrentPassword, "required")
+	if err != nil {
+		err = validationErrors.Collect(v.EvaluateErrorAs(err, NewValidationError("current_password", "required")))
+		if err != nil {
+			return err
+		}
+	}
+
+	err = validationErrors.Collect(v.validatePassword(ctx, req.Password, req.PasswordConfirmation))
+	if err != nil {
+		return err
+	}
+
+	return validationErrors.Err()
+}
+
+func (v userPasswordChangeRequestValidator) validatePassword(
+	ctx context.Context,
+	password string,
+	passwordConfirmation string,
+) error {
+	err := v.validate.VarCtx(ctx, password, "required")
+	if err != nil {
+		return v.EvaluateErrorAs(err, NewValidationError("password", "required"))
+	}
+
+	err = v.validate.VarCtx(ctx, password, "min=6")
+	if err != nil {
+		return v.EvaluateErrorAs(err, NewValidationError("password", "min=6"))
+	}
+
+	err = v.validate.VarWithValueCtx(ctx, password, passwordConfirmation, "eqfield")
+	if err != nil {
+		return v.EvaluateErrorAs(err, NewValidationError("password", "eqfield=password_confirmation"))
+	}
+
+	return nil
+}
+
+func NewUserPasswordChangeRequestValidator(validate *validator.Validate) *userPasswordChangeRequestValidator {
+	return &userPasswordChangeRequestValidator{
+		validate: validate,
+	}
+}
+
+type passwordResetConfirmationRequestValidator struct {
+	validate *validator.Validate
+}
+
+func (v passwordResetConfirmationRequestValidator) EvaluateErrorAs(err, target error) error {
+	if errors.As(err, &validator.ValidationErrors{}) {
+		err = target
+	}
+
+	return err
+}
+
+func (v passwordResetConfirmationRequestValidator) Validate(
+	ctx context.Context,
+	req PasswordResetConfirmationRequest,
+) error {
+	validationErrors := ValidationErrors{}
+
+	err := v.validate.VarCtx(ctx, req.Token, "required")
+	if err != nil {
+		err = validationErrors.Collect(v.EvaluateErrorAs(err, NewValidationError("token", "required")))
+		if err != nil {
+			return err
+		}
+	}
+
+	err = validationErrors.Collect(v.validatePassword(ctx, req.Password, req.PasswordConfirmation))
+	if err != nil {
+		return err
+	}
+
+	return validationErrors.Err()
+}
+
+func (v passwordResetConfirmationRequestValidator) validatePassword(
+	ctx context.Context,
+	password string,
+	passwordConfirmation string,
+) error {
+	err := v.validate.VarCtx(ctx, password, "required")
+	if err != nil {
+		return v.EvaluateErrorAs(err, NewValidationError("password", "required"))
+	}
+
+	err = v.validate.VarCtx(ctx, password, "min=6")
+	if err != nil {
+		return v.EvaluateErrorAs(err, NewValidationError("password", "min=6"))
+	}
+
+	err = v.validate.VarWithValueCtx(ctx, password, passwordConfirmation, "eqfield")
+	if err != nil {
+		return v.EvaluateErrorAs(err, NewValidationError("password", "eqfield=password_confirmation"))
+	}
+
+	return nil
+}
+
+func NewPasswordResetConfirmationRequestValidator(validate *validator.Validate) *passwordResetConfirmationRequestValidator {
+	return &passwordResetConfirmationRequestValidator{
+		validate: validate,
+	}
+}
@@ -2,10 +2,19 @@ package provider
 
 import (
 	"bytes"
+	"crypto"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"strings"
 
 	"github.com/adystag/jobs-search/internal"
 
 	"github.com/spf13/viper"
+	"golang.org/x/crypto/bcrypt"
 )
 
 type Configuration struct{}
@@ -14,14 +23,178 @@ func (Configuration) Provide(module *internal.Module) error {
 	viper.SetConfigFile(".env")
 	viper.ReadInConfig()
 
+	viper.SetDefault("APP_LOCALE", "en")
 	viper.SetDefault("DB_AUTO_MIGRATE", true)
+	viper.SetDefault("JWT_ALGORITHM", "HS512")
+	viper.SetDefault("JWT_KEY_ID", "primary")
+	viper.SetDefault("JWT_REFRESH_LIFETIME", "720h")
+	viper.SetDefault("JWT_REVOCATION_CACHE_SIZE", 10000)
+	viper.SetDefault("JWT_REVOCATION_CACHE_TTL", "5s")
+	viper.SetDefault("JWT_USER_CACHE_SIZE", 10000)
+	viper.SetDefault("JWT_USER_CACHE_TTL", "5s")
+	viper.SetDefault("JWT_LEEWAY", "30s")
+	viper.SetDefault("JWT_LEGACY_WINDOW", "1h")
+	viper.SetDefault("PASSWORD_HASH_ALGORITHM", PasswordHashAlgorithmArgon2id)
+	viper.SetDefault("PASSWORD_BCRYPT_COST", bcrypt.DefaultCost)
+	viper.SetDefault("PASSWORD_ARGON2_MEMORY", 64*1024)
+	viper.SetDefault("PASSWORD_ARGON2_ITERATIONS", 3)
+	viper.SetDefault("PASSWORD_ARGON2_PARALLELISM", 2)
+	viper.SetDefault("PASSWORD_ARGON2_SALT_LENGTH", 16)
+	viper.SetDefault("PASSWORD_ARGON2_KEY_LENGTH", 32)
+	viper.SetDefault("PASSWORD_RESET_LIFETIME", "30m")
+	viper.SetDefault("API_KEY_USAGE_RESOLUTION", "1m")
+	viper.SetDefault("LOGIN_MAX_USERNAME_FAILURES", 5)
+	viper.SetDefault("LOGIN_MAX_IP_FAILURES", 20)
+	viper.SetDefault("LOGIN_FAILURE_WINDOW", "15m")
+	viper.SetDefault("LOGIN_LOCKOUT_DURATION", "15m")
+	viper.SetDefault("LOGIN_DELAY_BASE", "1s")
+	viper.SetDefault("LOGIN_DELAY_MAX", "30s")
+	viper.SetDefault("NOTIFIER_DRIVER", NotifierDriverLog)
+	viper.SetDefault("NOTIFIER_FILE_PATH", "notifications.log")
+	viper.SetDefault("MAILER_DRIVER", MailerDriverLog)
+	viper.SetDefault("MAILER_FILE_PATH", "mails.log")
+	viper.SetDefault("MAILER_SMTP_HOST", "localhost")
+	viper.SetDefault("MAILER_SMTP_PORT", "1025")
+	viper.SetDefault("MAILER_FROM", "no-reply@localhost")
+	viper.SetDefault("EMAIL_VERIFICATION_LIFETIME", "24h")
+	viper.SetDefault("EMAIL_VERIFICATION_REQUIRED", false)
+	viper.SetDefault("TWO_FACTOR_ISSUER", "jobs-search")
+	viper.SetDefault("TWO_FACTOR_CHALLENGE_LIFETIME", "5m")
+	viper.SetDefault("TWO_FACTOR_CHALLENGE_MAX_ATTEMPTS", 5)
+	viper.SetDefault("TWO_FACTOR_RECOVERY_CODES", 10)
+	viper.SetDefault("TWO_FACTOR_REQUIRED_FOR_ADMIN", true)
+	viper.SetDefault("OIDC_STATE_LIFETIME", "10m")
+	viper.SetDefault("OIDC_LEEWAY", "30s")
+	viper.SetDefault("OIDC_CONNECT_TIMEOUT", "3s")
+	viper.SetDefault("OIDC_READ_TIMEOUT", "10s")
+	viper.SetDefault("DANS_PER_PAGE", 0)
+	viper.SetDefault("DANS_CONNECT_TIMEOUT", "3s")
+	viper.SetDefault("DANS_READ_TIMEOUT", "10s")
+	viper.SetDefault("DANS_MAX_RETRIES", 2)
+	viper.SetDefault("DANS_RETRY_BASE_DELAY", "200ms")
+	viper.SetDefault("DANS_RETRY_MAX_DELAY", "2s")
+	viper.SetDefault("DANS_CIRCUIT_BREAKER_THRESHOLD", 5)
+	viper.SetDefault("DANS_CIRCUIT_BREAKER_COOLDOWN", "30s")
+	viper.SetDefault("JOB_STORE", JobStoreProxy)
+	viper.SetDefault("JOB_PER_PAGE", 10)
+	viper.SetDefault("JOB_SYNC_INTERVAL", "15m")
+	viper.SetDefault("JOB_SYNC_MAX_PAGES", 100)
+	viper.SetDefault("JOB_MAX_PAGE", 1000)
+	viper.SetDefault("JOB_STRICT_QUERY", false)
+	viper.SetDefault("JOB_CURSOR_LIFETIME", "1h")
+	viper.SetDefault("JOB_CACHE_ENABLED", false)
+	viper.SetDefault("JOB_CACHE_SIZE", 1000)
+	viper.SetDefault("JOB_CACHE_TTL", "1m")
+	viper.SetDefault("JOB_CACHE_STALE_TTL", "5m")
+	viper.SetDefault("BOOKMARK_JOB_LOOKUP_CONCURRENCY", 4)
 
 	module.Configuration.Application.Env = viper.GetString("APP_ENV")
 	module.Configuration.Application.Port = viper.GetString("APP_PORT")
 	module.Configuration.Application.URL = viper.GetString("APP_URL")
 	module.Configuration.Application.Secret = bytes.NewBufferString(viper.GetString("APP_SECRET")).Bytes()
+	module.Configuration.Application.Locale = viper.GetString("APP_LOCALE")
+	module.Configuration.Application.ProxyHeader = viper.GetString("APP_PROXY_HEADER")
 
+	for _, each := range strings.Split(viper.GetString("APP_TRUSTED_PROXIES"), ",") {
+		if each = strings.TrimSpace(each); len(each) > 0 {
+			module.Configuration.Application.TrustedProxies = append(module.Configuration.Application.TrustedProxies, each)
+		}
+	}
+
+	module.Configuration.JWT.Algorithm = viper.GetString("JWT_ALGORITHM")
+	module.Configuration.JWT.KeyID = viper.GetString("JWT_KEY_ID")
 	module.Configuration.JWT.LifeTime = viper.GetDuration("JWT_LIFETIME")
+	module.Configuration.JWT.RefreshLifeTime = viper.GetDuration("JWT_REFRESH_LIFETIME")
+	module.Configuration.JWT.RevocationCacheSize = viper.GetInt("JWT_REVOCATION_CACHE_SIZE")
+	module.Configuration.JWT.RevocationCacheTTL = viper.GetDuration("JWT_REVOCATION_CACHE_TTL")
+	module.Configuration.JWT.UserCacheSize = viper.GetInt("JWT_USER_CACHE_SIZE")
+	module.Configuration.JWT.UserCacheTTL = viper.GetDuration("JWT_USER_CACHE_TTL")
+	module.Configuration.JWT.Leeway = viper.GetDuration("JWT_LEEWAY")
+	module.Configuration.JWT.LegacyWindow = viper.GetDuration("JWT_LEGACY_WINDOW")
+
+	if privateKeyFile := viper.GetString("JWT_PRIVATE_KEY_FILE"); len(privateKeyFile) > 0 {
+		privateKey, err := loadPrivateKey(privateKeyFile)
+		if err != nil {
+			return fmt.Errorf("loading jwt private key: %w", err)
+		}
+
+		module.Configuration.JWT.PrivateKey = privateKey
+	}
+
+	verificationKeys, err := loadPublicKeys(viper.GetString("JWT_VERIFICATION_KEY_FILES"))
+	if err != nil {
+		return fmt.Errorf("loading jwt verification keys: %w", err)
+	}
+
+	module.Configuration.JWT.VerificationKeys = verificationKeys
+
+	module.Configuration.Password.HashAlgorithm = viper.GetString("PASSWORD_HASH_ALGORITHM")
+	module.Configuration.Password.BcryptCost = viper.GetInt("PASSWORD_BCRYPT_COST")
+
+	argon2Memory := viper.GetInt64("PASSWORD_ARGON2_MEMORY")
+	argon2Iterations := viper.GetInt64("PASSWORD_ARGON2_ITERATIONS")
+	argon2Parallelism := viper.GetInt64("PASSWORD_ARGON2_PARALLELISM")
+	argon2SaltLength := viper.GetInt64("PASSWORD_ARGON2_SALT_LENGTH")
+	argon2KeyLength := viper.GetInt64("PASSWORD_ARGON2_KEY_LENGTH")
+
+	err = validateArgon2Parameters(argon2Memory, argon2Iterations, argon2Parallelism, argon2SaltLength, argon2KeyLength)
+	if err != nil {
+		return fmt.Errorf("validating argon2 parameters: %w", err)
+	}
+
+	module.Configuration.Password.Argon2Memory = uint32(argon2Memory)
+	module.Configuration.Password.Argon2Iterations = uint32(argon2Iterations)
+	module.Configuration.Password.Argon2Parallelism = uint8(argon2Parallelism)
+	module.Configuration.Password.Argon2SaltLength = uint32(argon2SaltLength)
+	module.Configuration.Password.Argon2KeyLength = uint32(argon2KeyLength)
+
+	module.Configuration.PasswordReset.LifeTime = viper.GetDuration("PASSWORD_RESET_LIFETIME")
+
+	module.Configuration.APIKey.UsageResolution = viper.GetDuration("API_KEY_USAGE_RESOLUTION")
+
+	module.Configuration.Login.MaxUsernameFailures = viper.GetInt("LOGIN_MAX_USERNAME_FAILURES")
+	module.Configuration.Login.MaxIPFailures = viper.GetInt("LOGIN_MAX_IP_FAILURES")
+	module.Configuration.Login.FailureWindow = viper.GetDuration("LOGIN_FAILURE_WINDOW")
+	module.Configuration.Login.LockoutDuration = viper.GetDuration("LOGIN_LOCKOUT_DURATION")
+	module.Configuration.Login.DelayBase = viper.GetDuration("LOGIN_DELAY_BASE")
+	module.Configuration.Login.DelayMax = viper.GetDuration("LOGIN_DELAY_MAX")
+
+	module.Configuration.Notifier.Driver = viper.GetString("NOTIFIER_DRIVER")
+	module.Configuration.Notifier.FilePath = viper.GetString("NOTIFIER_FILE_PATH")
+
+	module.Configuration.Mailer.Driver = viper.GetString("MAILER_DRIVER")
+	module.Configuration.Mailer.FilePath = viper.GetString("MAILER_FILE_PATH")
+	module.Configuration.Mailer.SMTPHost = viper.GetString("MAILER_SMTP_HOST")
+	module.Configuration.Mailer.SMTPPort = viper.GetString("MAILER_SMTP_PORT")
+	module.Configuration.Mailer.SMTPUsername = viper.GetString("MAILER_SMTP_USERNAME")
+	module.Configuration.Mailer.SMTPPassword = viper.GetString("MAILER_SMTP_PASSWORD")
+	module.Configuration.Mailer.From = viper.GetString("MAILER_FROM")
+
+	module.Configuration.EmailVerification.LifeTime = viper.GetDuration("EMAIL_VERIFICATION_LIFETIME")
+	module.Configuration.EmailVerification.Required = viper.GetBool("EMAIL_VERIFICATION_REQUIRED")
+
+	module.Configuration.TwoFactor.Issuer = viper.GetString("TWO_FACTOR_ISSUER")
+	module.Configuration.TwoFactor.Secret = bytes.NewBufferString(viper.GetString("TWO_FACTOR_SECRET")).Bytes()
+	module.Configuration.TwoFactor.ChallengeLifeTime = viper.GetDuration("TWO_FACTOR_CHALLENGE_LIFETIME")
+	module.Configuration.TwoFactor.ChallengeMaxAttempts = viper.GetInt("TWO_FACTOR_CHALLENGE_MAX_ATTEMPTS")
+	module.Configuration.TwoFactor.RecoveryCodes = viper.GetInt("TWO_FACTOR_RECOVERY_CODES")
+	module.Configuration.TwoFactor.RequiredForAdmin = viper.GetBool("TWO_FACTOR_REQUIRED_FOR_ADMIN")
+
+	if len(module.Configuration.TwoFactor.Secret) == 0 {
+		return errors.New("two factor secret must be set")
+	}
+
+	module.Configuration.OIDC.StateLifeTime = viper.GetDuration("OIDC_STATE_LIFETIME")
+	module.Configuration.OIDC.Leeway = viper.GetDuration("OIDC_LEEWAY")
+	module.Configuration.OIDC.ConnectTimeout = viper.GetDuration("OIDC_CONNECT_TIMEOUT")
+	module.Configuration.OIDC.ReadTimeout = viper.GetDuration("OIDC_READ_TIMEOUT")
+	module.Configuration.OIDC.Providers, err = loadOIDCProviders(
+		viper.GetString("OIDC_PROVIDERS"),
+		module.Configuration.Application.URL,
+	)
+	if err != nil {
+		return fmt.Errorf("loading oidc providers: %w", err)
+	}
 
 	module.Configuration.DB.Host = viper.GetString("DB_HOST")
 	module.Configuration.DB.Port = viper.GetString("DB_PORT")
@@ -31,6 +204,189 @@ func (Configuration) Provide(module *internal.Module) error {
 	module.Configuration.DB.AutoMigrate = viper.GetBool("DB_AUTO_MIGRATE")
 
 	module.Configuration.DANS.BaseURL = viper.GetString("DANS_BASE_URL")
+	module.Configuration.DANS.PerPage = viper.GetInt("DANS_PER_PAGE")
+	module.Configuration.DANS.ConnectTimeout = viper.GetDuration("DANS_CONNECT_TIMEOUT")
+	module.Configuration.DANS.ReadTimeout = viper.GetDuration("DANS_READ_TIMEOUT")
+	module.Configuration.DANS.MaxRetries = viper.GetInt("DANS_MAX_RETRIES")
+	module.Configuration.DANS.RetryBaseDelay = viper.GetDuration("DANS_RETRY_BASE_DELAY")
+	module.Configuration.DANS.RetryMaxDelay = viper.GetDuration("DANS_RETRY_MAX_DELAY")
+	module.Configuration.DANS.CircuitBreakerThreshold = viper.GetInt("DANS_CIRCUIT_BREAKER_THRESHOLD")
+	module.Configuration.DANS.CircuitBreakerCooldown = viper.GetDuration("DANS_CIRCUIT_BREAKER_COOLDOWN")
+
+	if module.Configuration.DANS.MaxRetries > 0 {
+		if module.Configuration.DANS.RetryBaseDelay <= 0 {
+			return fmt.Errorf("dans retry base delay must be positive, got %s", module.Configuration.DANS.RetryBaseDelay)
+		}
+
+		if module.Configuration.DANS.RetryMaxDelay < module.Configuration.DANS.RetryBaseDelay {
+			return fmt.Errorf(
+				"dans retry max delay must not be less than the base delay, got %s",
+				module.Configuration.DANS.RetryMaxDelay,
+			)
+		}
+	}
+
+	module.Configuration.Job.Store = viper.GetString("JOB_STORE")
+	module.Configuration.Job.PerPage = viper.GetInt("JOB_PER_PAGE")
+	module.Configuration.Job.SyncInterval = viper.GetDuration("JOB_SYNC_INTERVAL")
+	module.Configuration.Job.SyncMaxPages = viper.GetInt("JOB_SYNC_MAX_PAGES")
+	module.Configuration.Job.MaxPage = viper.GetInt("JOB_MAX_PAGE")
+	module.Configuration.Job.StrictQuery = viper.GetBool("JOB_STRICT_QUERY")
+	module.Configuration.Job.CursorSecret = bytes.NewBufferString(viper.GetString("JOB_CURSOR_SECRET")).Bytes()
+	module.Configuration.Job.CursorLifeTime = viper.GetDuration("JOB_CURSOR_LIFETIME")
+
+	if module.Configuration.Job.SyncInterval <= 0 {
+		return fmt.Errorf("job sync interval must be positive, got %s", module.Configuration.Job.SyncInterval)
+	}
+
+	if module.Configuration.Job.Store == JobStoreLocal && len(module.Configuration.Job.CursorSecret) == 0 {
+		return errors.New("job cursor secret must be set")
+	}
+
+	module.Configuration.JobCache.Enabled = viper.GetBool("JOB_CACHE_ENABLED")
+	module.Configuration.JobCache.Size = viper.GetInt("JOB_CACHE_SIZE")
+	module.Configuration.JobCache.TTL = viper.GetDuration("JOB_CACHE_TTL")
+	module.Configuration.JobCache.StaleTTL = viper.GetDuration("JOB_CACHE_STALE_TTL")
+
+	module.Configuration.Bookmark.JobLookupConcurrency = viper.GetInt("BOOKMARK_JOB_LOOKUP_CONCURRENCY")
+
+	if module.Configuration.Bookmark.JobLookupConcurrency <= 0 {
+		return fmt.Errorf(
+			"bookmark job lookup concurrency must be positive, got %d",
+			module.Configuration.Bookmark.JobLookupConcurrency,
+		)
+	}
 
 	return nil
 }
+
+func validateArgon2Parameters(memory, iterations, parallelism, saltLength, keyLength int64) error {
+	if parallelism < 1 || parallelism > math.MaxUint8 {
+		return fmt.Errorf("parallelism must be between 1 and %d, got %d", math.MaxUint8, parallelism)
+	}
+
+	if memory < 8*parallelism || memory > math.MaxUint32 {
+		return fmt.Errorf("memory must be between %d and %d KiB, got %d", 8*parallelism, uint32(math.MaxUint32), memory)
+	}
+
+	if iterations < 1 || iterations > math.MaxUint32 {
+		return fmt.Errorf("iterations must be between 1 and %d, got %d", uint32(math.MaxUint32), iterations)
+	}
+
+	if saltLength < 8 || saltLength > math.MaxUint32 {
+		return fmt.Errorf("salt length must be between 8 and %d, got %d", uint32(math.MaxUint32), saltLength)
+	}
+
+	if keyLength < 16 || keyLength > math.MaxUint32 {
+		return fmt.Errorf("key length must be between 16 and %d, got %d", uint32(math.MaxUint32), keyLength)
+	}
+
+	return nil
+}
+
+func loadOIDCProviders(spec, applicationURL string) ([]internal.OIDCProviderConfiguration, error) {
+	var providers []internal.OIDCProviderConfiguration
+
+	for _, name := range strings.Split(spec, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+
+		if len(name) == 0 {
+			continue
+		}
+
+		prefix := "OIDC_" + strings.ToUpper(name) + "_"
+		provider := internal.OIDCProviderConfiguration{
+			Name:         name,
+			Issuer:       viper.GetString(prefix + "ISSUER"),
+			ClientID:     viper.GetString(prefix + "CLIENT_ID"),
+			ClientSecret: viper.GetString(prefix + "CLIENT_SECRET"),
+			RedirectURL:  viper.GetString(prefix + "REDIRECT_URL"),
+			Scopes:       strings.Fields(viper.GetString(prefix + "SCOPES")),
+		}
+
+		if len(provider.Issuer) == 0 || len(provider.ClientID) == 0 {
+			return nil, fmt.Errorf("oidc provider %s requires issuer and client id", name)
+		}
+
+		if len(provider.RedirectURL) == 0 {
+			provider.RedirectURL = strings.TrimSuffix(applicationURL, "/") + "/api/v1/user/oidc/" + name + "/callback"
+		}
+
+		if len(provider.Scopes) == 0 {
+			provider.Scopes = []string{"openid", "email", "profile"}
+		}
+
+		providers = append(providers, provider)
+	}
+
+	return providers, nil
+}
+
+func loadPrivateKey(path string) (crypto.PrivateKey, error) {
+	block, err := loadPEMBlock(path)
+	if err != nil {
+		return nil, err
+	}
+
+	privateKey, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err == nil {
+		return privateKey, nil
+	}
+
+	rsaPrivateKey, rsaErr := x509.ParsePKCS1PrivateKey(block.Bytes)
+	if rsaErr == nil {
+		return rsaPrivateKey, nil
+	}
+
+	return nil, fmt.Errorf("parsing private key from %s: %w", path, err)
+}
+
+func loadPublicKeys(spec string) (map[string]crypto.PublicKey, error) {
+	publicKeys := map[string]crypto.PublicKey{}
+
+	for _, each := range strings.Split(spec, ",") {
+		each = strings.TrimSpace(each)
+
+		if len(each) == 0 {
+			continue
+		}
+
+		keyID, path, ok := strings.Cut(each, "=")
+		if !ok || len(keyID) == 0 || len(path) == 0 {
+			return nil, fmt.Errorf("malformed verification key %q, expected <kid>=<path>", each)
+		}
+
+		block, err := loadPEMBlock(path)
+		if err != nil {
+			return nil, err
+		}
+
+		publicKey, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			rsaPublicKey, rsaErr := x509.ParsePKCS1PublicKey(block.Bytes)
+			if rsaErr != nil {
+				return nil, fmt.Errorf("parsing public key from %s: %w", path, err)
+			}
+
+			publicKey = rsaPublicKey
+		}
+
+		publicKeys[keyID] = publicKey
+	}
+
+	return publicKeys, nil
+}
+
+func loadPEMBlock(path string) (*pem.Block, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+
+	block, _ := pem.Decode(b)
+	if block == nil {
+		return nil, fmt.Errorf("decoding pem block: no pem data found in %s", path)
+	}
+
+	return block, nil
+}
@@ -1,12 +1,38 @@
 package provider
 
 import (
+	"fmt"
+	"os"
+
 	"github.com/adystag/jobs-search/internal"
+	"github.com/adystag/jobs-search/internal/mailer"
+	"github.com/adystag/jobs-search/internal/notifier"
+	"github.com/adystag/jobs-search/internal/repository/cache"
 	"github.com/adystag/jobs-search/internal/repository/http"
 	"github.com/adystag/jobs-search/internal/repository/mysql"
 
 	"github.com/go-playground/validator/v10"
-	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	JobStoreProxy = "proxy"
+	JobStoreLocal = "local"
+)
+
+const (
+	PasswordHashAlgorithmBcrypt   = "bcrypt"
+	PasswordHashAlgorithmArgon2id = "argon2id"
+)
+
+const (
+	NotifierDriverLog  = "log"
+	NotifierDriverFile = "file"
+)
+
+const (
+	MailerDriverLog  = "log"
+	MailerDriverFile = "file"
+	MailerDriverSMTP = "smtp"
 )
 
 type Service struct{}
@@ -15,28 +41,509 @@ func (Service) Provide(module *internal.Module) error {
 	module.Timer = internal.NewTimer()
 
 	validate := validator.New()
-	bcryptHasher := internal.NewBcryptHasher(bcrypt.DefaultCost)
+	bcryptHasher := internal.NewBcryptHasher(module.Configuration.Password.BcryptCost)
+	argon2idHasher := internal.NewArgon2idHasher(
+		module.Configuration.Password.Argon2Memory,
+		module.Configuration.Password.Argon2Iterations,
+		module.Configuration.Password.Argon2Parallelism,
+		module.Configuration.Password.Argon2SaltLength,
+		module.Configuration.Password.Argon2KeyLength,
+	)
+
+	var passwordHasher internal.PasswordHasher
+
+	switch module.Configuration.Password.HashAlgorithm {
+	case PasswordHashAlgorithmBcrypt:
+		passwordHasher = internal.NewMultiAlgorithmHasher(bcryptHasher, argon2idHasher)
+	case PasswordHashAlgorithmArgon2id:
+		passwordHasher = internal.NewMultiAlgorithmHasher(argon2idHasher, bcryptHasher)
+	default:
+		return fmt.Errorf("unknown password hash algorithm %s", module.Configuration.Password.HashAlgorithm)
+	}
+
+	var mailSender internal.Mailer
+
+	switch module.Configuration.Mailer.Driver {
+	case MailerDriverLog:
+		mailSender = mailer.NewLogMailer(os.Stdout)
+	case MailerDriverFile:
+		mailFile, err := os.OpenFile(
+			module.Configuration.Mailer.FilePath,
+			os.O_APPEND|os.O_CREATE|os.O_WRONLY,
+			0o600,
+		)
+		if err != nil {
+			return fmt.Errorf("opening mail file: %w", err)
+		}
+
+		mailSender = mailer.NewLogMailer(mailFile)
+	case MailerDriverSMTP:
+		mailSender = mailer.NewSMTPMailer(
+			module.Timer,
+			module.Configuration.Mailer.SMTPHost,
+			module.Configuration.Mailer.SMTPPort,
+			module.Configuration.Mailer.SMTPUsername,
+			module.Configuration.Mailer.SMTPPassword,
+			module.Configuration.Mailer.From,
+		)
+	default:
+		return fmt.Errorf("unknown mailer driver %s", module.Configuration.Mailer.Driver)
+	}
+
 	userRepository := mysql.NewUserRepository(module.DB)
+	emailVerificationTokenRepository := mysql.NewEmailVerificationTokenRepository(module.DB)
+
+	emailVerificationRequester := internal.NewEmailVerificationRequester(
+		module.Timer,
+		internal.NewRandomTokenGenerator(32),
+		module.Configuration.EmailVerification.LifeTime,
+		userRepository,
+		userRepository,
+		emailVerificationTokenRepository,
+		mailSender,
+	)
 
+	module.EmailVerificationRequester = emailVerificationRequester
+	module.UserEmailVerificationRequester = emailVerificationRequester
+	module.EmailVerifier = internal.NewEmailVerifier(
+		module.Timer,
+		emailVerificationTokenRepository,
+		emailVerificationTokenRepository,
+		userRepository,
+		userRepository,
+	)
 	module.UserRegistrator = internal.NewUserRegistrator(
 		internal.NewValidationAggregator[internal.UserRegistrationRequest](
-			internal.NewUserRegistrationRequestValidator(validate),
+			internal.NewUserRegistrationRequestValidator(validate, module.Configuration.EmailVerification.Required),
 			internal.NewUsernameUniquenessValidator(userRepository),
+			internal.NewRegistrationEmailUniquenessValidator(userRepository),
+		),
+		module.Timer,
+		passwordHasher,
+		userRepository,
+		module.UserEmailVerificationRequester,
+	)
+	loginThrottleRepository := mysql.NewLoginThrottleRepository(module.DB)
+
+	throttledUserAuthenticator := internal.NewThrottledUserAuthenticator(
+		module.Timer,
+		internal.LoginPolicy{
+			MaxUsernameFailures: module.Configuration.Login.MaxUsernameFailures,
+			MaxIPFailures:       module.Configuration.Login.MaxIPFailures,
+			FailureWindow:       module.Configuration.Login.FailureWindow,
+			LockoutDuration:     module.Configuration.Login.LockoutDuration,
+			DelayBase:           module.Configuration.Login.DelayBase,
+			DelayMax:            module.Configuration.Login.DelayMax,
+		},
+		internal.NewUserAuthenticator(
+			internal.NewUserAuthenticationRequestValidator(validate),
+			module.Timer,
+			userRepository,
+			passwordHasher,
+			passwordHasher,
+			passwordHasher,
+			userRepository,
+		),
+		loginThrottleRepository,
+		loginThrottleRepository,
+		loginThrottleRepository,
+		loginThrottleRepository,
+		loginThrottleRepository,
+	)
+
+	module.UserAuthenticator = throttledUserAuthenticator
+
+	if module.Configuration.EmailVerification.Required {
+		module.UserAuthenticator = internal.NewVerifiedEmailUserAuthenticator(module.UserAuthenticator)
+	}
+
+	refreshTokenRepository := mysql.NewRefreshTokenRepository(module.DB)
+	sessionIssuer := internal.NewSessionIssuer(
+		module.Timer,
+		internal.NewRandomTokenGenerator(32),
+		module.Configuration.JWT.RefreshLifeTime,
+		refreshTokenRepository,
+	)
+
+	module.SessionIssuer = sessionIssuer
+	module.SessionRefresher = internal.NewSessionRefresher(
+		module.Timer,
+		sessionIssuer,
+		refreshTokenRepository,
+		refreshTokenRepository,
+		refreshTokenRepository,
+		userRepository,
+	)
+	module.UserGetterByID = userRepository
+	module.UserProfileUpdater = internal.NewUserProfileUpdater(
+		internal.NewValidationAggregator[internal.UserProfileUpdateRequest](
+			internal.NewUserProfileUpdateRequestValidator(validate, module.Configuration.EmailVerification.Required),
+			internal.NewEmailUniquenessValidator(userRepository),
 		),
 		module.Timer,
-		bcryptHasher,
 		userRepository,
+		userRepository,
+		module.UserEmailVerificationRequester,
+	)
+
+	cachedUserRepository := cache.NewUserRepository(
+		cache.NewLRUBackend(module.Configuration.JWT.UserCacheSize),
+		module.Timer,
+		module.Configuration.JWT.UserCacheTTL,
+		userRepository,
+		userRepository,
+		userRepository,
+	)
+
+	module.AuthenticatedUserGetterByID = cachedUserRepository
+	module.UserSessionsRevoker = internal.NewUserSessionsRevoker(
+		module.Timer,
+		cachedUserRepository,
+		refreshTokenRepository,
+	)
+
+	twoFactorCipher, err := internal.NewAESGCMCipher(module.Configuration.TwoFactor.Secret)
+	if err != nil {
+		return fmt.Errorf("initializing two factor cipher: %w", err)
+	}
+
+	totpSecretRepository := mysql.NewTOTPSecretRepository(module.DB)
+	recoveryCodeRepository := mysql.NewRecoveryCodeRepository(module.DB)
+	twoFactorChallengeRepository := mysql.NewTwoFactorChallengeRepository(module.DB)
+
+	module.TwoFactorEnroller = internal.NewTwoFactorEnroller(
+		module.Timer,
+		module.Configuration.TwoFactor.Issuer,
+		passwordHasher,
+		twoFactorCipher,
+		userRepository,
+		totpSecretRepository,
+	)
+	module.TwoFactorConfirmer = internal.NewTwoFactorConfirmer(
+		module.Timer,
+		internal.NewRandomTokenGenerator(12),
+		module.Configuration.TwoFactor.RecoveryCodes,
+		twoFactorCipher,
+		userRepository,
+		cachedUserRepository,
+		totpSecretRepository,
+		totpSecretRepository,
+		recoveryCodeRepository,
+		module.UserSessionsRevoker,
+	)
+	module.TwoFactorDisabler = internal.NewTwoFactorDisabler(
+		module.Timer,
+		passwordHasher,
+		userRepository,
+		cachedUserRepository,
+		totpSecretRepository,
+		recoveryCodeRepository,
+	)
+	module.TwoFactorChallengeIssuer = internal.NewTwoFactorChallengeIssuer(
+		module.Timer,
+		internal.NewRandomTokenGenerator(32),
+		module.Configuration.TwoFactor.ChallengeLifeTime,
+		twoFactorChallengeRepository,
+	)
+	module.TwoFactorAuthenticator = internal.NewTwoFactorAuthenticator(
+		module.Timer,
+		module.Configuration.TwoFactor.ChallengeMaxAttempts,
+		twoFactorCipher,
+		twoFactorChallengeRepository,
+		twoFactorChallengeRepository,
+		twoFactorChallengeRepository,
+		userRepository,
+		totpSecretRepository,
+		totpSecretRepository,
+		recoveryCodeRepository,
+		throttledUserAuthenticator,
+	)
+
+	accessTokenRevocationRepository := mysql.NewAccessTokenRevocationRepository(module.DB, module.Timer)
+	cachedAccessTokenRevocationRepository := cache.NewAccessTokenRevocationRepository(
+		cache.NewLRUBackend(module.Configuration.JWT.RevocationCacheSize),
+		module.Timer,
+		module.Configuration.JWT.LifeTime,
+		module.Configuration.JWT.RevocationCacheTTL,
+		accessTokenRevocationRepository,
+		accessTokenRevocationRepository,
+	)
+
+	module.AccessTokenRevocationChecker = cachedAccessTokenRevocationRepository
+	module.SessionTerminator = internal.NewSessionTerminator(
+		module.Timer,
+		cachedAccessTokenRevocationRepository,
+		refreshTokenRepository,
+		refreshTokenRepository,
+		module.UserSessionsRevoker,
+	)
+
+	var passwordResetNotifier internal.Notifier
+
+	switch module.Configuration.Notifier.Driver {
+	case NotifierDriverLog:
+		passwordResetNotifier = notifier.NewLogNotifier(os.Stdout)
+	case NotifierDriverFile:
+		notificationFile, err := os.OpenFile(
+			module.Configuration.Notifier.FilePath,
+			os.O_APPEND|os.O_CREATE|os.O_WRONLY,
+			0o600,
+		)
+		if err != nil {
+			return fmt.Errorf("opening notification file: %w", err)
+		}
+
+		module.Closers = append(module.Closers, notificationFile)
+
+		passwordResetNotifier = notifier.NewLogNotifier(notificationFile)
+	default:
+		return fmt.Errorf("unknown notifier driver %s", module.Configuration.Notifier.Driver)
+	}
+
+	module.UsersLister = userRepository
+	module.UserRoleGranter = internal.NewUserRoleGranter(userRepository, userRepository)
+	module.UserDisabler = internal.NewUserDisabler(
+		module.Timer,
+		userRepository,
+		userRepository,
+		module.UserSessionsRevoker,
+	)
+
+	passwordResetTokenRepository := mysql.NewPasswordResetTokenRepository(module.DB)
+
+	module.UserPasswordChanger = internal.NewUserPasswordChanger(
+		internal.NewUserPasswordChangeRequestValidator(validate),
+		module.Timer,
+		passwordHasher,
+		passwordHasher,
+		userRepository,
+		userRepository,
+		module.UserSessionsRevoker,
+	)
+	passwordResetRequester := internal.NewPasswordResetRequester(
+		module.Timer,
+		internal.NewRandomTokenGenerator(32),
+		module.Configuration.PasswordReset.LifeTime,
+		userRepository,
+		userRepository,
+		passwordResetTokenRepository,
+		passwordResetNotifier,
+	)
+
+	module.PasswordResetRequester = passwordResetRequester
+	module.UserPasswordResetRequester = passwordResetRequester
+	module.PasswordResetter = internal.NewPasswordResetter(
+		internal.NewPasswordResetConfirmationRequestValidator(validate),
+		module.Timer,
+		passwordHasher,
+		passwordResetTokenRepository,
+		passwordResetTokenRepository,
+		userRepository,
+		module.UserSessionsRevoker,
+	)
+
+	oidcClient := http.NewTimeoutClient(module.Configuration.OIDC.ConnectTimeout, module.Configuration.OIDC.ReadTimeout)
+	oidcProviders := map[string]internal.OIDCProvider{}
+
+	for _, each := range module.Configuration.OIDC.Providers {
+		oidcProviders[each.Name] = http.NewOIDCProvider(
+			oidcClient,
+			module.Timer,
+			each.Issuer,
+			each.ClientID,
+			each.ClientSecret,
+			each.RedirectURL,
+			each.Scopes,
+			module.Configuration.OIDC.Leeway,
+		)
+	}
+
+	oidcAuthorizationRepository := mysql.NewOIDCAuthorizationRepository(module.DB)
+	userIdentityRepository := mysql.NewUserIdentityRepository(module.DB)
+
+	module.OIDCAuthorizer = internal.NewOIDCAuthorizer(
+		module.Timer,
+		internal.NewRandomTokenGenerator(32),
+		module.Configuration.OIDC.StateLifeTime,
+		oidcProviders,
+		oidcAuthorizationRepository,
+	)
+	module.OIDCAuthenticator = internal.NewOIDCAuthenticator(
+		module.Timer,
+		internal.NewRandomTokenGenerator(32),
+		passwordHasher,
+		oidcProviders,
+		oidcAuthorizationRepository,
+		oidcAuthorizationRepository,
+		userIdentityRepository,
+		userIdentityRepository,
+		userRepository,
+		userRepository,
+		userRepository,
+		userRepository,
+	)
+
+	apiKeyRepository := mysql.NewAPIKeyRepository(module.DB)
+
+	module.APIKeyCreator = internal.NewAPIKeyCreator(
+		internal.NewAPIKeyRequestValidator(validate),
+		module.Timer,
+		internal.NewRandomTokenGenerator(6),
+		internal.NewRandomTokenGenerator(32),
+		userRepository,
+		apiKeyRepository,
 	)
-	module.UserAuthenticator = internal.NewUserAuthenticator(
-		internal.NewUserAuthenticationRequestValidator(validate),
+	module.APIKeysListerByUserID = apiKeyRepository
+	module.APIKeyRevoker = internal.NewAPIKeyRevoker(module.Timer, apiKeyRepository)
+	module.APIKeyAuthenticator = internal.NewAPIKeyAuthenticator(
+		module.Timer,
+		module.Configuration.APIKey.UsageResolution,
+		apiKeyRepository,
+		apiKeyRepository,
 		userRepository,
-		bcryptHasher,
 	)
 
-	jobRepository := http.NewJobRepository(module.Configuration.DANS.BaseURL)
+	dansClient := http.NewResilientClient(
+		http.NewTimeoutClient(
+			module.Configuration.DANS.ConnectTimeout,
+			module.Configuration.DANS.ReadTimeout,
+		),
+		http.NewCircuitBreaker(
+			module.Timer,
+			module.Configuration.DANS.CircuitBreakerThreshold,
+			module.Configuration.DANS.CircuitBreakerCooldown,
+		),
+		module.Configuration.DANS.MaxRetries,
+		module.Configuration.DANS.RetryBaseDelay,
+		module.Configuration.DANS.RetryMaxDelay,
+	)
+	dansJobRepository := http.NewJobRepository(
+		module.Configuration.DANS.BaseURL,
+		module.Configuration.DANS.PerPage,
+		dansClient,
+	)
+
+	switch module.Configuration.Job.Store {
+	case JobStoreProxy:
+		module.JobsLister = dansJobRepository
+		module.JobGetterByID = dansJobRepository
+	case JobStoreLocal:
+		jobRepository := mysql.NewJobRepository(module.DB, module.Timer, module.Configuration.Job.PerPage)
+
+		module.JobsLister = jobRepository
+		module.JobGetterByID = jobRepository
+		module.JobsSynchronizer = internal.NewJobsSynchronizer(
+			dansJobRepository,
+			jobRepository,
+			module.Configuration.Job.SyncMaxPages,
+		)
+	default:
+		return fmt.Errorf("unknown job store %q", module.Configuration.Job.Store)
+	}
+
+	if module.Configuration.JobCache.Enabled {
+		jobRepository := cache.NewJobRepository(
+			cache.NewLRUBackend(module.Configuration.JobCache.Size),
+			module.Timer,
+			module.Configuration.JobCache.TTL,
+			module.Configuration.JobCache.StaleTTL,
+			module.JobsLister,
+			module.JobGetterByID,
+		)
+
+		module.JobsLister = jobRepository
+		module.JobGetterByID = jobRepository
+		module.JobCacheStatsGetter = jobRepository
+	}
+
+	module.JobsListerOptionValidator = internal.NewJobsListerOptionValidator(validate, module.Configuration.Job.MaxPage)
+
+	jobsCursorSecret := module.Configuration.Job.CursorSecret
+
+	if len(jobsCursorSecret) == 0 {
+		secret, err := internal.NewRandomTokenGenerator(32).GenerateToken()
+		if err != nil {
+			return fmt.Errorf("generating jobs cursor secret: %w", err)
+		}
+
+		jobsCursorSecret = []byte(secret)
+	}
+
+	jobsCursorCodec, err := internal.NewHMACJobsCursorCodec(
+		module.Timer,
+		jobsCursorSecret,
+		module.Configuration.Job.CursorLifeTime,
+	)
+	if err != nil {
+		return fmt.Errorf("initializing jobs cursor codec: %w", err)
+	}
+
+	module.JobsCursorEncoder = jobsCursorCodec
+	module.JobsCursorDecoder = jobsCursorCodec
+
+	savedSearchRepository := mysql.NewSavedSearchRepository(module.DB)
+	savedSearchRequestValidator := internal.NewSavedSearchRequestValidator(validate)
+
+	module.SavedSearchCreator = internal.NewSavedSearchCreator(
+		savedSearchRequestValidator,
+		module.Timer,
+		savedSearchRepository,
+	)
+	module.SavedSearchUpdater = internal.NewSavedSearchUpdater(
+		savedSearchRequestValidator,
+		module.Timer,
+		savedSearchRepository,
+		savedSearchRepository,
+	)
+	module.SavedSearchRunner = internal.NewSavedSearchRunner(
+		module.JobsListerOptionValidator,
+		module.Timer,
+		savedSearchRepository,
+		savedSearchRepository,
+		module.JobsLister,
+	)
+	module.SavedSearchesListerByUserID = savedSearchRepository
+	module.SavedSearchGetterByID = savedSearchRepository
+	module.SavedSearchDeleter = savedSearchRepository
+
+	bookmarkRepository := mysql.NewBookmarkRepository(module.DB)
 
-	module.JobsLister = jobRepository
-	module.JobGetterByID = jobRepository
+	module.BookmarkCreator = internal.NewBookmarkCreator(
+		internal.NewBookmarkRequestValidator(validate),
+		module.Timer,
+		module.JobGetterByID,
+		bookmarkRepository,
+	)
+	module.BookmarksLister = internal.NewBookmarksLister(
+		bookmarkRepository,
+		module.JobGetterByID,
+		module.Configuration.Bookmark.JobLookupConcurrency,
+	)
+	module.BookmarkDeleter = bookmarkRepository
+
+	applicationRepository := mysql.NewApplicationRepository(module.DB)
+
+	module.ApplicationCreator = internal.NewApplicationCreator(
+		internal.NewApplicationRequestValidator(validate),
+		module.Timer,
+		module.JobGetterByID,
+		applicationRepository,
+	)
+	module.ApplicationUpdater = internal.NewApplicationUpdater(
+		internal.NewApplicationUpdateRequestValidator(validate),
+		module.Timer,
+		applicationRepository,
+		applicationRepository,
+	)
+	module.ApplicationStatusTransitioner = internal.NewApplicationStatusTransitioner(
+		internal.NewApplicationStatusTransitionRequestValidator(validate),
+		module.Timer,
+		applicationRepository,
+		applicationRepository,
+	)
+	module.ApplicationsListerByUserID = applicationRepository
+	module.ApplicationGetterByID = applicationRepository
+	module.ApplicationDeleter = applicationRepository
 
 	return nil
 }
@@ -0,0 +1,75 @@
+package cache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+type Item struct {
+	Value      interface{}
+	FreshUntil time.Time
+	StaleUntil time.Time
+}
+
+type Backend interface {
+	Get(key string) (Item, bool)
+	Set(key string, item Item)
+}
+
+type lruEntry struct {
+	key  string
+	item Item
+}
+
+type lruBackend struct {
+	mu       sync.Mutex
+	capacity int
+	entries  map[string]*list.Element
+	order    *list.List
+}
+
+func (b *lruBackend) Get(key string) (Item, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	element, ok := b.entries[key]
+	if !ok {
+		return Item{}, false
+	}
+
+	b.order.MoveToFront(element)
+
+	return element.Value.(*lruEntry).item, true
+}
+
+func (b *lruBackend) Set(key string, item Item) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if element, ok := b.entries[key]; ok {
+		element.Value.(*lruEntry).item = item
+		b.order.MoveToFront(element)
+
+		return
+	}
+
+	b.entries[key] = b.order.PushFront(&lruEntry{
+		key:  key,
+		item: item,
+	})
+
+	for b.order.Len() > b.capacity {
+		oldest := b.order.Back()
+		b.order.Remove(oldest)
+		delete(b.entries, oldest.Value.(*lruEntry).key)
+	}
+}
+
+func NewLRUBackend(capacity int) *lruBackend {
+	return &lruBackend{
+		capacity: capacity,
+		entries:  map[string]*list.Element{},
+		order:    list.New(),
+	}
+}
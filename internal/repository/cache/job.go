@@ -0,0 +1,167 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/adystag/jobs-search/internal"
+)
+
+const refreshTimeout = 30 * time.Second
+
+type jobRepository struct {
+	backend       Backend
+	timer         internal.Timer
+	ttl           time.Duration
+	staleTTL      time.Duration
+	jobsLister    internal.JobsLister
+	jobGetterByID internal.JobGetterByID
+
+	refreshing sync.Map
+	hits       atomic.Int64
+	staleHits  atomic.Int64
+	misses     atomic.Int64
+}
+
+func (r *jobRepository) ListJobs(
+	ctx context.Context,
+	opts ...internal.Option[internal.JobsListerOption],
+) (internal.JobsListResult, error) {
+	opt := internal.JobsListerOption{}
+
+	internal.ApplyOptions(&opt, opts...)
+
+	key := fmt.Sprintf(
+		"jobs:description=%q;location=%q;full_time=%t;page=%d;cursor=%s:%s",
+		opt.Description,
+		opt.Location,
+		opt.FullTime,
+		opt.Page,
+		opt.Cursor.PostedAt.Format(time.RFC3339Nano),
+		opt.Cursor.ID,
+	)
+	val, err := r.load(ctx, key, func(ctx context.Context) (interface{}, error) {
+		jobsListResult, err := r.jobsLister.ListJobs(ctx, opts...)
+
+		return jobsListResult, err
+	})
+	if err != nil {
+		return internal.JobsListResult{}, fmt.Errorf("loading cached jobs: %w", err)
+	}
+
+	jobsListResult := val.(internal.JobsListResult)
+	jobsListResult.Jobs = append([]internal.Job(nil), jobsListResult.Jobs...)
+
+	return jobsListResult, nil
+}
+
+func (r *jobRepository) GetJobByID(ctx context.Context, jobID string) (internal.Job, error) {
+	key := fmt.Sprintf("job:%s", strings.ToLower(jobID))
+	val, err := r.load(ctx, key, func(ctx context.Context) (interface{}, error) {
+		job, err := r.jobGetterByID.GetJobByID(ctx, jobID)
+
+		return job, err
+	})
+	if err != nil {
+		return internal.Job{}, fmt.Errorf("loading cached job: %w", err)
+	}
+
+	return val.(internal.Job), nil
+}
+
+func (r *jobRepository) GetJobCacheStats(ctx context.Context) (internal.JobCacheStats, error) {
+	return internal.JobCacheStats{
+		Hits:      r.hits.Load(),
+		StaleHits: r.staleHits.Load(),
+		Misses:    r.misses.Load(),
+	}, nil
+}
+
+func (r *jobRepository) load(
+	ctx context.Context,
+	key string,
+	fetch func(ctx context.Context) (interface{}, error),
+) (interface{}, error) {
+	now := r.timer.Now()
+	item, ok := r.backend.Get(key)
+
+	if ok && now.Before(item.FreshUntil) {
+		r.hits.Add(1)
+
+		return item.Value, nil
+	}
+
+	if ok && now.Before(item.StaleUntil) {
+		r.staleHits.Add(1)
+		r.refresh(key, fetch)
+
+		return item.Value, nil
+	}
+
+	r.misses.Add(1)
+
+	val, err := fetch(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	r.store(key, val)
+
+	return val, nil
+}
+
+func (r *jobRepository) refresh(key string, fetch func(ctx context.Context) (interface{}, error)) {
+	_, loaded := r.refreshing.LoadOrStore(key, struct{}{})
+	if loaded {
+		return
+	}
+
+	go func() {
+		defer r.refreshing.Delete(key)
+
+		ctx, cancel := context.WithTimeout(context.Background(), refreshTimeout)
+		defer cancel()
+
+		val, err := fetch(ctx)
+		if err != nil {
+			log.Printf("refreshing cache entry %s: %s\n", key, err)
+
+			return
+		}
+
+		r.store(key, val)
+	}()
+}
+
+func (r *jobRepository) store(key string, val interface{}) {
+	now := r.timer.Now()
+
+	r.backend.Set(key, Item{
+		Value:      val,
+		FreshUntil: now.Add(r.ttl),
+		StaleUntil: now.Add(r.ttl + r.staleTTL),
+	})
+}
+
+func NewJobRepository(
+	backend Backend,
+	timer internal.Timer,
+	ttl time.Duration,
+	staleTTL time.Duration,
+	jobsLister internal.JobsLister,
+	jobGetterByID internal.JobGetterByID,
+) *jobRepository {
+	return &jobRepository{
+		backend:       backend,
+		timer:         timer,
+		ttl:           ttl,
+		staleTTL:      staleTTL,
+		jobsLister:    jobsLister,
+		jobGetterByID: jobGetterByID,
+	}
+}
@@ -0,0 +1,87 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/adystag/jobs-search/internal"
+)
+
+type accessTokenRevocationRepository struct {
+	backend                      Backend
+	timer                        internal.Timer
+	revokedTTL                   time.Duration
+	notRevokedTTL                time.Duration
+	accessTokenRevoker           internal.AccessTokenRevoker
+	accessTokenRevocationChecker internal.AccessTokenRevocationChecker
+}
+
+func (r accessTokenRevocationRepository) RevokeAccessToken(
+	ctx context.Context,
+	accessTokenID string,
+	userID int64,
+	expiresAt time.Time,
+) error {
+	err := r.accessTokenRevoker.RevokeAccessToken(ctx, accessTokenID, userID, expiresAt)
+	if err != nil {
+		return fmt.Errorf("revoking access token: %w", err)
+	}
+
+	r.store(accessTokenID, true, expiresAt)
+
+	return nil
+}
+
+func (r accessTokenRevocationRepository) IsAccessTokenRevoked(ctx context.Context, accessTokenID string) (bool, error) {
+	now := r.timer.Now()
+	item, ok := r.backend.Get(r.key(accessTokenID))
+
+	if ok && now.Before(item.FreshUntil) {
+		return item.Value.(bool), nil
+	}
+
+	revoked, err := r.accessTokenRevocationChecker.IsAccessTokenRevoked(ctx, accessTokenID)
+	if err != nil {
+		return false, fmt.Errorf("checking access token revocation: %w", err)
+	}
+
+	ttl := r.notRevokedTTL
+	if revoked {
+		ttl = r.revokedTTL
+	}
+
+	r.store(accessTokenID, revoked, now.Add(ttl))
+
+	return revoked, nil
+}
+
+func (r accessTokenRevocationRepository) store(accessTokenID string, revoked bool, until time.Time) {
+	r.backend.Set(r.key(accessTokenID), Item{
+		Value:      revoked,
+		FreshUntil: until,
+		StaleUntil: until,
+	})
+}
+
+func (r accessTokenRevocationRepository) key(accessTokenID string) string {
+	return fmt.Sprintf("revoked_access_token:%s", accessTokenID)
+}
+
+func NewAccessTokenRevocationRepository(
+	backend Backend,
+	timer internal.Timer,
+	revokedTTL time.Duration,
+	notRevokedTTL time.Duration,
+	accessTokenRevoker internal.AccessTokenRevoker,
+	accessTokenRevocationChecker internal.AccessTokenRevocationChecker,
+) *accessTokenRevocationRepository {
+	return &accessTokenRevocationRepository{
+		backend:                      backend,
+		timer:                        timer,
+		revokedTTL:                   revokedTTL,
+		notRevokedTTL:                notRevokedTTL,
+		accessTokenRevoker:           accessTokenRevoker,
+		accessTokenRevocationChecker: accessTokenRevocationChecker,
+	}
+}
@@ -0,0 +1,120 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/adystag/jobs-search/internal"
+)
+
+// userRepository caches users in process memory only, so invalidations made
+// by one instance are not seen by the others until their entries expire.
+type userRepository struct {
+	mu                          sync.Mutex
+	generation                  uint64
+	backend                     Backend
+	timer                       internal.Timer
+	ttl                         time.Duration
+	userGetterByID              internal.UserGetterByID
+	userTokenVersionIncrementer internal.UserTokenVersionIncrementer
+	userTwoFactorUpdater        internal.UserTwoFactorUpdater
+}
+
+func (r *userRepository) GetUserByID(ctx context.Context, userID int64) (internal.User, error) {
+	now := r.timer.Now()
+	item, ok := r.backend.Get(r.key(userID))
+
+	if ok && now.Before(item.FreshUntil) {
+		return item.Value.(internal.User), nil
+	}
+
+	generation := r.currentGeneration()
+
+	user, err := r.userGetterByID.GetUserByID(ctx, userID)
+	if err != nil {
+		return internal.User{}, fmt.Errorf("getting user by id: %w", err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.generation == generation {
+		r.store(userID, user, now.Add(r.ttl))
+	}
+
+	return user, nil
+}
+
+func (r *userRepository) IncrementUserTokenVersion(ctx context.Context, userID int64) error {
+	err := r.userTokenVersionIncrementer.IncrementUserTokenVersion(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("incrementing user token version: %w", err)
+	}
+
+	r.invalidate(userID)
+
+	return nil
+}
+
+func (r *userRepository) UpdateUserTwoFactor(
+	ctx context.Context,
+	userID int64,
+	twoFactorEnabledAt time.Time,
+	updatedAt time.Time,
+) error {
+	err := r.userTwoFactorUpdater.UpdateUserTwoFactor(ctx, userID, twoFactorEnabledAt, updatedAt)
+	if err != nil {
+		return fmt.Errorf("updating user two factor: %w", err)
+	}
+
+	r.invalidate(userID)
+
+	return nil
+}
+
+func (r *userRepository) currentGeneration() uint64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.generation
+}
+
+func (r *userRepository) invalidate(userID int64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.generation++
+	r.store(userID, internal.User{}, r.timer.Now())
+}
+
+func (r *userRepository) store(userID int64, user internal.User, until time.Time) {
+	r.backend.Set(r.key(userID), Item{
+		Value:      user,
+		FreshUntil: until,
+		StaleUntil: until,
+	})
+}
+
+func (r *userRepository) key(userID int64) string {
+	return fmt.Sprintf("user:%d", userID)
+}
+
+func NewUserRepository(
+	backend Backend,
+	timer internal.Timer,
+	ttl time.Duration,
+	userGetterByID internal.UserGetterByID,
+	userTokenVersionIncrementer internal.UserTokenVersionIncrementer,
+	userTwoFactorUpdater internal.UserTwoFactorUpdater,
+) *userRepository {
+	return &userRepository{
+		backend:                     backend,
+		timer:                       timer,
+		ttl:                         ttl,
+		userGetterByID:              userGetterByID,
+		userTokenVersionIncrementer: userTokenVersionIncrementer,
+		userTwoFactorUpdater:        userTwoFactorUpdater,
+	}
+}
@@ -0,0 +1,121 @@
+package cache_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/adystag/jobs-search/internal"
+	"github.com/adystag/jobs-search/internal/repository/cache"
+)
+
+type fixedTimer struct {
+	now time.Time
+}
+
+func (t fixedTimer) Now() time.Time {
+	return t.now
+}
+
+type countingUserRepository struct {
+	user   internal.User
+	calls  int
+	during func()
+}
+
+func (r *countingUserRepository) GetUserByID(ctx context.Context, userID int64) (internal.User, error) {
+	r.calls++
+	user := r.user
+
+	if r.during != nil {
+		during := r.during
+		r.during = nil
+		during()
+	}
+
+	return user, nil
+}
+
+func (r *countingUserRepository) IncrementUserTokenVersion(ctx context.Context, userID int64) error {
+	r.user.TokenVersion++
+
+	return nil
+}
+
+func (r *countingUserRepository) UpdateUserTwoFactor(
+	ctx context.Context,
+	userID int64,
+	twoFactorEnabledAt time.Time,
+	updatedAt time.Time,
+) error {
+	r.user.TwoFactorEnabledAt = twoFactorEnabledAt
+
+	return nil
+}
+
+func TestUserRepositoryInvalidation(t *testing.T) {
+	tests := []struct {
+		name       string
+		invalidate func(ctx context.Context, repository internal.UserTokenVersionIncrementer, updater internal.UserTwoFactorUpdater) error
+	}{
+		{
+			name: "token version incremented",
+			invalidate: func(ctx context.Context, repository internal.UserTokenVersionIncrementer, updater internal.UserTwoFactorUpdater) error {
+				return repository.IncrementUserTokenVersion(ctx, 1)
+			},
+		},
+		{
+			name: "two factor updated",
+			invalidate: func(ctx context.Context, repository internal.UserTokenVersionIncrementer, updater internal.UserTwoFactorUpdater) error {
+				return updater.UpdateUserTwoFactor(ctx, 1, time.Time{}, time.Time{})
+			},
+		},
+	}
+
+	now := time.Date(2023, time.May, 4, 7, 31, 44, 0, time.UTC)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			users := &countingUserRepository{
+				user: internal.User{ID: 1, TwoFactorEnabledAt: now.Add(-time.Hour)},
+			}
+			repository := cache.NewUserRepository(cache.NewLRUBackend(10), fixedTimer{now: now}, time.Minute, users, users, users)
+
+			users.during = func() {
+				err := tt.invalidate(ctx, repository, repository)
+				if err != nil {
+					t.Fatalf("invalidating user error = %v", err)
+				}
+			}
+
+			stale, err := repository.GetUserByID(ctx, 1)
+			if err != nil {
+				t.Fatalf("GetUserByID() error = %v", err)
+			}
+
+			user, err := repository.GetUserByID(ctx, 1)
+			if err != nil {
+				t.Fatalf("GetUserByID() error = %v", err)
+			}
+
+			if users.calls != 2 {
+				t.Errorf("GetUserByID() backing calls = %d, want 2", users.calls)
+			}
+
+			if reflect.DeepEqual(user, stale) {
+				t.Errorf("GetUserByID() = %+v, want user read after invalidation", user)
+			}
+
+			_, err = repository.GetUserByID(ctx, 1)
+			if err != nil {
+				t.Fatalf("GetUserByID() error = %v", err)
+			}
+
+			if users.calls != 2 {
+				t.Errorf("GetUserByID() backing calls = %d, want cached user", users.calls)
+			}
+		})
+	}
+}
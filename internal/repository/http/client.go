@@ -0,0 +1,243 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/adystag/jobs-search/internal"
+)
+
+type Doer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+type circuitBreakerState int
+
+const (
+	circuitBreakerClosed circuitBreakerState = iota
+	circuitBreakerOpen
+	circuitBreakerHalfOpen
+)
+
+type circuitBreaker struct {
+	mu        sync.Mutex
+	timer     internal.Timer
+	threshold int
+	cooldown  time.Duration
+	state     circuitBreakerState
+	failures  int
+	openedAt  time.Time
+	probing   bool
+}
+
+func (cb *circuitBreaker) Allow() error {
+	if cb.threshold <= 0 {
+		return nil
+	}
+
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	switch cb.state {
+	case circuitBreakerOpen:
+		if cb.timer.Now().Sub(cb.openedAt) < cb.cooldown {
+			return fmt.Errorf("%w: circuit breaker is open", internal.ErrUpstreamUnavailable)
+		}
+
+		cb.state = circuitBreakerHalfOpen
+		cb.probing = true
+	case circuitBreakerHalfOpen:
+		if cb.probing {
+			return fmt.Errorf("%w: circuit breaker is probing", internal.ErrUpstreamUnavailable)
+		}
+
+		cb.probing = true
+	}
+
+	return nil
+}
+
+func (cb *circuitBreaker) Succeed() {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	cb.state = circuitBreakerClosed
+	cb.failures = 0
+	cb.probing = false
+}
+
+func (cb *circuitBreaker) Fail() {
+	if cb.threshold <= 0 {
+		return
+	}
+
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	cb.failures++
+	cb.probing = false
+
+	if cb.state == circuitBreakerHalfOpen || cb.failures >= cb.threshold {
+		cb.state = circuitBreakerOpen
+		cb.openedAt = cb.timer.Now()
+	}
+}
+
+func (cb *circuitBreaker) Release() {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	cb.probing = false
+}
+
+func NewCircuitBreaker(timer internal.Timer, threshold int, cooldown time.Duration) *circuitBreaker {
+	return &circuitBreaker{
+		timer:     timer,
+		threshold: threshold,
+		cooldown:  cooldown,
+	}
+}
+
+type resilientClient struct {
+	client         Doer
+	circuitBreaker *circuitBreaker
+	maxRetries     int
+	baseDelay      time.Duration
+	maxDelay       time.Duration
+	sleep          func(ctx context.Context, delay time.Duration) error
+}
+
+func (c resilientClient) Do(req *http.Request) (*http.Response, error) {
+	var lastErr error
+
+	for attempt := 0; attempt <= c.maxRetries; attempt++ {
+		if attempt > 0 {
+			err := c.wait(req, attempt)
+			if err != nil {
+				return nil, fmt.Errorf("waiting before retry attempt %d: %w", attempt, err)
+			}
+		}
+
+		err := c.circuitBreaker.Allow()
+		if err != nil {
+			return nil, err
+		}
+
+		res, err := c.client.Do(req)
+		if err != nil {
+			if req.Context().Err() != nil {
+				c.circuitBreaker.Release()
+
+				return nil, err
+			}
+
+			c.circuitBreaker.Fail()
+			lastErr = err
+
+			continue
+		}
+
+		if res.StatusCode < http.StatusInternalServerError {
+			c.circuitBreaker.Succeed()
+
+			return res, nil
+		}
+
+		c.circuitBreaker.Fail()
+
+		if attempt == c.maxRetries {
+			return res, nil
+		}
+
+		io.Copy(io.Discard, res.Body)
+		res.Body.Close()
+	}
+
+	return nil, lastErr
+}
+
+func (c resilientClient) wait(req *http.Request, attempt int) error {
+	delay := c.baseDelay << (attempt - 1)
+	if delay <= 0 || delay > c.maxDelay {
+		delay = c.maxDelay
+	}
+
+	if delay > 0 {
+		delay = delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+	}
+
+	err := c.sleep(req.Context(), delay)
+	if err != nil {
+		return err
+	}
+
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return fmt.Errorf("rewinding request body: %w", err)
+		}
+
+		req.Body = body
+	}
+
+	return nil
+}
+
+func NewResilientClient(
+	client Doer,
+	circuitBreaker *circuitBreaker,
+	maxRetries int,
+	baseDelay time.Duration,
+	maxDelay time.Duration,
+) *resilientClient {
+	if maxDelay < baseDelay {
+		maxDelay = baseDelay
+	}
+
+	return &resilientClient{
+		client:         client,
+		circuitBreaker: circuitBreaker,
+		maxRetries:     maxRetries,
+		baseDelay:      baseDelay,
+		maxDelay:       maxDelay,
+		sleep:          sleep,
+	}
+}
+
+func sleep(ctx context.Context, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+	}
+
+	return nil
+}
+
+func NewTimeoutClient(connectTimeout, readTimeout time.Duration) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: connectTimeout,
+	}
+
+	return &http.Client{
+		Timeout: connectTimeout + readTimeout,
+		Transport: &http.Transport{
+			Proxy:                 http.ProxyFromEnvironment,
+			DialContext:           dialer.DialContext,
+			TLSHandshakeTimeout:   connectTimeout,
+			ResponseHeaderTimeout: readTimeout,
+			MaxIdleConnsPerHost:   10,
+			IdleConnTimeout:       90 * time.Second,
+		},
+	}
+}
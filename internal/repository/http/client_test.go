@@ -0,0 +1,305 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/adystag/jobs-search/internal"
+)
+
+type manualTimer struct {
+	now time.Time
+}
+
+func (t *manualTimer) Now() time.Time {
+	return t.now
+}
+
+type scriptedDoer struct {
+	results []int
+	calls   int
+}
+
+func (d *scriptedDoer) Do(req *http.Request) (*http.Response, error) {
+	result := d.results[len(d.results)-1]
+
+	if d.calls < len(d.results) {
+		result = d.results[d.calls]
+	}
+
+	d.calls++
+
+	if result == 0 {
+		return nil, errors.New("connection reset by peer")
+	}
+
+	return &http.Response{
+		StatusCode: result,
+		Body:       io.NopCloser(strings.NewReader("")),
+	}, nil
+}
+
+func TestCircuitBreakerTransitions(t *testing.T) {
+	type step struct {
+		action  string
+		wantErr bool
+	}
+
+	tests := []struct {
+		name  string
+		steps []step
+	}{
+		{
+			name: "opens after threshold",
+			steps: []step{
+				{action: "allow"},
+				{action: "fail"},
+				{action: "allow"},
+				{action: "fail"},
+				{action: "allow", wantErr: true},
+			},
+		},
+		{
+			name: "success resets failures",
+			steps: []step{
+				{action: "allow"},
+				{action: "fail"},
+				{action: "allow"},
+				{action: "succeed"},
+				{action: "allow"},
+				{action: "fail"},
+				{action: "allow"},
+			},
+		},
+		{
+			name: "half open allows a single probe",
+			steps: []step{
+				{action: "fail"},
+				{action: "fail"},
+				{action: "advance"},
+				{action: "allow"},
+				{action: "allow", wantErr: true},
+			},
+		},
+		{
+			name: "failed probe reopens",
+			steps: []step{
+				{action: "fail"},
+				{action: "fail"},
+				{action: "advance"},
+				{action: "allow"},
+				{action: "fail"},
+				{action: "allow", wantErr: true},
+				{action: "advance"},
+				{action: "allow"},
+			},
+		},
+		{
+			name: "successful probe closes",
+			steps: []step{
+				{action: "fail"},
+				{action: "fail"},
+				{action: "advance"},
+				{action: "allow"},
+				{action: "succeed"},
+				{action: "allow"},
+				{action: "allow"},
+				{action: "fail"},
+				{action: "allow"},
+			},
+		},
+		{
+			name: "released probe can be retried",
+			steps: []step{
+				{action: "fail"},
+				{action: "fail"},
+				{action: "advance"},
+				{action: "allow"},
+				{action: "release"},
+				{action: "allow"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			timer := &manualTimer{now: time.Date(2023, time.May, 4, 7, 31, 44, 0, time.UTC)}
+			cb := NewCircuitBreaker(timer, 2, 10*time.Second)
+
+			for i, each := range tt.steps {
+				switch each.action {
+				case "allow":
+					err := cb.Allow()
+					if (err != nil) != each.wantErr {
+						t.Fatalf("step %d Allow() error = %v, want error %t", i, err, each.wantErr)
+					}
+
+					if err != nil && !errors.Is(err, internal.ErrUpstreamUnavailable) {
+						t.Fatalf("step %d Allow() error = %v, want %v", i, err, internal.ErrUpstreamUnavailable)
+					}
+				case "fail":
+					cb.Fail()
+				case "succeed":
+					cb.Succeed()
+				case "release":
+					cb.Release()
+				case "advance":
+					timer.now = timer.now.Add(10 * time.Second)
+				}
+			}
+		})
+	}
+}
+
+func TestResilientClientRetry(t *testing.T) {
+	tests := []struct {
+		name       string
+		results    []int
+		maxRetries int
+		threshold  int
+		wantCalls  int
+		wantStatus int
+		wantErr    error
+	}{
+		{
+			name:       "success",
+			results:    []int{http.StatusOK},
+			maxRetries: 3,
+			wantCalls:  1,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "retries server errors",
+			results:    []int{http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusOK},
+			maxRetries: 3,
+			wantCalls:  3,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "retries network errors",
+			results:    []int{0, 0, http.StatusOK},
+			maxRetries: 3,
+			wantCalls:  3,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "does not retry client errors",
+			results:    []int{http.StatusNotFound},
+			maxRetries: 3,
+			wantCalls:  1,
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "returns last server error",
+			results:    []int{http.StatusServiceUnavailable},
+			maxRetries: 2,
+			wantCalls:  3,
+			wantStatus: http.StatusServiceUnavailable,
+		},
+		{
+			name:       "returns last network error",
+			results:    []int{0},
+			maxRetries: 2,
+			wantCalls:  3,
+			wantErr:    errors.New("connection reset by peer"),
+		},
+		{
+			name:       "stops when circuit opens",
+			results:    []int{0},
+			maxRetries: 5,
+			threshold:  2,
+			wantCalls:  2,
+			wantErr:    internal.ErrUpstreamUnavailable,
+		},
+	}
+
+	baseDelay := 100 * time.Millisecond
+	maxDelay := 300 * time.Millisecond
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doer := &scriptedDoer{results: tt.results}
+			timer := &manualTimer{now: time.Date(2023, time.May, 4, 7, 31, 44, 0, time.UTC)}
+			client := NewResilientClient(doer, NewCircuitBreaker(timer, tt.threshold, time.Minute), tt.maxRetries, baseDelay, maxDelay)
+
+			var delays []time.Duration
+
+			client.sleep = func(ctx context.Context, delay time.Duration) error {
+				delays = append(delays, delay)
+
+				return nil
+			}
+
+			req, err := http.NewRequest(http.MethodGet, "http://upstream.test", nil)
+			if err != nil {
+				t.Fatalf("initializing new request: %s", err)
+			}
+
+			res, err := client.Do(req)
+
+			switch {
+			case tt.wantErr == nil && err != nil:
+				t.Fatalf("Do() error = %v, want nil", err)
+			case tt.wantErr != nil && err == nil:
+				t.Fatalf("Do() error = nil, want %v", tt.wantErr)
+			case tt.wantErr != nil && !errors.Is(err, tt.wantErr) && err.Error() != tt.wantErr.Error():
+				t.Fatalf("Do() error = %v, want %v", err, tt.wantErr)
+			case tt.wantErr == nil && res.StatusCode != tt.wantStatus:
+				t.Errorf("Do() status = %d, want %d", res.StatusCode, tt.wantStatus)
+			}
+
+			if doer.calls != tt.wantCalls {
+				t.Errorf("Do() calls = %d, want %d", doer.calls, tt.wantCalls)
+			}
+
+			if len(delays) < tt.wantCalls-1 {
+				t.Fatalf("Do() waited %d times, want at least %d", len(delays), tt.wantCalls-1)
+			}
+
+			for i, delay := range delays {
+				ceiling := baseDelay << i
+				if ceiling > maxDelay {
+					ceiling = maxDelay
+				}
+
+				if delay < ceiling/2 || delay > ceiling {
+					t.Errorf("retry %d delay = %s, want between %s and %s", i+1, delay, ceiling/2, ceiling)
+				}
+			}
+		})
+	}
+}
+
+func TestNewResilientClientDelayBounds(t *testing.T) {
+	client := NewResilientClient(&scriptedDoer{results: []int{0}}, NewCircuitBreaker(&manualTimer{}, 0, 0), 3, 50*time.Millisecond, 0)
+
+	var delays []time.Duration
+
+	client.sleep = func(ctx context.Context, delay time.Duration) error {
+		delays = append(delays, delay)
+
+		return nil
+	}
+
+	req, err := http.NewRequest(http.MethodGet, "http://upstream.test", nil)
+	if err != nil {
+		t.Fatalf("initializing new request: %s", err)
+	}
+
+	client.Do(req)
+
+	if len(delays) != 3 {
+		t.Fatalf("Do() waited %d times, want 3", len(delays))
+	}
+
+	for i, delay := range delays {
+		if delay < 25*time.Millisecond || delay > 50*time.Millisecond {
+			t.Errorf("retry %d delay = %s, want between 25ms and 50ms", i+1, delay)
+		}
+	}
+}
@@ -3,8 +3,11 @@ package http
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"log"
+	"net"
 	"net/http"
 	"path"
 	"strconv"
@@ -31,19 +34,66 @@ type Job struct {
 
 type jobRepository struct {
 	baseURL string
+	perPage int
+	client  Doer
 }
 
-func (jr jobRepository) ListJobs(ctx context.Context, opts ...internal.Option[internal.JobsListerOption]) ([]internal.Job, error) {
+func (jr jobRepository) ListJobs(
+	ctx context.Context,
+	opts ...internal.Option[internal.JobsListerOption],
+) (internal.JobsListResult, error) {
+	opt := internal.JobsListerOption{}
+
+	internal.ApplyOptions(&opt, opts...)
+
+	if !opt.Cursor.IsZero() {
+		return internal.JobsListResult{}, internal.NewValidationError("cursor", "unsupported")
+	}
+
+	jobs, err := jr.listJobs(ctx, opt)
+	if err != nil {
+		return internal.JobsListResult{}, fmt.Errorf("listing jobs page %d: %w", opt.Page, err)
+	}
+
+	jobsListResult := internal.JobsListResult{
+		Jobs: jobs,
+		Page: opt.Page,
+	}
+
+	if opt.Page == 0 {
+		return jobsListResult, nil
+	}
+
+	jobsListResult.PerPage = jr.perPage
+
+	switch {
+	case len(jobs) == 0:
+	case jr.perPage > 0:
+		jobsListResult.HasNext = len(jobs) >= jr.perPage
+	default:
+		next := opt
+		next.Page++
+
+		nextJobs, err := jr.listJobs(ctx, next)
+		if err != nil {
+			log.Printf("probing jobs page %d: %s\n", next.Page, err)
+
+			break
+		}
+
+		jobsListResult.HasNext = len(nextJobs) > 0
+	}
+
+	return jobsListResult, nil
+}
+
+func (jr jobRepository) listJobs(ctx context.Context, opt internal.JobsListerOption) ([]internal.Job, error) {
 	url, err := gourl.Parse(jr.baseURL)
 	if err != nil {
 		return nil, fmt.Errorf("parsing base url: %w", err)
 	}
 
 	url.Path = path.Join(url.Path, "api/recruitment/positions.json")
-	opt := internal.JobsListerOption{}
-
-	internal.ApplyOptions(&opt, opts...)
-
 	values := url.Query()
 
 	if len(opt.Description) > 0 {
@@ -63,15 +113,14 @@ func (jr jobRepository) ListJobs(ctx context.Context, opts ...internal.Option[in
 	}
 
 	url.RawQuery = values.Encode()
-	req, err := http.NewRequest(http.MethodGet, url.String(), nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url.String(), nil)
 	if err != nil {
 		return nil, fmt.Errorf("initializing new request: %w", err)
 	}
 
-	client := &http.Client{}
-	res, err := client.Do(req)
+	res, err := jr.client.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("doing http request: %w", err)
+		return nil, fmt.Errorf("doing http request: %w", evaluateDoError(err))
 	}
 
 	defer res.Body.Close()
@@ -82,7 +131,7 @@ func (jr jobRepository) ListJobs(ctx context.Context, opts ...internal.Option[in
 	}
 
 	if res.StatusCode >= http.StatusBadRequest {
-		return nil, fmt.Errorf("http request returns %d:%s", res.StatusCode, string(b))
+		return nil, fmt.Errorf("%w: http request returns %d:%s", internal.ErrUpstreamFailed, res.StatusCode, string(b))
 	}
 
 	var mJobs []Job
@@ -125,15 +174,14 @@ func (jr jobRepository) GetJobByID(ctx context.Context, jobID string) (internal.
 	}
 
 	url.Path = path.Join(url.Path, fmt.Sprintf("/api/recruitment/positions/%s", id.String()))
-	req, err := http.NewRequest(http.MethodGet, url.String(), nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url.String(), nil)
 	if err != nil {
 		return internal.Job{}, fmt.Errorf("initializing new request: %w", err)
 	}
 
-	client := &http.Client{}
-	res, err := client.Do(req)
+	res, err := jr.client.Do(req)
 	if err != nil {
-		return internal.Job{}, fmt.Errorf("doing http request: %w", err)
+		return internal.Job{}, fmt.Errorf("doing http request: %w", evaluateDoError(err))
 	}
 
 	defer res.Body.Close()
@@ -143,8 +191,12 @@ func (jr jobRepository) GetJobByID(ctx context.Context, jobID string) (internal.
 		return internal.Job{}, fmt.Errorf("reading http response body: %w", err)
 	}
 
+	if res.StatusCode == http.StatusNotFound {
+		return internal.Job{}, fmt.Errorf("%w: http request returns %d:%s", internal.ErrJobNotFound, res.StatusCode, string(b))
+	}
+
 	if res.StatusCode >= http.StatusBadRequest {
-		return internal.Job{}, fmt.Errorf("http request returns %d:%s", res.StatusCode, string(b))
+		return internal.Job{}, fmt.Errorf("%w: http request returns %d:%s", internal.ErrUpstreamFailed, res.StatusCode, string(b))
 	}
 
 	var job Job
@@ -154,6 +206,10 @@ func (jr jobRepository) GetJobByID(ctx context.Context, jobID string) (internal.
 		return internal.Job{}, fmt.Errorf("unmarshalling body response from json: %w", err)
 	}
 
+	if job.ID == uuid.Nil {
+		return internal.Job{}, fmt.Errorf("%w: http request returns empty job", internal.ErrJobNotFound)
+	}
+
 	return internal.Job{
 		ID:          job.ID,
 		Company:     job.Company,
@@ -169,8 +225,24 @@ func (jr jobRepository) GetJobByID(ctx context.Context, jobID string) (internal.
 	}, nil
 }
 
-func NewJobRepository(baseURL string) *jobRepository {
+func evaluateDoError(err error) error {
+	if errors.Is(err, internal.ErrUpstreamUnavailable) {
+		return err
+	}
+
+	var netErr net.Error
+
+	if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()) {
+		return fmt.Errorf("%w: %w", internal.ErrUpstreamTimeout, err)
+	}
+
+	return fmt.Errorf("%w: %w", internal.ErrUpstreamFailed, err)
+}
+
+func NewJobRepository(baseURL string, perPage int, client Doer) *jobRepository {
 	return &jobRepository{
 		baseURL: baseURL,
+		perPage: perPage,
+		client:  client,
 	}
 }
@@ -0,0 +1,117 @@
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/adystag/jobs-search/internal"
+	repohttp "github.com/adystag/jobs-search/internal/repository/http"
+
+	"github.com/google/uuid"
+)
+
+func serveJobPages(t *testing.T, pages map[int]int, failingPage int) *httptest.Server {
+	t.Helper()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+
+		if page == failingPage {
+			w.WriteHeader(http.StatusBadGateway)
+
+			return
+		}
+
+		jobs := []repohttp.Job{}
+
+		for i := 0; i < pages[page]; i++ {
+			jobs = append(jobs, repohttp.Job{ID: uuid.New()})
+		}
+
+		json.NewEncoder(w).Encode(jobs)
+	}))
+
+	t.Cleanup(ts.Close)
+
+	return ts
+}
+
+func TestJobRepositoryListJobsPagination(t *testing.T) {
+	tests := []struct {
+		name        string
+		perPage     int
+		pages       map[int]int
+		failingPage int
+		page        int
+		wantPerPage int
+		wantHasNext bool
+	}{
+		{
+			name:        "full page",
+			perPage:     3,
+			pages:       map[int]int{1: 3, 2: 1},
+			page:        1,
+			wantPerPage: 3,
+			wantHasNext: true,
+		},
+		{
+			name:        "short page",
+			perPage:     3,
+			pages:       map[int]int{1: 3, 2: 1},
+			page:        2,
+			wantPerPage: 3,
+		},
+		{
+			name:        "empty page",
+			perPage:     3,
+			pages:       map[int]int{1: 3},
+			page:        2,
+			wantPerPage: 3,
+		},
+		{
+			name:        "probed next page",
+			pages:       map[int]int{1: 3, 2: 1},
+			page:        1,
+			wantHasNext: true,
+		},
+		{
+			name:  "probed last page",
+			pages: map[int]int{1: 3, 2: 1},
+			page:  2,
+		},
+		{
+			name:        "failed probe",
+			pages:       map[int]int{1: 3, 2: 1},
+			failingPage: 2,
+			page:        1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := serveJobPages(t, tt.pages, tt.failingPage)
+			repository := repohttp.NewJobRepository(ts.URL, tt.perPage, ts.Client())
+
+			jobsListResult, err := repository.ListJobs(context.Background(), internal.WithJobsListerPage(tt.page))
+			if err != nil {
+				t.Fatalf("ListJobs() error = %v", err)
+			}
+
+			if len(jobsListResult.Jobs) != tt.pages[tt.page] {
+				t.Errorf("ListJobs() jobs = %d, want %d", len(jobsListResult.Jobs), tt.pages[tt.page])
+			}
+
+			if jobsListResult.PerPage != tt.wantPerPage {
+				t.Errorf("ListJobs() per page = %d, want %d", jobsListResult.PerPage, tt.wantPerPage)
+			}
+
+			if jobsListResult.HasNext != tt.wantHasNext {
+				t.Errorf("ListJobs() has next = %t, want %t", jobsListResult.HasNext, tt.wantHasNext)
+			}
+		})
+	}
+}
@@ -0,0 +1,457 @@
+package http
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	gourl "net/url"
+
+	"github.com/adystag/jobs-search/internal"
+	"github.com/dgrijalva/jwt-go"
+)
+
+type oidcDiscovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+type oidcJWK struct {
+	KeyType string `json:"kty"`
+	KeyID   string `json:"kid"`
+	Use     string `json:"use"`
+	N       string `json:"n"`
+	E       string `json:"e"`
+	Curve   string `json:"crv"`
+	X       string `json:"x"`
+	Y       string `json:"y"`
+}
+
+type oidcTokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	IDToken     string `json:"id_token"`
+}
+
+type oidcAudience []string
+
+func (a *oidcAudience) UnmarshalJSON(b []byte) error {
+	var single string
+
+	err := json.Unmarshal(b, &single)
+	if err == nil {
+		*a = oidcAudience{single}
+
+		return nil
+	}
+
+	var multiple []string
+
+	err = json.Unmarshal(b, &multiple)
+	if err != nil {
+		return fmt.Errorf("unmarshalling audience from json: %w", err)
+	}
+
+	*a = multiple
+
+	return nil
+}
+
+func (a oidcAudience) Contains(audience string) bool {
+	for _, each := range a {
+		if each == audience {
+			return true
+		}
+	}
+
+	return false
+}
+
+type oidcIDTokenClaims struct {
+	Issuer            string       `json:"iss"`
+	Subject           string       `json:"sub"`
+	Audience          oidcAudience `json:"aud"`
+	AuthorizedParty   string       `json:"azp"`
+	ExpiresAt         int64        `json:"exp"`
+	IssuedAt          int64        `json:"iat"`
+	Nonce             string       `json:"nonce"`
+	Email             string       `json:"email"`
+	EmailVerified     bool         `json:"email_verified"`
+	Name              string       `json:"name"`
+	PreferredUsername string       `json:"preferred_username"`
+}
+
+func (c oidcIDTokenClaims) Valid() error {
+	return nil
+}
+
+type oidcProvider struct {
+	mu           sync.Mutex
+	client       Doer
+	timer        internal.Timer
+	issuer       string
+	clientID     string
+	clientSecret string
+	redirectURL  string
+	scopes       []string
+	leeway       time.Duration
+	discovery    *oidcDiscovery
+	keys         map[string]interface{}
+}
+
+func (p *oidcProvider) AuthorizationURL(ctx context.Context, req internal.OIDCAuthorizationURLRequest) (string, error) {
+	discovery, err := p.discover(ctx)
+	if err != nil {
+		return "", fmt.Errorf("discovering oidc provider: %w", err)
+	}
+
+	url, err := gourl.Parse(discovery.AuthorizationEndpoint)
+	if err != nil {
+		return "", fmt.Errorf("parsing authorization endpoint: %w", err)
+	}
+
+	values := url.Query()
+
+	values.Set("response_type", "code")
+	values.Set("client_id", p.clientID)
+	values.Set("redirect_uri", p.redirectURL)
+	values.Set("scope", strings.Join(p.scopes, " "))
+	values.Set("state", req.State)
+	values.Set("nonce", req.Nonce)
+	values.Set("code_challenge", req.CodeChallenge)
+	values.Set("code_challenge_method", "S256")
+
+	url.RawQuery = values.Encode()
+
+	return url.String(), nil
+}
+
+func (p *oidcProvider) Exchange(ctx context.Context, req internal.OIDCExchangeRequest) (internal.OIDCIdentity, error) {
+	discovery, err := p.discover(ctx)
+	if err != nil {
+		return internal.OIDCIdentity{}, fmt.Errorf("discovering oidc provider: %w", err)
+	}
+
+	values := gourl.Values{}
+
+	values.Set("grant_type", "authorization_code")
+	values.Set("code", req.Code)
+	values.Set("redirect_uri", p.redirectURL)
+	values.Set("client_id", p.clientID)
+	values.Set("code_verifier", req.CodeVerifier)
+
+	if len(p.clientSecret) > 0 {
+		values.Set("client_secret", p.clientSecret)
+	}
+
+	httpReq, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodPost,
+		discovery.TokenEndpoint,
+		strings.NewReader(values.Encode()),
+	)
+	if err != nil {
+		return internal.OIDCIdentity{}, fmt.Errorf("initializing new request: %w", err)
+	}
+
+	httpReq.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	httpReq.Header.Set("Accept", "application/json")
+
+	res, err := p.client.Do(httpReq)
+	if err != nil {
+		return internal.OIDCIdentity{}, fmt.Errorf("doing http request: %w", evaluateDoError(err))
+	}
+
+	defer res.Body.Close()
+
+	b, err := io.ReadAll(res.Body)
+	if err != nil {
+		return internal.OIDCIdentity{}, fmt.Errorf("reading http response body: %w", err)
+	}
+
+	if res.StatusCode >= http.StatusBadRequest && res.StatusCode < http.StatusInternalServerError {
+		return internal.OIDCIdentity{}, fmt.Errorf(
+			"%w: token endpoint returns %d:%s",
+			internal.ErrOIDCAuthenticationFailed,
+			res.StatusCode,
+			string(b),
+		)
+	}
+
+	if res.StatusCode >= http.StatusBadRequest {
+		return internal.OIDCIdentity{}, fmt.Errorf(
+			"%w: token endpoint returns %d:%s",
+			internal.ErrUpstreamFailed,
+			res.StatusCode,
+			string(b),
+		)
+	}
+
+	var tokenResponse oidcTokenResponse
+
+	err = json.Unmarshal(b, &tokenResponse)
+	if err != nil {
+		return internal.OIDCIdentity{}, fmt.Errorf("unmarshalling body response from json: %w", err)
+	}
+
+	if len(tokenResponse.IDToken) == 0 {
+		return internal.OIDCIdentity{}, fmt.Errorf("%w: token response has no id token", internal.ErrOIDCAuthenticationFailed)
+	}
+
+	claims, err := p.verify(ctx, discovery, tokenResponse.IDToken, req.Nonce)
+	if err != nil {
+		return internal.OIDCIdentity{}, fmt.Errorf("verifying id token: %w", err)
+	}
+
+	return internal.OIDCIdentity{
+		Subject:           claims.Subject,
+		Email:             claims.Email,
+		EmailVerified:     claims.EmailVerified,
+		Name:              claims.Name,
+		PreferredUsername: claims.PreferredUsername,
+	}, nil
+}
+
+func (p *oidcProvider) verify(
+	ctx context.Context,
+	discovery oidcDiscovery,
+	idToken string,
+	nonce string,
+) (oidcIDTokenClaims, error) {
+	var claims oidcIDTokenClaims
+
+	parser := jwt.Parser{
+		ValidMethods: []string{
+			jwt.SigningMethodRS256.Alg(),
+			jwt.SigningMethodRS384.Alg(),
+			jwt.SigningMethodRS512.Alg(),
+			jwt.SigningMethodES256.Alg(),
+			jwt.SigningMethodES384.Alg(),
+			jwt.SigningMethodES512.Alg(),
+		},
+		SkipClaimsValidation: true,
+	}
+
+	_, err := parser.ParseWithClaims(idToken, &claims, func(t *jwt.Token) (interface{}, error) {
+		keyID, _ := t.Header["kid"].(string)
+
+		return p.key(ctx, discovery, keyID)
+	})
+	if err != nil {
+		return oidcIDTokenClaims{}, fmt.Errorf("%w: %w", internal.ErrOIDCAuthenticationFailed, err)
+	}
+
+	now := p.timer.Now()
+
+	switch {
+	case claims.Issuer != discovery.Issuer:
+		return oidcIDTokenClaims{}, fmt.Errorf("%w: unexpected issuer %s", internal.ErrOIDCAuthenticationFailed, claims.Issuer)
+	case !claims.Audience.Contains(p.clientID):
+		return oidcIDTokenClaims{}, fmt.Errorf("%w: unexpected audience", internal.ErrOIDCAuthenticationFailed)
+	case len(claims.Audience) > 1 && claims.AuthorizedParty != p.clientID:
+		return oidcIDTokenClaims{}, fmt.Errorf("%w: unexpected authorized party", internal.ErrOIDCAuthenticationFailed)
+	case !now.Before(time.Unix(claims.ExpiresAt, 0).Add(p.leeway)):
+		return oidcIDTokenClaims{}, fmt.Errorf("%w: id token is expired", internal.ErrOIDCAuthenticationFailed)
+	case now.Add(p.leeway).Before(time.Unix(claims.IssuedAt, 0)):
+		return oidcIDTokenClaims{}, fmt.Errorf("%w: id token is issued in the future", internal.ErrOIDCAuthenticationFailed)
+	case claims.Nonce != nonce:
+		return oidcIDTokenClaims{}, fmt.Errorf("%w: unexpected nonce", internal.ErrOIDCAuthenticationFailed)
+	case len(claims.Subject) == 0:
+		return oidcIDTokenClaims{}, fmt.Errorf("%w: id token has no subject", internal.ErrOIDCAuthenticationFailed)
+	}
+
+	return claims, nil
+}
+
+func (p *oidcProvider) discover(ctx context.Context) (oidcDiscovery, error) {
+	p.mu.Lock()
+	discovery := p.discovery
+	p.mu.Unlock()
+
+	if discovery != nil {
+		return *discovery, nil
+	}
+
+	var fetched oidcDiscovery
+
+	err := p.get(ctx, strings.TrimSuffix(p.issuer, "/")+"/.well-known/openid-configuration", &fetched)
+	if err != nil {
+		return oidcDiscovery{}, fmt.Errorf("getting openid configuration: %w", err)
+	}
+
+	if fetched.Issuer != p.issuer {
+		return oidcDiscovery{}, fmt.Errorf(
+			"%w: openid configuration issuer %s does not match %s",
+			internal.ErrUpstreamFailed,
+			fetched.Issuer,
+			p.issuer,
+		)
+	}
+
+	p.mu.Lock()
+	p.discovery = &fetched
+	p.mu.Unlock()
+
+	return fetched, nil
+}
+
+func (p *oidcProvider) key(ctx context.Context, discovery oidcDiscovery, keyID string) (interface{}, error) {
+	p.mu.Lock()
+	key, ok := p.keys[keyID]
+	p.mu.Unlock()
+
+	if ok {
+		return key, nil
+	}
+
+	var jwks struct {
+		Keys []oidcJWK `json:"keys"`
+	}
+
+	err := p.get(ctx, discovery.JWKSURI, &jwks)
+	if err != nil {
+		return nil, fmt.Errorf("getting jwks: %w", err)
+	}
+
+	keys := map[string]interface{}{}
+
+	for _, each := range jwks.Keys {
+		if len(each.Use) > 0 && each.Use != "sig" {
+			continue
+		}
+
+		publicKey, err := parseOIDCJWK(each)
+		if err != nil {
+			continue
+		}
+
+		keys[each.KeyID] = publicKey
+	}
+
+	p.mu.Lock()
+	p.keys = keys
+	p.mu.Unlock()
+
+	key, ok = keys[keyID]
+	if !ok {
+		return nil, fmt.Errorf("unknown id token key %q", keyID)
+	}
+
+	return key, nil
+}
+
+func (p *oidcProvider) get(ctx context.Context, url string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("initializing new request: %w", err)
+	}
+
+	req.Header.Set("Accept", "application/json")
+
+	res, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("doing http request: %w", evaluateDoError(err))
+	}
+
+	defer res.Body.Close()
+
+	b, err := io.ReadAll(res.Body)
+	if err != nil {
+		return fmt.Errorf("reading http response body: %w", err)
+	}
+
+	if res.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("%w: http request returns %d:%s", internal.ErrUpstreamFailed, res.StatusCode, string(b))
+	}
+
+	err = json.Unmarshal(b, v)
+	if err != nil {
+		return fmt.Errorf("unmarshalling body response from json: %w", err)
+	}
+
+	return nil
+}
+
+func parseOIDCJWK(jwk oidcJWK) (interface{}, error) {
+	switch jwk.KeyType {
+	case "RSA":
+		n, err := jwt.DecodeSegment(jwk.N)
+		if err != nil {
+			return nil, fmt.Errorf("decoding rsa modulus: %w", err)
+		}
+
+		e, err := jwt.DecodeSegment(jwk.E)
+		if err != nil {
+			return nil, fmt.Errorf("decoding rsa exponent: %w", err)
+		}
+
+		return &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}, nil
+	case "EC":
+		var curve elliptic.Curve
+
+		switch jwk.Curve {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported ec curve %s", jwk.Curve)
+		}
+
+		x, err := jwt.DecodeSegment(jwk.X)
+		if err != nil {
+			return nil, fmt.Errorf("decoding ec x coordinate: %w", err)
+		}
+
+		y, err := jwt.DecodeSegment(jwk.Y)
+		if err != nil {
+			return nil, fmt.Errorf("decoding ec y coordinate: %w", err)
+		}
+
+		return &ecdsa.PublicKey{
+			Curve: curve,
+			X:     new(big.Int).SetBytes(x),
+			Y:     new(big.Int).SetBytes(y),
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported jwk key type %s", jwk.KeyType)
+	}
+}
+
+func NewOIDCProvider(
+	client Doer,
+	timer internal.Timer,
+	issuer string,
+	clientID string,
+	clientSecret string,
+	redirectURL string,
+	scopes []string,
+	leeway time.Duration,
+) *oidcProvider {
+	return &oidcProvider{
+		client:       client,
+		timer:        timer,
+		issuer:       issuer,
+		clientID:     clientID,
+		clientSecret: clientSecret,
+		redirectURL:  redirectURL,
+		scopes:       scopes,
+		leeway:       leeway,
+	}
+}
@@ -0,0 +1,207 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/adystag/jobs-search/internal"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type apiKeyRepository struct {
+	db *sqlx.DB
+}
+
+func (r apiKeyRepository) ListAPIKeysByUserID(ctx context.Context, userID int64) ([]internal.APIKey, error) {
+	query := `
+		SELECT
+			id,
+			user_id,
+			name,
+			prefix,
+			key_hash,
+			scopes,
+			expires_at,
+			last_used_at,
+			revoked_at,
+			created_at
+		FROM api_keys
+		WHERE user_id = ? AND revoked_at IS NULL
+		ORDER BY id DESC
+	`
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("querying mysql api_keys table: %w", err)
+	}
+
+	defer rows.Close()
+
+	var apiKeys []internal.APIKey
+
+	for rows.Next() {
+		apiKey, err := r.scan(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scanning mysql api_keys row: %w", err)
+		}
+
+		apiKeys = append(apiKeys, apiKey)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("iterating mysql api_keys rows: %w", err)
+	}
+
+	return apiKeys, nil
+}
+
+func (r apiKeyRepository) GetAPIKeyByPrefix(ctx context.Context, prefix string) (internal.APIKey, error) {
+	query := `
+		SELECT
+			id,
+			user_id,
+			name,
+			prefix,
+			key_hash,
+			scopes,
+			expires_at,
+			last_used_at,
+			revoked_at,
+			created_at
+		FROM api_keys
+		WHERE prefix = ?
+		LIMIT 1
+	`
+	apiKey, err := r.scan(r.db.QueryRowContext(ctx, query, prefix))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = fmt.Errorf("%w: %w", internal.ErrAPIKeyNotFound, err)
+		}
+
+		return internal.APIKey{}, fmt.Errorf("querying mysql api_keys table: %w", err)
+	}
+
+	return apiKey, nil
+}
+
+func (r apiKeyRepository) StoreAPIKey(ctx context.Context, apiKey *internal.APIKey) error {
+	scopes := apiKey.Scopes
+
+	if scopes == nil {
+		scopes = []internal.APIKeyScope{}
+	}
+
+	encodedScopes, err := json.Marshal(scopes)
+	if err != nil {
+		return fmt.Errorf("marshalling api key scopes to json: %w", err)
+	}
+
+	query := `
+		INSERT INTO api_keys (user_id, name, prefix, key_hash, scopes, expires_at, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`
+	res, err := r.db.ExecContext(
+		ctx,
+		query,
+		apiKey.UserID,
+		apiKey.Name,
+		apiKey.Prefix,
+		apiKey.Hash,
+		encodedScopes,
+		sql.NullTime{Time: apiKey.ExpiresAt, Valid: !apiKey.ExpiresAt.IsZero()},
+		apiKey.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("executing mysql query: %w", err)
+	}
+
+	lastInsertedID, err := res.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("getting last inserted id: %w", err)
+	}
+
+	apiKey.ID = lastInsertedID
+
+	return nil
+}
+
+func (r apiKeyRepository) StoreAPIKeyRevocation(ctx context.Context, userID, apiKeyID int64, revokedAt time.Time) error {
+	query := `
+		UPDATE api_keys
+		SET revoked_at = ?
+		WHERE id = ? AND user_id = ? AND revoked_at IS NULL
+	`
+	res, err := r.db.ExecContext(ctx, query, revokedAt, apiKeyID, userID)
+	if err != nil {
+		return fmt.Errorf("executing mysql query: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("getting affected rows: %w", err)
+	}
+
+	if affected == 0 {
+		return internal.ErrAPIKeyNotFound
+	}
+
+	return nil
+}
+
+func (r apiKeyRepository) StoreAPIKeyUsage(ctx context.Context, apiKeyID int64, usedAt time.Time) error {
+	query := `
+		UPDATE api_keys
+		SET last_used_at = ?
+		WHERE id = ?
+	`
+	_, err := r.db.ExecContext(ctx, query, usedAt, apiKeyID)
+	if err != nil {
+		return fmt.Errorf("executing mysql query: %w", err)
+	}
+
+	return nil
+}
+
+func (r apiKeyRepository) scan(row rowScanner) (internal.APIKey, error) {
+	var apiKey internal.APIKey
+	var scopes []byte
+	var expiresAt, lastUsedAt, revokedAt sql.NullTime
+
+	err := row.Scan(
+		&apiKey.ID,
+		&apiKey.UserID,
+		&apiKey.Name,
+		&apiKey.Prefix,
+		&apiKey.Hash,
+		&scopes,
+		&expiresAt,
+		&lastUsedAt,
+		&revokedAt,
+		&apiKey.CreatedAt,
+	)
+	if err != nil {
+		return internal.APIKey{}, err
+	}
+
+	err = json.Unmarshal(scopes, &apiKey.Scopes)
+	if err != nil {
+		return internal.APIKey{}, fmt.Errorf("unmarshalling api key scopes from json: %w", err)
+	}
+
+	apiKey.ExpiresAt = expiresAt.Time
+	apiKey.LastUsedAt = lastUsedAt.Time
+	apiKey.RevokedAt = revokedAt.Time
+
+	return apiKey, nil
+}
+
+func NewAPIKeyRepository(db *sqlx.DB) *apiKeyRepository {
+	return &apiKeyRepository{
+		db: db,
+	}
+}
@@ -0,0 +1,360 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/adystag/jobs-search/internal"
+
+	"github.com/jmoiron/sqlx"
+
+	gomysql "github.com/go-sql-driver/mysql"
+)
+
+const mysqlErrDuplicateEntry = 1062
+
+type applicationRepository struct {
+	db *sqlx.DB
+}
+
+func (r applicationRepository) ListApplicationsByUserID(ctx context.Context, userID int64) ([]internal.Application, error) {
+	query := `
+		SELECT
+			id,
+			user_id,
+			job_id,
+			status,
+			notes,
+			created_at,
+			updated_at
+		FROM applications
+		WHERE user_id = ?
+		ORDER BY updated_at DESC, id DESC
+	`
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("querying mysql applications table: %w", err)
+	}
+
+	defer rows.Close()
+
+	var applications []internal.Application
+
+	for rows.Next() {
+		application, err := r.scan(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scanning mysql applications row: %w", err)
+		}
+
+		applications = append(applications, application)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("iterating mysql applications rows: %w", err)
+	}
+
+	histories, err := r.listStatusChanges(ctx, `
+		SELECT
+			c.application_id,
+			c.id,
+			c.from_status,
+			c.to_status,
+			c.note,
+			c.changed_at
+		FROM application_status_changes c
+		INNER JOIN applications a ON a.id = c.application_id
+		WHERE a.user_id = ?
+		ORDER BY c.id
+	`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("listing application status changes: %w", err)
+	}
+
+	for index := range applications {
+		applications[index].History = histories[applications[index].ID]
+	}
+
+	return applications, nil
+}
+
+func (r applicationRepository) GetApplicationByID(ctx context.Context, userID, applicationID int64) (internal.Application, error) {
+	query := `
+		SELECT
+			id,
+			user_id,
+			job_id,
+			status,
+			notes,
+			created_at,
+			updated_at
+		FROM applications
+		WHERE id = ? AND user_id = ?
+		LIMIT 1
+	`
+	application, err := r.scan(r.db.QueryRowContext(ctx, query, applicationID, userID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = fmt.Errorf("%w: %w", internal.ErrApplicationNotFound, err)
+		}
+
+		return internal.Application{}, fmt.Errorf("querying mysql applications table: %w", err)
+	}
+
+	histories, err := r.listStatusChanges(ctx, `
+		SELECT
+			application_id,
+			id,
+			from_status,
+			to_status,
+			note,
+			changed_at
+		FROM application_status_changes
+		WHERE application_id = ?
+		ORDER BY id
+	`, application.ID)
+	if err != nil {
+		return internal.Application{}, fmt.Errorf("listing application status changes: %w", err)
+	}
+
+	application.History = histories[application.ID]
+
+	return application, nil
+}
+
+func (r applicationRepository) StoreApplication(ctx context.Context, application *internal.Application) (err error) {
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("initializing mysql db transaction: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	if application.ID > 0 {
+		expectedStatus := application.Status
+
+		for _, each := range application.History {
+			if each.ID <= 0 {
+				expectedStatus = each.From
+
+				break
+			}
+		}
+
+		var status string
+
+		query := `
+			SELECT status
+			FROM applications
+			WHERE id = ? AND user_id = ?
+			FOR UPDATE
+		`
+		err = tx.QueryRowContext(ctx, query, application.ID, application.UserID).Scan(&status)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				err = fmt.Errorf("%w: %w", internal.ErrApplicationNotFound, err)
+			}
+
+			return fmt.Errorf("querying mysql applications table: %w", err)
+		}
+
+		if internal.ApplicationStatus(status) != expectedStatus {
+			return internal.ErrApplicationStatusConflict
+		}
+	}
+
+	{
+		query := `
+			INSERT INTO applications (user_id, job_id, status, notes, created_at, updated_at)
+			VALUES (?, ?, ?, ?, ?, ?)
+		`
+		args := []interface{}{
+			application.UserID,
+			application.JobID.String(),
+			string(application.Status),
+			application.Notes,
+			application.CreatedAt,
+			application.UpdatedAt,
+		}
+
+		if application.ID > 0 {
+			query = `
+				UPDATE applications
+				SET
+					status = ?,
+					notes = ?,
+					updated_at = ?
+				WHERE id = ? AND user_id = ?
+			`
+			args = []interface{}{
+				string(application.Status),
+				application.Notes,
+				application.UpdatedAt,
+				application.ID,
+				application.UserID,
+			}
+		}
+
+		res, err := tx.ExecContext(ctx, query, args...)
+		if err != nil {
+			var mysqlErr *gomysql.MySQLError
+
+			if errors.As(err, &mysqlErr) && mysqlErr.Number == mysqlErrDuplicateEntry {
+				return internal.NewValidationError("job_id", "unique")
+			}
+
+			return fmt.Errorf("executing mysql query: %w", err)
+		}
+
+		if application.ID <= 0 {
+			lastInsertedID, err := res.LastInsertId()
+			if err != nil {
+				return fmt.Errorf("getting last inserted id: %w", err)
+			}
+
+			application.ID = lastInsertedID
+		}
+	}
+
+	{
+		query := `
+			INSERT INTO application_status_changes (application_id, from_status, to_status, note, changed_at)
+			VALUES (?, ?, ?, ?, ?)
+		`
+
+		stmt, err := tx.PrepareContext(ctx, query)
+		if err != nil {
+			return fmt.Errorf("preparing mysql query: %w", err)
+		}
+
+		defer stmt.Close()
+
+		for index := range application.History {
+			change := &application.History[index]
+
+			if change.ID > 0 {
+				continue
+			}
+
+			res, err := stmt.ExecContext(
+				ctx,
+				application.ID,
+				string(change.From),
+				string(change.To),
+				change.Note,
+				change.ChangedAt,
+			)
+			if err != nil {
+				return fmt.Errorf("executing mysql query: %w", err)
+			}
+
+			lastInsertedID, err := res.LastInsertId()
+			if err != nil {
+				return fmt.Errorf("getting last inserted id: %w", err)
+			}
+
+			change.ID = lastInsertedID
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("committing mysql db transaction: %w", err)
+	}
+
+	return nil
+}
+
+func (r applicationRepository) DeleteApplication(ctx context.Context, userID, applicationID int64) error {
+	query := `
+		DELETE FROM applications
+		WHERE id = ? AND user_id = ?
+	`
+	res, err := r.db.ExecContext(ctx, query, applicationID, userID)
+	if err != nil {
+		return fmt.Errorf("executing mysql query: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("getting affected rows: %w", err)
+	}
+
+	if affected == 0 {
+		return internal.ErrApplicationNotFound
+	}
+
+	return nil
+}
+
+func (r applicationRepository) listStatusChanges(
+	ctx context.Context,
+	query string,
+	args ...interface{},
+) (map[int64][]internal.ApplicationStatusChange, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("querying mysql application_status_changes table: %w", err)
+	}
+
+	defer rows.Close()
+
+	histories := map[int64][]internal.ApplicationStatusChange{}
+
+	for rows.Next() {
+		var applicationID int64
+		var change internal.ApplicationStatusChange
+
+		err = rows.Scan(
+			&applicationID,
+			&change.ID,
+			&change.From,
+			&change.To,
+			&change.Note,
+			&change.ChangedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("scanning mysql application_status_changes row: %w", err)
+		}
+
+		histories[applicationID] = append(histories[applicationID], change)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("iterating mysql application_status_changes rows: %w", err)
+	}
+
+	return histories, nil
+}
+
+func (r applicationRepository) scan(row rowScanner) (internal.Application, error) {
+	var application internal.Application
+
+	err := row.Scan(
+		&application.ID,
+		&application.UserID,
+		&application.JobID,
+		&application.Status,
+		&application.Notes,
+		&application.CreatedAt,
+		&application.UpdatedAt,
+	)
+	if err != nil {
+		return internal.Application{}, err
+	}
+
+	return application, nil
+}
+
+func NewApplicationRepository(db *sqlx.DB) *applicationRepository {
+	return &applicationRepository{
+		db: db,
+	}
+}
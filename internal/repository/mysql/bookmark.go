@@ -0,0 +1,219 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/adystag/jobs-search/internal"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+type bookmarkSnapshot struct {
+	ID          uuid.UUID `json:"id"`
+	Company     string    `json:"company"`
+	CompanyURL  string    `json:"company_url"`
+	CompanyLogo string    `json:"company_logo"`
+	URL         string    `json:"url"`
+	Type        string    `json:"type"`
+	Location    string    `json:"location"`
+	Title       string    `json:"title"`
+	Description string    `json:"description"`
+	HowToApply  string    `json:"how_to_apply"`
+	CreatedAt   string    `json:"created_at"`
+}
+
+type bookmarkRepository struct {
+	db *sqlx.DB
+}
+
+func (r bookmarkRepository) ListBookmarksByUserID(ctx context.Context, userID int64) ([]internal.Bookmark, error) {
+	query := `
+		SELECT
+			id,
+			user_id,
+			job_id,
+			note,
+			tags,
+			snapshot,
+			created_at,
+			updated_at
+		FROM bookmarks
+		WHERE user_id = ?
+		ORDER BY id DESC
+	`
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("querying mysql bookmarks table: %w", err)
+	}
+
+	defer rows.Close()
+
+	var bookmarks []internal.Bookmark
+
+	for rows.Next() {
+		var bookmark internal.Bookmark
+		var tags, snapshot []byte
+
+		err = rows.Scan(
+			&bookmark.ID,
+			&bookmark.UserID,
+			&bookmark.JobID,
+			&bookmark.Note,
+			&tags,
+			&snapshot,
+			&bookmark.CreatedAt,
+			&bookmark.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("scanning mysql bookmarks row: %w", err)
+		}
+
+		err = json.Unmarshal(tags, &bookmark.Tags)
+		if err != nil {
+			return nil, fmt.Errorf("unmarshalling bookmark tags from json: %w", err)
+		}
+
+		var s bookmarkSnapshot
+
+		err = json.Unmarshal(snapshot, &s)
+		if err != nil {
+			return nil, fmt.Errorf("unmarshalling bookmark snapshot from json: %w", err)
+		}
+
+		bookmark.Job = internal.Job(s)
+
+		bookmarks = append(bookmarks, bookmark)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("iterating mysql bookmarks rows: %w", err)
+	}
+
+	return bookmarks, nil
+}
+
+func (r bookmarkRepository) StoreBookmark(ctx context.Context, bookmark *internal.Bookmark) (err error) {
+	tags := bookmark.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+
+	b, err := json.Marshal(tags)
+	if err != nil {
+		return fmt.Errorf("marshalling bookmark tags to json: %w", err)
+	}
+
+	snapshot, err := json.Marshal(bookmarkSnapshot(bookmark.Job))
+	if err != nil {
+		return fmt.Errorf("marshalling bookmark snapshot to json: %w", err)
+	}
+
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("initializing mysql db transaction: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	{
+		query := `
+			INSERT INTO bookmarks (user_id, job_id, note, tags, snapshot, created_at, updated_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?)
+			ON DUPLICATE KEY UPDATE
+				id = LAST_INSERT_ID(id),
+				note = VALUES(note),
+				tags = VALUES(tags),
+				snapshot = VALUES(snapshot),
+				updated_at = VALUES(updated_at)
+		`
+
+		stmt, err := tx.PrepareContext(ctx, query)
+		if err != nil {
+			return fmt.Errorf("preparing mysql query: %w", err)
+		}
+
+		defer stmt.Close()
+
+		res, err := stmt.ExecContext(
+			ctx,
+			bookmark.UserID,
+			bookmark.JobID.String(),
+			bookmark.Note,
+			b,
+			snapshot,
+			bookmark.CreatedAt,
+			bookmark.UpdatedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("executing mysql query: %w", err)
+		}
+
+		lastInsertedID, err := res.LastInsertId()
+		if err != nil {
+			return fmt.Errorf("getting last inserted id: %w", err)
+		}
+
+		bookmark.ID = lastInsertedID
+	}
+
+	{
+		query := `
+			SELECT created_at, updated_at
+			FROM bookmarks
+			WHERE id = ?
+		`
+		err = tx.QueryRowContext(ctx, query, bookmark.ID).Scan(&bookmark.CreatedAt, &bookmark.UpdatedAt)
+		if err != nil {
+			return fmt.Errorf("querying mysql bookmarks table: %w", err)
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("committing mysql db transaction: %w", err)
+	}
+
+	return nil
+}
+
+func (r bookmarkRepository) DeleteBookmark(ctx context.Context, userID int64, jobID string) error {
+	id, err := uuid.Parse(jobID)
+	if err != nil {
+		return internal.NewValidationError("job_id", "uuid")
+	}
+
+	query := `
+		DELETE FROM bookmarks
+		WHERE user_id = ? AND job_id = ?
+	`
+	res, err := r.db.ExecContext(ctx, query, userID, id.String())
+	if err != nil {
+		return fmt.Errorf("executing mysql query: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("getting affected rows: %w", err)
+	}
+
+	if affected == 0 {
+		return internal.ErrBookmarkNotFound
+	}
+
+	return nil
+}
+
+func NewBookmarkRepository(db *sqlx.DB) *bookmarkRepository {
+	return &bookmarkRepository{
+		db: db,
+	}
+}
@@ -0,0 +1,123 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/adystag/jobs-search/internal"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type emailVerificationTokenRepository struct {
+	db *sqlx.DB
+}
+
+func (r emailVerificationTokenRepository) GetEmailVerificationTokenByHash(
+	ctx context.Context,
+	hash string,
+) (internal.EmailVerificationToken, error) {
+	var emailVerificationToken internal.EmailVerificationToken
+	var usedAt sql.NullTime
+
+	query := `
+		SELECT
+			id,
+			user_id,
+			email,
+			token_hash,
+			expires_at,
+			used_at,
+			created_at
+		FROM email_verification_tokens
+		WHERE token_hash = ?
+		LIMIT 1
+	`
+	err := r.db.QueryRowContext(ctx, query, hash).Scan(
+		&emailVerificationToken.ID,
+		&emailVerificationToken.UserID,
+		&emailVerificationToken.Email,
+		&emailVerificationToken.Hash,
+		&emailVerificationToken.ExpiresAt,
+		&usedAt,
+		&emailVerificationToken.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = fmt.Errorf("%w: %w", internal.ErrEmailVerificationTokenNotFound, err)
+		}
+
+		return internal.EmailVerificationToken{}, fmt.Errorf("querying mysql email_verification_tokens table: %w", err)
+	}
+
+	emailVerificationToken.UsedAt = usedAt.Time
+
+	return emailVerificationToken, nil
+}
+
+func (r emailVerificationTokenRepository) StoreEmailVerificationToken(
+	ctx context.Context,
+	emailVerificationToken *internal.EmailVerificationToken,
+) error {
+	query := `
+		INSERT INTO email_verification_tokens (user_id, email, token_hash, expires_at, created_at)
+		VALUES (?, ?, ?, ?, ?)
+	`
+	res, err := r.db.ExecContext(
+		ctx,
+		query,
+		emailVerificationToken.UserID,
+		emailVerificationToken.Email,
+		emailVerificationToken.Hash,
+		emailVerificationToken.ExpiresAt,
+		emailVerificationToken.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("executing mysql query: %w", err)
+	}
+
+	lastInsertedID, err := res.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("getting last inserted id: %w", err)
+	}
+
+	emailVerificationToken.ID = lastInsertedID
+
+	return nil
+}
+
+func (r emailVerificationTokenRepository) ConsumeEmailVerificationToken(
+	ctx context.Context,
+	emailVerificationTokenID int64,
+	usedAt time.Time,
+) error {
+	query := `
+		UPDATE email_verification_tokens
+		SET used_at = ?
+		WHERE id = ? AND used_at IS NULL
+	`
+	res, err := r.db.ExecContext(ctx, query, usedAt, emailVerificationTokenID)
+	if err != nil {
+		return fmt.Errorf("executing mysql query: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("getting affected rows: %w", err)
+	}
+
+	if affected == 0 {
+		return internal.ErrEmailVerificationTokenInvalid
+	}
+
+	return nil
+}
+
+func NewEmailVerificationTokenRepository(db *sqlx.DB) *emailVerificationTokenRepository {
+	return &emailVerificationTokenRepository{
+		db: db,
+	}
+}
@@ -0,0 +1,306 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/adystag/jobs-search/internal"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+type jobRepository struct {
+	db      *sqlx.DB
+	timer   internal.Timer
+	perPage int
+}
+
+func (r jobRepository) ListJobs(
+	ctx context.Context,
+	opts ...internal.Option[internal.JobsListerOption],
+) (internal.JobsListResult, error) {
+	opt := internal.JobsListerOption{}
+
+	internal.ApplyOptions(&opt, opts...)
+
+	query := `
+		SELECT
+			id,
+			company,
+			company_url,
+			company_logo,
+			url,
+			type,
+			location,
+			title,
+			description,
+			how_to_apply,
+			created_at,
+			posted_at
+		FROM jobs
+		WHERE 1 = 1
+	`
+	args := []interface{}{}
+
+	if len(opt.Description) > 0 {
+		query += ` AND (title LIKE ? OR description LIKE ? OR company LIKE ?)`
+		pattern := likePattern(opt.Description)
+		args = append(args, pattern, pattern, pattern)
+	}
+
+	if len(opt.Location) > 0 {
+		query += ` AND location LIKE ?`
+		args = append(args, likePattern(opt.Location))
+	}
+
+	if opt.FullTime {
+		query += ` AND type = ?`
+		args = append(args, internal.JobTypeFullTime)
+	}
+
+	switch {
+	case opt.Cursor.IsZero():
+	case opt.Cursor.PostedAt.IsZero():
+		query += ` AND posted_at IS NULL AND id < ?`
+		args = append(args, opt.Cursor.ID.String())
+	default:
+		query += ` AND (posted_at < ? OR (posted_at = ? AND id < ?) OR posted_at IS NULL)`
+		args = append(args, opt.Cursor.PostedAt, opt.Cursor.PostedAt, opt.Cursor.ID.String())
+	}
+
+	query += ` ORDER BY posted_at DESC, id DESC LIMIT ?`
+	args = append(args, r.perPage+1)
+
+	paged := opt.Cursor.IsZero() && opt.Page > 0
+
+	if paged {
+		query += ` OFFSET ?`
+		args = append(args, (opt.Page-1)*r.perPage)
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return internal.JobsListResult{}, fmt.Errorf("querying mysql jobs table: %w", err)
+	}
+
+	defer rows.Close()
+
+	var jobs []internal.Job
+	var postedAts []sql.NullTime
+
+	for rows.Next() {
+		var job internal.Job
+		var postedAt sql.NullTime
+
+		err = rows.Scan(
+			&job.ID,
+			&job.Company,
+			&job.CompanyURL,
+			&job.CompanyLogo,
+			&job.URL,
+			&job.Type,
+			&job.Location,
+			&job.Title,
+			&job.Description,
+			&job.HowToApply,
+			&job.CreatedAt,
+			&postedAt,
+		)
+		if err != nil {
+			return internal.JobsListResult{}, fmt.Errorf("scanning mysql jobs row: %w", err)
+		}
+
+		jobs = append(jobs, job)
+		postedAts = append(postedAts, postedAt)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return internal.JobsListResult{}, fmt.Errorf("iterating mysql jobs rows: %w", err)
+	}
+
+	jobsListResult := internal.JobsListResult{
+		Jobs:    jobs,
+		Page:    opt.Page,
+		PerPage: r.perPage,
+	}
+
+	if len(jobs) > r.perPage {
+		jobsListResult.Jobs = jobs[:r.perPage]
+		jobsListResult.HasNext = true
+
+		if !paged {
+			jobsListResult.NextCursor = internal.JobsCursor{
+				PostedAt: postedAts[r.perPage-1].Time,
+				ID:       jobs[r.perPage-1].ID,
+			}
+		}
+	}
+
+	return jobsListResult, nil
+}
+
+func (r jobRepository) GetJobByID(ctx context.Context, jobID string) (internal.Job, error) {
+	id, err := uuid.Parse(jobID)
+	if err != nil {
+		return internal.Job{}, internal.NewValidationError("job_id", "uuid")
+	}
+
+	var job internal.Job
+
+	query := `
+		SELECT
+			id,
+			company,
+			company_url,
+			company_logo,
+			url,
+			type,
+			location,
+			title,
+			description,
+			how_to_apply,
+			created_at
+		FROM jobs
+		WHERE id = ?
+		LIMIT 1
+	`
+	err = r.db.QueryRowContext(ctx, query, id.String()).Scan(
+		&job.ID,
+		&job.Company,
+		&job.CompanyURL,
+		&job.CompanyLogo,
+		&job.URL,
+		&job.Type,
+		&job.Location,
+		&job.Title,
+		&job.Description,
+		&job.HowToApply,
+		&job.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = fmt.Errorf("%w: %w", internal.ErrJobNotFound, err)
+		}
+
+		return internal.Job{}, fmt.Errorf("querying mysql jobs table: %w", err)
+	}
+
+	return job, nil
+}
+
+func (r jobRepository) StoreJobs(ctx context.Context, jobs []internal.Job) (err error) {
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("initializing mysql db transaction: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	{
+		query := `
+			INSERT INTO jobs (
+				id,
+				company,
+				company_url,
+				company_logo,
+				url,
+				type,
+				location,
+				title,
+				description,
+				how_to_apply,
+				created_at,
+				posted_at,
+				synced_at
+			)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			ON DUPLICATE KEY UPDATE
+				company = VALUES(company),
+				company_url = VALUES(company_url),
+				company_logo = VALUES(company_logo),
+				url = VALUES(url),
+				type = VALUES(type),
+				location = VALUES(location),
+				title = VALUES(title),
+				description = VALUES(description),
+				how_to_apply = VALUES(how_to_apply),
+				created_at = VALUES(created_at),
+				posted_at = VALUES(posted_at),
+				synced_at = VALUES(synced_at)
+		`
+
+		stmt, err := tx.PrepareContext(ctx, query)
+		if err != nil {
+			return fmt.Errorf("preparing mysql query: %w", err)
+		}
+
+		defer stmt.Close()
+
+		now := r.timer.Now()
+
+		for _, each := range jobs {
+			_, err = stmt.ExecContext(
+				ctx,
+				each.ID.String(),
+				each.Company,
+				each.CompanyURL,
+				each.CompanyLogo,
+				each.URL,
+				each.Type,
+				each.Location,
+				each.Title,
+				each.Description,
+				each.HowToApply,
+				each.CreatedAt,
+				parsePostedAt(each.CreatedAt),
+				now,
+			)
+			if err != nil {
+				return fmt.Errorf("executing mysql query for job %s: %w", each.ID, err)
+			}
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("committing mysql db transaction: %w", err)
+	}
+
+	return nil
+}
+
+func NewJobRepository(db *sqlx.DB, timer internal.Timer, perPage int) *jobRepository {
+	return &jobRepository{
+		db:      db,
+		timer:   timer,
+		perPage: perPage,
+	}
+}
+
+func likePattern(s string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+	return "%" + replacer.Replace(s) + "%"
+}
+
+func parsePostedAt(createdAt string) sql.NullTime {
+	postedAt, err := time.Parse(time.UnixDate, createdAt)
+	if err != nil {
+		return sql.NullTime{}
+	}
+
+	return sql.NullTime{
+		Time:  postedAt.UTC(),
+		Valid: true,
+	}
+}
@@ -0,0 +1,165 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/adystag/jobs-search/internal"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type loginThrottleRepository struct {
+	db *sqlx.DB
+}
+
+func (r loginThrottleRepository) GetLoginThrottle(
+	ctx context.Context,
+	key internal.LoginThrottleKey,
+) (internal.LoginThrottle, error) {
+	throttle, err := r.get(ctx, r.db, key)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return internal.LoginThrottle{Key: key}, nil
+		}
+
+		return internal.LoginThrottle{}, fmt.Errorf("querying mysql login_throttles table: %w", err)
+	}
+
+	return throttle, nil
+}
+
+func (r loginThrottleRepository) RecordLoginFailure(
+	ctx context.Context,
+	key internal.LoginThrottleKey,
+	failedAt time.Time,
+	windowStart time.Time,
+) (_ internal.LoginThrottle, err error) {
+	tx, err := r.db.BeginTxx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return internal.LoginThrottle{}, fmt.Errorf("initializing mysql db transaction: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	query := `
+		INSERT INTO login_throttles (scope, throttle_key, failures, last_failed_at)
+		VALUES (?, ?, 1, ?)
+		ON DUPLICATE KEY UPDATE
+			failures = IF(last_failed_at < ?, 1, failures + 1),
+			last_failed_at = VALUES(last_failed_at)
+	`
+	_, err = tx.ExecContext(ctx, query, string(key.Scope), key.Value, failedAt, windowStart)
+	if err != nil {
+		return internal.LoginThrottle{}, fmt.Errorf("executing mysql query: %w", err)
+	}
+
+	throttle, err := r.get(ctx, tx, key)
+	if err != nil {
+		return internal.LoginThrottle{}, fmt.Errorf("querying mysql login_throttles table: %w", err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return internal.LoginThrottle{}, fmt.Errorf("committing mysql db transaction: %w", err)
+	}
+
+	return throttle, nil
+}
+
+func (r loginThrottleRepository) LockLogin(
+	ctx context.Context,
+	key internal.LoginThrottleKey,
+	lockedUntil time.Time,
+) error {
+	query := `
+		UPDATE login_throttles
+		SET locked_until = ?
+		WHERE scope = ? AND throttle_key = ?
+	`
+	_, err := r.db.ExecContext(ctx, query, lockedUntil, string(key.Scope), key.Value)
+	if err != nil {
+		return fmt.Errorf("executing mysql query: %w", err)
+	}
+
+	return nil
+}
+
+func (r loginThrottleRepository) ResetLoginThrottle(ctx context.Context, key internal.LoginThrottleKey) error {
+	query := `
+		DELETE FROM login_throttles
+		WHERE scope = ? AND throttle_key = ?
+	`
+	_, err := r.db.ExecContext(ctx, query, string(key.Scope), key.Value)
+	if err != nil {
+		return fmt.Errorf("executing mysql query: %w", err)
+	}
+
+	return nil
+}
+
+func (r loginThrottleRepository) AuditLoginAttempt(ctx context.Context, attempt internal.LoginAttempt) error {
+	query := `
+		INSERT INTO login_attempts (username, ip, succeeded, reason, attempted_at)
+		VALUES (?, ?, ?, ?, ?)
+	`
+	_, err := r.db.ExecContext(
+		ctx,
+		query,
+		attempt.Username,
+		attempt.IP,
+		attempt.Succeeded,
+		attempt.Reason,
+		attempt.AttemptedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("executing mysql query: %w", err)
+	}
+
+	return nil
+}
+
+func (r loginThrottleRepository) get(
+	ctx context.Context,
+	queryer sqlx.QueryerContext,
+	key internal.LoginThrottleKey,
+) (internal.LoginThrottle, error) {
+	throttle := internal.LoginThrottle{Key: key}
+
+	var lockedUntil sql.NullTime
+
+	query := `
+		SELECT
+			failures,
+			last_failed_at,
+			locked_until
+		FROM login_throttles
+		WHERE scope = ? AND throttle_key = ?
+		LIMIT 1
+	`
+	err := queryer.QueryRowxContext(ctx, query, string(key.Scope), key.Value).Scan(
+		&throttle.Failures,
+		&throttle.LastFailedAt,
+		&lockedUntil,
+	)
+	if err != nil {
+		return internal.LoginThrottle{}, err
+	}
+
+	throttle.LockedUntil = lockedUntil.Time
+
+	return throttle, nil
+}
+
+func NewLoginThrottleRepository(db *sqlx.DB) *loginThrottleRepository {
+	return &loginThrottleRepository{
+		db: db,
+	}
+}
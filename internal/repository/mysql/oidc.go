@@ -0,0 +1,218 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/adystag/jobs-search/internal"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type oidcAuthorizationRepository struct {
+	db *sqlx.DB
+}
+
+func (r oidcAuthorizationRepository) GetOIDCAuthorizationByStateHash(
+	ctx context.Context,
+	stateHash string,
+) (internal.OIDCAuthorization, error) {
+	var oidcAuthorization internal.OIDCAuthorization
+	var usedAt sql.NullTime
+
+	query := `
+		SELECT
+			id,
+			provider,
+			state_hash,
+			nonce,
+			code_verifier,
+			expires_at,
+			used_at,
+			created_at
+		FROM oidc_authorizations
+		WHERE state_hash = ?
+		LIMIT 1
+	`
+	err := r.db.QueryRowContext(ctx, query, stateHash).Scan(
+		&oidcAuthorization.ID,
+		&oidcAuthorization.Provider,
+		&oidcAuthorization.StateHash,
+		&oidcAuthorization.Nonce,
+		&oidcAuthorization.CodeVerifier,
+		&oidcAuthorization.ExpiresAt,
+		&usedAt,
+		&oidcAuthorization.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = fmt.Errorf("%w: %w", internal.ErrOIDCAuthorizationNotFound, err)
+		}
+
+		return internal.OIDCAuthorization{}, fmt.Errorf("querying mysql oidc_authorizations table: %w", err)
+	}
+
+	oidcAuthorization.UsedAt = usedAt.Time
+
+	return oidcAuthorization, nil
+}
+
+func (r oidcAuthorizationRepository) StoreOIDCAuthorization(
+	ctx context.Context,
+	oidcAuthorization *internal.OIDCAuthorization,
+) error {
+	query := `
+		INSERT INTO oidc_authorizations (provider, state_hash, nonce, code_verifier, expires_at, created_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`
+	res, err := r.db.ExecContext(
+		ctx,
+		query,
+		oidcAuthorization.Provider,
+		oidcAuthorization.StateHash,
+		oidcAuthorization.Nonce,
+		oidcAuthorization.CodeVerifier,
+		oidcAuthorization.ExpiresAt,
+		oidcAuthorization.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("executing mysql query: %w", err)
+	}
+
+	lastInsertedID, err := res.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("getting last inserted id: %w", err)
+	}
+
+	oidcAuthorization.ID = lastInsertedID
+
+	return nil
+}
+
+func (r oidcAuthorizationRepository) ConsumeOIDCAuthorization(
+	ctx context.Context,
+	oidcAuthorizationID int64,
+	usedAt time.Time,
+) error {
+	query := `
+		UPDATE oidc_authorizations
+		SET used_at = ?
+		WHERE id = ? AND used_at IS NULL
+	`
+	res, err := r.db.ExecContext(ctx, query, usedAt, oidcAuthorizationID)
+	if err != nil {
+		return fmt.Errorf("executing mysql query: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("getting affected rows: %w", err)
+	}
+
+	if affected == 0 {
+		return internal.ErrOIDCAuthorizationInvalid
+	}
+
+	return nil
+}
+
+func NewOIDCAuthorizationRepository(db *sqlx.DB) *oidcAuthorizationRepository {
+	return &oidcAuthorizationRepository{
+		db: db,
+	}
+}
+
+type userIdentityRepository struct {
+	db *sqlx.DB
+}
+
+func (r userIdentityRepository) GetUserIdentityBySubject(
+	ctx context.Context,
+	provider string,
+	subject string,
+) (internal.UserIdentity, error) {
+	var userIdentity internal.UserIdentity
+	var email sql.NullString
+	var lastLoginAt sql.NullTime
+
+	query := `
+		SELECT
+			id,
+			user_id,
+			provider,
+			subject,
+			email,
+			last_login_at,
+			created_at,
+			updated_at
+		FROM user_identities
+		WHERE provider = ? AND subject = ?
+		LIMIT 1
+	`
+	err := r.db.QueryRowContext(ctx, query, provider, subject).Scan(
+		&userIdentity.ID,
+		&userIdentity.UserID,
+		&userIdentity.Provider,
+		&userIdentity.Subject,
+		&email,
+		&lastLoginAt,
+		&userIdentity.CreatedAt,
+		&userIdentity.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = fmt.Errorf("%w: %w", internal.ErrUserIdentityNotFound, err)
+		}
+
+		return internal.UserIdentity{}, fmt.Errorf("querying mysql user_identities table: %w", err)
+	}
+
+	userIdentity.Email = email.String
+	userIdentity.LastLoginAt = lastLoginAt.Time
+
+	return userIdentity, nil
+}
+
+func (r userIdentityRepository) StoreUserIdentity(ctx context.Context, userIdentity *internal.UserIdentity) error {
+	query := `
+		INSERT INTO user_identities (user_id, provider, subject, email, last_login_at, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+			id = LAST_INSERT_ID(id),
+			email = VALUES(email),
+			last_login_at = VALUES(last_login_at),
+			updated_at = VALUES(updated_at)
+	`
+	res, err := r.db.ExecContext(
+		ctx,
+		query,
+		userIdentity.UserID,
+		userIdentity.Provider,
+		userIdentity.Subject,
+		sql.NullString{String: userIdentity.Email, Valid: len(userIdentity.Email) > 0},
+		sql.NullTime{Time: userIdentity.LastLoginAt, Valid: !userIdentity.LastLoginAt.IsZero()},
+		userIdentity.CreatedAt,
+		userIdentity.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("executing mysql query: %w", err)
+	}
+
+	lastInsertedID, err := res.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("getting last inserted id: %w", err)
+	}
+
+	userIdentity.ID = lastInsertedID
+
+	return nil
+}
+
+func NewUserIdentityRepository(db *sqlx.DB) *userIdentityRepository {
+	return &userIdentityRepository{
+		db: db,
+	}
+}
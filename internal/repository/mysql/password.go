@@ -0,0 +1,159 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/adystag/jobs-search/internal"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type passwordResetTokenRepository struct {
+	db *sqlx.DB
+}
+
+func (r passwordResetTokenRepository) GetPasswordResetTokenByHash(
+	ctx context.Context,
+	hash string,
+) (internal.PasswordResetToken, error) {
+	var passwordResetToken internal.PasswordResetToken
+	var usedAt sql.NullTime
+
+	query := `
+		SELECT
+			id,
+			user_id,
+			token_hash,
+			expires_at,
+			used_at,
+			created_at
+		FROM password_reset_tokens
+		WHERE token_hash = ?
+		LIMIT 1
+	`
+	err := r.db.QueryRowContext(ctx, query, hash).Scan(
+		&passwordResetToken.ID,
+		&passwordResetToken.UserID,
+		&passwordResetToken.Hash,
+		&passwordResetToken.ExpiresAt,
+		&usedAt,
+		&passwordResetToken.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = fmt.Errorf("%w: %w", internal.ErrPasswordResetTokenNotFound, err)
+		}
+
+		return internal.PasswordResetToken{}, fmt.Errorf("querying mysql password_reset_tokens table: %w", err)
+	}
+
+	passwordResetToken.UsedAt = usedAt.Time
+
+	return passwordResetToken, nil
+}
+
+func (r passwordResetTokenRepository) StorePasswordResetToken(
+	ctx context.Context,
+	passwordResetToken *internal.PasswordResetToken,
+) error {
+	query := `
+		INSERT INTO password_reset_tokens (user_id, token_hash, expires_at, created_at)
+		VALUES (?, ?, ?, ?)
+	`
+	res, err := r.db.ExecContext(
+		ctx,
+		query,
+		passwordResetToken.UserID,
+		passwordResetToken.Hash,
+		passwordResetToken.ExpiresAt,
+		passwordResetToken.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("executing mysql query: %w", err)
+	}
+
+	lastInsertedID, err := res.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("getting last inserted id: %w", err)
+	}
+
+	passwordResetToken.ID = lastInsertedID
+
+	return nil
+}
+
+func (r passwordResetTokenRepository) ConsumePasswordResetToken(
+	ctx context.Context,
+	passwordResetTokenID int64,
+	user internal.User,
+) (err error) {
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("initializing mysql db transaction: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	{
+		query := `
+			DELETE FROM password_reset_tokens
+			WHERE id = ? AND user_id = ? AND used_at IS NULL
+		`
+		res, err := tx.ExecContext(ctx, query, passwordResetTokenID, user.ID)
+		if err != nil {
+			return fmt.Errorf("executing mysql query: %w", err)
+		}
+
+		affected, err := res.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("getting affected rows: %w", err)
+		}
+
+		if affected == 0 {
+			return internal.ErrPasswordResetTokenInvalid
+		}
+	}
+
+	{
+		query := `
+			UPDATE users
+			SET password = ?, updated_at = ?
+			WHERE id = ?
+		`
+		_, err = tx.ExecContext(ctx, query, user.Password, user.UpdatedAt, user.ID)
+		if err != nil {
+			return fmt.Errorf("executing mysql query: %w", err)
+		}
+	}
+
+	{
+		query := `
+			DELETE FROM password_reset_tokens
+			WHERE user_id = ?
+		`
+		_, err = tx.ExecContext(ctx, query, user.ID)
+		if err != nil {
+			return fmt.Errorf("executing mysql query: %w", err)
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("committing mysql db transaction: %w", err)
+	}
+
+	return nil
+}
+
+func NewPasswordResetTokenRepository(db *sqlx.DB) *passwordResetTokenRepository {
+	return &passwordResetTokenRepository{
+		db: db,
+	}
+}
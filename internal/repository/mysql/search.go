@@ -0,0 +1,237 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/adystag/jobs-search/internal"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+type savedSearchRepository struct {
+	db *sqlx.DB
+}
+
+func (r savedSearchRepository) ListSavedSearchesByUserID(ctx context.Context, userID int64) ([]internal.SavedSearch, error) {
+	query := `
+		SELECT
+			id,
+			user_id,
+			name,
+			description,
+			location,
+			full_time,
+			last_run_at,
+			created_at,
+			updated_at
+		FROM saved_searches
+		WHERE user_id = ?
+		ORDER BY id
+	`
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("querying mysql saved_searches table: %w", err)
+	}
+
+	defer rows.Close()
+
+	var savedSearches []internal.SavedSearch
+
+	for rows.Next() {
+		savedSearch, err := r.scan(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scanning mysql saved_searches row: %w", err)
+		}
+
+		savedSearches = append(savedSearches, savedSearch)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("iterating mysql saved_searches rows: %w", err)
+	}
+
+	return savedSearches, nil
+}
+
+func (r savedSearchRepository) GetSavedSearchByID(ctx context.Context, userID, savedSearchID int64) (internal.SavedSearch, error) {
+	query := `
+		SELECT
+			id,
+			user_id,
+			name,
+			description,
+			location,
+			full_time,
+			last_run_at,
+			created_at,
+			updated_at
+		FROM saved_searches
+		WHERE id = ? AND user_id = ?
+		LIMIT 1
+	`
+	savedSearch, err := r.scan(r.db.QueryRowContext(ctx, query, savedSearchID, userID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = fmt.Errorf("%w: %w", internal.ErrSavedSearchNotFound, err)
+		}
+
+		return internal.SavedSearch{}, fmt.Errorf("querying mysql saved_searches table: %w", err)
+	}
+
+	return savedSearch, nil
+}
+
+func (r savedSearchRepository) StoreSavedSearch(ctx context.Context, savedSearch *internal.SavedSearch) (err error) {
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("initializing mysql db transaction: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	{
+		lastRunAt := sql.NullTime{
+			Time:  savedSearch.LastRunAt,
+			Valid: !savedSearch.LastRunAt.IsZero(),
+		}
+		query := `
+			INSERT INTO saved_searches (
+				user_id,
+				name,
+				description,
+				location,
+				full_time,
+				last_run_at,
+				created_at,
+				updated_at
+			)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		`
+		args := []interface{}{
+			savedSearch.UserID,
+			savedSearch.Name,
+			savedSearch.Description,
+			savedSearch.Location,
+			savedSearch.FullTime,
+			lastRunAt,
+			savedSearch.CreatedAt,
+			savedSearch.UpdatedAt,
+		}
+
+		if savedSearch.ID > 0 {
+			query = `
+				UPDATE saved_searches
+				SET
+					name = ?,
+					description = ?,
+					location = ?,
+					full_time = ?,
+					last_run_at = ?,
+					updated_at = ?
+				WHERE id = ? AND user_id = ?
+			`
+			args = []interface{}{
+				savedSearch.Name,
+				savedSearch.Description,
+				savedSearch.Location,
+				savedSearch.FullTime,
+				lastRunAt,
+				savedSearch.UpdatedAt,
+				savedSearch.ID,
+				savedSearch.UserID,
+			}
+		}
+
+		stmt, err := tx.PrepareContext(ctx, query)
+		if err != nil {
+			return fmt.Errorf("preparing mysql query: %w", err)
+		}
+
+		defer stmt.Close()
+
+		res, err := stmt.ExecContext(ctx, args...)
+		if err != nil {
+			return fmt.Errorf("executing mysql query: %w", err)
+		}
+
+		if savedSearch.ID <= 0 {
+			lastInsertedID, err := res.LastInsertId()
+			if err != nil {
+				return fmt.Errorf("getting last inserted id: %w", err)
+			}
+
+			savedSearch.ID = lastInsertedID
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("committing mysql db transaction: %w", err)
+	}
+
+	return nil
+}
+
+func (r savedSearchRepository) DeleteSavedSearch(ctx context.Context, userID, savedSearchID int64) error {
+	query := `
+		DELETE FROM saved_searches
+		WHERE id = ? AND user_id = ?
+	`
+	res, err := r.db.ExecContext(ctx, query, savedSearchID, userID)
+	if err != nil {
+		return fmt.Errorf("executing mysql query: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("getting affected rows: %w", err)
+	}
+
+	if affected == 0 {
+		return internal.ErrSavedSearchNotFound
+	}
+
+	return nil
+}
+
+func (r savedSearchRepository) scan(row rowScanner) (internal.SavedSearch, error) {
+	var savedSearch internal.SavedSearch
+	var lastRunAt sql.NullTime
+
+	err := row.Scan(
+		&savedSearch.ID,
+		&savedSearch.UserID,
+		&savedSearch.Name,
+		&savedSearch.Description,
+		&savedSearch.Location,
+		&savedSearch.FullTime,
+		&lastRunAt,
+		&savedSearch.CreatedAt,
+		&savedSearch.UpdatedAt,
+	)
+	if err != nil {
+		return internal.SavedSearch{}, err
+	}
+
+	savedSearch.LastRunAt = lastRunAt.Time
+
+	return savedSearch, nil
+}
+
+func NewSavedSearchRepository(db *sqlx.DB) *savedSearchRepository {
+	return &savedSearchRepository{
+		db: db,
+	}
+}
@@ -0,0 +1,227 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/adystag/jobs-search/internal"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+type refreshTokenRepository struct {
+	db *sqlx.DB
+}
+
+func (r refreshTokenRepository) GetRefreshTokenByHash(ctx context.Context, hash string) (internal.RefreshToken, error) {
+	var refreshToken internal.RefreshToken
+	var usedAt, revokedAt sql.NullTime
+
+	query := `
+		SELECT
+			id,
+			user_id,
+			family_id,
+			two_factor,
+			token_hash,
+			expires_at,
+			used_at,
+			revoked_at,
+			created_at
+		FROM refresh_tokens
+		WHERE token_hash = ?
+		LIMIT 1
+	`
+	err := r.db.QueryRowContext(ctx, query, hash).Scan(
+		&refreshToken.ID,
+		&refreshToken.UserID,
+		&refreshToken.FamilyID,
+		&refreshToken.TwoFactor,
+		&refreshToken.Hash,
+		&refreshToken.ExpiresAt,
+		&usedAt,
+		&revokedAt,
+		&refreshToken.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = fmt.Errorf("%w: %w", internal.ErrRefreshTokenNotFound, err)
+		}
+
+		return internal.RefreshToken{}, fmt.Errorf("querying mysql refresh_tokens table: %w", err)
+	}
+
+	refreshToken.UsedAt = usedAt.Time
+	refreshToken.RevokedAt = revokedAt.Time
+
+	return refreshToken, nil
+}
+
+func (r refreshTokenRepository) StoreRefreshToken(ctx context.Context, refreshToken *internal.RefreshToken) error {
+	query := `
+		INSERT INTO refresh_tokens (user_id, family_id, two_factor, token_hash, expires_at, created_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`
+	res, err := r.db.ExecContext(
+		ctx,
+		query,
+		refreshToken.UserID,
+		refreshToken.FamilyID.String(),
+		refreshToken.TwoFactor,
+		refreshToken.Hash,
+		refreshToken.ExpiresAt,
+		refreshToken.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("executing mysql query: %w", err)
+	}
+
+	lastInsertedID, err := res.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("getting last inserted id: %w", err)
+	}
+
+	refreshToken.ID = lastInsertedID
+
+	return nil
+}
+
+func (r refreshTokenRepository) ConsumeRefreshToken(ctx context.Context, refreshTokenID int64, usedAt time.Time) error {
+	query := `
+		UPDATE refresh_tokens
+		SET used_at = ?
+		WHERE id = ? AND used_at IS NULL AND revoked_at IS NULL
+	`
+	res, err := r.db.ExecContext(ctx, query, usedAt, refreshTokenID)
+	if err != nil {
+		return fmt.Errorf("executing mysql query: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("getting affected rows: %w", err)
+	}
+
+	if affected == 0 {
+		return internal.ErrRefreshTokenReused
+	}
+
+	return nil
+}
+
+func (r refreshTokenRepository) RevokeRefreshTokenFamily(ctx context.Context, familyID uuid.UUID, revokedAt time.Time) error {
+	query := `
+		UPDATE refresh_tokens
+		SET revoked_at = ?
+		WHERE family_id = ? AND revoked_at IS NULL
+	`
+	_, err := r.db.ExecContext(ctx, query, revokedAt, familyID.String())
+	if err != nil {
+		return fmt.Errorf("executing mysql query: %w", err)
+	}
+
+	return nil
+}
+
+func (r refreshTokenRepository) RevokeRefreshTokensByUserID(ctx context.Context, userID int64, revokedAt time.Time) error {
+	query := `
+		UPDATE refresh_tokens
+		SET revoked_at = ?
+		WHERE user_id = ? AND revoked_at IS NULL
+	`
+	_, err := r.db.ExecContext(ctx, query, revokedAt, userID)
+	if err != nil {
+		return fmt.Errorf("executing mysql query: %w", err)
+	}
+
+	return nil
+}
+
+func NewRefreshTokenRepository(db *sqlx.DB) *refreshTokenRepository {
+	return &refreshTokenRepository{
+		db: db,
+	}
+}
+
+type accessTokenRevocationRepository struct {
+	db    *sqlx.DB
+	timer internal.Timer
+}
+
+func (r accessTokenRevocationRepository) RevokeAccessToken(
+	ctx context.Context,
+	accessTokenID string,
+	userID int64,
+	expiresAt time.Time,
+) (err error) {
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("initializing mysql db transaction: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	now := r.timer.Now()
+
+	{
+		query := `
+			DELETE FROM revoked_access_tokens
+			WHERE expires_at < ?
+		`
+		_, err = tx.ExecContext(ctx, query, now)
+		if err != nil {
+			return fmt.Errorf("executing mysql query: %w", err)
+		}
+	}
+
+	{
+		query := `
+			INSERT IGNORE INTO revoked_access_tokens (jti, user_id, expires_at, revoked_at)
+			VALUES (?, ?, ?, ?)
+		`
+		_, err = tx.ExecContext(ctx, query, accessTokenID, userID, expiresAt, now)
+		if err != nil {
+			return fmt.Errorf("executing mysql query: %w", err)
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("committing mysql db transaction: %w", err)
+	}
+
+	return nil
+}
+
+func (r accessTokenRevocationRepository) IsAccessTokenRevoked(ctx context.Context, accessTokenID string) (bool, error) {
+	var revoked bool
+
+	query := `
+		SELECT EXISTS (
+			SELECT 1
+			FROM revoked_access_tokens
+			WHERE jti = ?
+		)
+	`
+	err := r.db.QueryRowContext(ctx, query, accessTokenID).Scan(&revoked)
+	if err != nil {
+		return false, fmt.Errorf("querying mysql revoked_access_tokens table: %w", err)
+	}
+
+	return revoked, nil
+}
+
+func NewAccessTokenRevocationRepository(db *sqlx.DB, timer internal.Timer) *accessTokenRevocationRepository {
+	return &accessTokenRevocationRepository{
+		db:    db,
+		timer: timer,
+	}
+}
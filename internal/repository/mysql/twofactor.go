@@ -0,0 +1,347 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/adystag/jobs-search/internal"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type totpSecretRepository struct {
+	db *sqlx.DB
+}
+
+func (r totpSecretRepository) GetTOTPSecretByUserID(ctx context.Context, userID int64) (internal.TOTPSecret, error) {
+	var totpSecret internal.TOTPSecret
+	var lastUsedStep sql.NullInt64
+
+	query := `
+		SELECT
+			user_id,
+			secret,
+			last_used_step,
+			created_at,
+			updated_at
+		FROM user_totp_secrets
+		WHERE user_id = ?
+		LIMIT 1
+	`
+	err := r.db.QueryRowContext(ctx, query, userID).Scan(
+		&totpSecret.UserID,
+		&totpSecret.Secret,
+		&lastUsedStep,
+		&totpSecret.CreatedAt,
+		&totpSecret.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = fmt.Errorf("%w: %w", internal.ErrTOTPSecretNotFound, err)
+		}
+
+		return internal.TOTPSecret{}, fmt.Errorf("querying mysql user_totp_secrets table: %w", err)
+	}
+
+	totpSecret.LastUsedStep = lastUsedStep.Int64
+
+	return totpSecret, nil
+}
+
+func (r totpSecretRepository) StoreTOTPSecret(ctx context.Context, totpSecret *internal.TOTPSecret) error {
+	query := `
+		INSERT INTO user_totp_secrets (user_id, secret, last_used_step, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+			secret = VALUES(secret),
+			last_used_step = VALUES(last_used_step),
+			updated_at = VALUES(updated_at)
+	`
+	_, err := r.db.ExecContext(
+		ctx,
+		query,
+		totpSecret.UserID,
+		totpSecret.Secret,
+		sql.NullInt64{Int64: totpSecret.LastUsedStep, Valid: totpSecret.LastUsedStep > 0},
+		totpSecret.CreatedAt,
+		totpSecret.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("executing mysql query: %w", err)
+	}
+
+	return nil
+}
+
+func (r totpSecretRepository) DeleteTOTPSecret(ctx context.Context, userID int64) error {
+	query := `
+		DELETE FROM user_totp_secrets
+		WHERE user_id = ?
+	`
+	_, err := r.db.ExecContext(ctx, query, userID)
+	if err != nil {
+		return fmt.Errorf("executing mysql query: %w", err)
+	}
+
+	return nil
+}
+
+func (r totpSecretRepository) ConsumeTOTPStep(ctx context.Context, userID int64, step int64) error {
+	query := `
+		UPDATE user_totp_secrets
+		SET last_used_step = ?
+		WHERE user_id = ? AND (last_used_step IS NULL OR last_used_step < ?)
+	`
+	res, err := r.db.ExecContext(ctx, query, step, userID, step)
+	if err != nil {
+		return fmt.Errorf("executing mysql query: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("getting affected rows: %w", err)
+	}
+
+	if affected == 0 {
+		return internal.ErrTwoFactorCodeInvalid
+	}
+
+	return nil
+}
+
+func NewTOTPSecretRepository(db *sqlx.DB) *totpSecretRepository {
+	return &totpSecretRepository{
+		db: db,
+	}
+}
+
+type recoveryCodeRepository struct {
+	db *sqlx.DB
+}
+
+func (r recoveryCodeRepository) ReplaceRecoveryCodes(
+	ctx context.Context,
+	userID int64,
+	recoveryCodes []internal.RecoveryCode,
+) (err error) {
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("initializing mysql db transaction: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	{
+		query := `
+			DELETE FROM recovery_codes
+			WHERE user_id = ?
+		`
+
+		_, err = tx.ExecContext(ctx, query, userID)
+		if err != nil {
+			return fmt.Errorf("executing mysql query: %w", err)
+		}
+	}
+
+	if len(recoveryCodes) > 0 {
+		query := `
+			INSERT INTO recovery_codes (user_id, code_hash, created_at)
+			VALUES (?, ?, ?)
+		`
+
+		stmt, err := tx.PrepareContext(ctx, query)
+		if err != nil {
+			return fmt.Errorf("preparing mysql query: %w", err)
+		}
+
+		defer stmt.Close()
+
+		for index := range recoveryCodes {
+			res, err := stmt.ExecContext(ctx, userID, recoveryCodes[index].Hash, recoveryCodes[index].CreatedAt)
+			if err != nil {
+				return fmt.Errorf("executing mysql query: %w", err)
+			}
+
+			lastInsertedID, err := res.LastInsertId()
+			if err != nil {
+				return fmt.Errorf("getting last inserted id: %w", err)
+			}
+
+			recoveryCodes[index].ID = lastInsertedID
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("committing mysql db transaction: %w", err)
+	}
+
+	return nil
+}
+
+func (r recoveryCodeRepository) ConsumeRecoveryCode(
+	ctx context.Context,
+	userID int64,
+	hash string,
+	usedAt time.Time,
+) error {
+	query := `
+		UPDATE recovery_codes
+		SET used_at = ?
+		WHERE user_id = ? AND code_hash = ? AND used_at IS NULL
+	`
+	res, err := r.db.ExecContext(ctx, query, usedAt, userID, hash)
+	if err != nil {
+		return fmt.Errorf("executing mysql query: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("getting affected rows: %w", err)
+	}
+
+	if affected == 0 {
+		return internal.ErrTwoFactorCodeInvalid
+	}
+
+	return nil
+}
+
+func NewRecoveryCodeRepository(db *sqlx.DB) *recoveryCodeRepository {
+	return &recoveryCodeRepository{
+		db: db,
+	}
+}
+
+type twoFactorChallengeRepository struct {
+	db *sqlx.DB
+}
+
+func (r twoFactorChallengeRepository) GetTwoFactorChallengeByHash(
+	ctx context.Context,
+	hash string,
+) (internal.TwoFactorChallenge, error) {
+	var twoFactorChallenge internal.TwoFactorChallenge
+	var usedAt sql.NullTime
+
+	query := `
+		SELECT
+			id,
+			user_id,
+			token_hash,
+			attempts,
+			expires_at,
+			used_at,
+			created_at
+		FROM two_factor_challenges
+		WHERE token_hash = ?
+		LIMIT 1
+	`
+	err := r.db.QueryRowContext(ctx, query, hash).Scan(
+		&twoFactorChallenge.ID,
+		&twoFactorChallenge.UserID,
+		&twoFactorChallenge.Hash,
+		&twoFactorChallenge.Attempts,
+		&twoFactorChallenge.ExpiresAt,
+		&usedAt,
+		&twoFactorChallenge.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = fmt.Errorf("%w: %w", internal.ErrTwoFactorChallengeNotFound, err)
+		}
+
+		return internal.TwoFactorChallenge{}, fmt.Errorf("querying mysql two_factor_challenges table: %w", err)
+	}
+
+	twoFactorChallenge.UsedAt = usedAt.Time
+
+	return twoFactorChallenge, nil
+}
+
+func (r twoFactorChallengeRepository) StoreTwoFactorChallenge(
+	ctx context.Context,
+	twoFactorChallenge *internal.TwoFactorChallenge,
+) error {
+	query := `
+		INSERT INTO two_factor_challenges (user_id, token_hash, expires_at, created_at)
+		VALUES (?, ?, ?, ?)
+	`
+	res, err := r.db.ExecContext(
+		ctx,
+		query,
+		twoFactorChallenge.UserID,
+		twoFactorChallenge.Hash,
+		twoFactorChallenge.ExpiresAt,
+		twoFactorChallenge.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("executing mysql query: %w", err)
+	}
+
+	lastInsertedID, err := res.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("getting last inserted id: %w", err)
+	}
+
+	twoFactorChallenge.ID = lastInsertedID
+
+	return nil
+}
+
+func (r twoFactorChallengeRepository) RecordTwoFactorChallengeAttempt(
+	ctx context.Context,
+	twoFactorChallengeID int64,
+) error {
+	query := `
+		UPDATE two_factor_challenges
+		SET attempts = attempts + 1
+		WHERE id = ?
+	`
+	_, err := r.db.ExecContext(ctx, query, twoFactorChallengeID)
+	if err != nil {
+		return fmt.Errorf("executing mysql query: %w", err)
+	}
+
+	return nil
+}
+
+func (r twoFactorChallengeRepository) ConsumeTwoFactorChallenge(
+	ctx context.Context,
+	twoFactorChallengeID int64,
+	usedAt time.Time,
+) error {
+	query := `
+		UPDATE two_factor_challenges
+		SET used_at = ?
+		WHERE id = ? AND used_at IS NULL
+	`
+	res, err := r.db.ExecContext(ctx, query, usedAt, twoFactorChallengeID)
+	if err != nil {
+		return fmt.Errorf("executing mysql query: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("getting affected rows: %w", err)
+	}
+
+	if affected == 0 {
+		return internal.ErrTwoFactorChallengeInvalid
+	}
+
+	return nil
+}
+
+func NewTwoFactorChallengeRepository(db *sqlx.DB) *twoFactorChallengeRepository {
+	return &twoFactorChallengeRepository{
+		db: db,
+	}
+}
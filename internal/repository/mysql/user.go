@@ -3,12 +3,17 @@ package mysql
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/adystag/jobs-search/internal"
 
 	"github.com/jmoiron/sqlx"
+
+	gomysql "github.com/go-sql-driver/mysql"
 )
 
 type userRepository struct {
@@ -16,26 +21,102 @@ type userRepository struct {
 }
 
 func (r userRepository) GetUserByUsername(ctx context.Context, username string) (internal.User, error) {
-	var user internal.User
+	query := `
+		SELECT
+			u.id,
+			u.username,
+			u.password,
+			u.email,
+			u.email_verified_at,
+			u.display_name,
+			u.preferred_locations,
+			u.preferred_job_type,
+			u.timezone,
+			u.two_factor_enabled_at,
+			u.token_version,
+			u.disabled_at,
+			COALESCE(GROUP_CONCAT(r.role ORDER BY r.role), ''),
+			u.created_at,
+			u.updated_at
+		FROM users u
+		LEFT JOIN user_roles r ON r.user_id = u.id
+		WHERE u.username = ?
+		GROUP BY u.id
+		LIMIT 1
+	`
+	user, err := r.scan(r.db.QueryRowContext(ctx, query, username))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = fmt.Errorf("%w: %w", internal.ErrUserNotFound, err)
+		}
 
+		return internal.User{}, fmt.Errorf("querying mysql users table: %w", err)
+	}
+
+	return user, nil
+}
+
+func (r userRepository) GetUserByID(ctx context.Context, userID int64) (internal.User, error) {
 	query := `
 		SELECT
-			id,
-			username,
-			password,
-			created_at,
-			updated_at
-		FROM users
-		WHERE username = ?
+			u.id,
+			u.username,
+			u.password,
+			u.email,
+			u.email_verified_at,
+			u.display_name,
+			u.preferred_locations,
+			u.preferred_job_type,
+			u.timezone,
+			u.two_factor_enabled_at,
+			u.token_version,
+			u.disabled_at,
+			COALESCE(GROUP_CONCAT(r.role ORDER BY r.role), ''),
+			u.created_at,
+			u.updated_at
+		FROM users u
+		LEFT JOIN user_roles r ON r.user_id = u.id
+		WHERE u.id = ?
+		GROUP BY u.id
 		LIMIT 1
 	`
-	err := r.db.QueryRowContext(ctx, query, username).Scan(
-		&user.ID,
-		&user.Username,
-		&user.Password,
-		&user.CreatedAt,
-		&user.UpdatedAt,
-	)
+	user, err := r.scan(r.db.QueryRowContext(ctx, query, userID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = fmt.Errorf("%w: %w", internal.ErrUserNotFound, err)
+		}
+
+		return internal.User{}, fmt.Errorf("querying mysql users table: %w", err)
+	}
+
+	return user, nil
+}
+
+func (r userRepository) GetUserByEmail(ctx context.Context, email string) (internal.User, error) {
+	query := `
+		SELECT
+			u.id,
+			u.username,
+			u.password,
+			u.email,
+			u.email_verified_at,
+			u.display_name,
+			u.preferred_locations,
+			u.preferred_job_type,
+			u.timezone,
+			u.two_factor_enabled_at,
+			u.token_version,
+			u.disabled_at,
+			COALESCE(GROUP_CONCAT(r.role ORDER BY r.role), ''),
+			u.created_at,
+			u.updated_at
+		FROM users u
+		LEFT JOIN user_roles r ON r.user_id = u.id
+		WHERE u.email = ?
+		GROUP BY u.id
+		LIMIT 1
+	`
+	user, err := r.scan(r.db.QueryRowContext(ctx, query, email))
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			err = fmt.Errorf("%w: %w", internal.ErrUserNotFound, err)
@@ -47,7 +128,111 @@ func (r userRepository) GetUserByUsername(ctx context.Context, username string)
 	return user, nil
 }
 
+func (r userRepository) ListUsers(ctx context.Context) ([]internal.User, error) {
+	query := `
+		SELECT
+			u.id,
+			u.username,
+			u.password,
+			u.email,
+			u.email_verified_at,
+			u.display_name,
+			u.preferred_locations,
+			u.preferred_job_type,
+			u.timezone,
+			u.two_factor_enabled_at,
+			u.token_version,
+			u.disabled_at,
+			COALESCE(GROUP_CONCAT(r.role ORDER BY r.role), ''),
+			u.created_at,
+			u.updated_at
+		FROM users u
+		LEFT JOIN user_roles r ON r.user_id = u.id
+		GROUP BY u.id
+		ORDER BY u.id
+	`
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("querying mysql users table: %w", err)
+	}
+
+	defer rows.Close()
+
+	var users []internal.User
+
+	for rows.Next() {
+		user, err := r.scan(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scanning mysql users row: %w", err)
+		}
+
+		users = append(users, user)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("iterating mysql users rows: %w", err)
+	}
+
+	return users, nil
+}
+
+func (r userRepository) IncrementUserTokenVersion(ctx context.Context, userID int64) error {
+	query := `
+		UPDATE users
+		SET token_version = token_version + 1
+		WHERE id = ?
+	`
+	_, err := r.db.ExecContext(ctx, query, userID)
+	if err != nil {
+		return fmt.Errorf("executing mysql query: %w", err)
+	}
+
+	return nil
+}
+
+func (r userRepository) StoreUserRole(ctx context.Context, userID int64, role internal.Role) error {
+	query := `
+		INSERT IGNORE INTO user_roles (user_id, role)
+		VALUES (?, ?)
+	`
+	_, err := r.db.ExecContext(ctx, query, userID, string(role))
+	if err != nil {
+		return fmt.Errorf("executing mysql query: %w", err)
+	}
+
+	return nil
+}
+
+func (r userRepository) UpdateUserPassword(
+	ctx context.Context,
+	userID int64,
+	password string,
+	updatedAt time.Time,
+) error {
+	query := `
+		UPDATE users
+		SET password = ?, updated_at = ?
+		WHERE id = ?
+	`
+	_, err := r.db.ExecContext(ctx, query, password, updatedAt, userID)
+	if err != nil {
+		return fmt.Errorf("executing mysql query: %w", err)
+	}
+
+	return nil
+}
+
 func (r userRepository) StoreUser(ctx context.Context, user *internal.User) (err error) {
+	var preferredLocations []byte
+
+	if user.PreferredLocations != nil {
+		preferredLocations, err = json.Marshal(user.PreferredLocations)
+		if err != nil {
+			return fmt.Errorf("marshalling user preferred locations to json: %w", err)
+		}
+	}
+
 	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{})
 	if err != nil {
 		return fmt.Errorf("initializing mysql db transaction: %w", err)
@@ -61,12 +246,29 @@ func (r userRepository) StoreUser(ctx context.Context, user *internal.User) (err
 
 	{
 		query := `
-			INSERT INTO users (username, password, created_at, updated_at)
-			VALUES (?, ?, ?, ?)
+			INSERT INTO users (
+				username,
+				password,
+				email,
+				email_verified_at,
+				display_name,
+				preferred_locations,
+				preferred_job_type,
+				timezone,
+				created_at,
+				updated_at
+			)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 		`
 		args := []interface{}{
 			user.Username,
 			user.Password,
+			sql.NullString{String: user.Email, Valid: len(user.Email) > 0},
+			sql.NullTime{Time: user.EmailVerifiedAt, Valid: !user.EmailVerifiedAt.IsZero()},
+			sql.NullString{String: user.DisplayName, Valid: len(user.DisplayName) > 0},
+			preferredLocations,
+			sql.NullString{String: user.PreferredJobType, Valid: len(user.PreferredJobType) > 0},
+			sql.NullString{String: user.Timezone, Valid: len(user.Timezone) > 0},
 			user.CreatedAt,
 			user.UpdatedAt,
 		}
@@ -77,12 +279,28 @@ func (r userRepository) StoreUser(ctx context.Context, user *internal.User) (err
 				SET
 					username = ?,
 					password = ?,
+					email = ?,
+					email_verified_at = ?,
+					display_name = ?,
+					preferred_locations = ?,
+					preferred_job_type = ?,
+					timezone = ?,
+					two_factor_enabled_at = ?,
+					disabled_at = ?,
 					updated_at = ?
 				WHERE id = ?
 			`
 			args = []interface{}{
 				user.Username,
 				user.Password,
+				sql.NullString{String: user.Email, Valid: len(user.Email) > 0},
+				sql.NullTime{Time: user.EmailVerifiedAt, Valid: !user.EmailVerifiedAt.IsZero()},
+				sql.NullString{String: user.DisplayName, Valid: len(user.DisplayName) > 0},
+				preferredLocations,
+				sql.NullString{String: user.PreferredJobType, Valid: len(user.PreferredJobType) > 0},
+				sql.NullString{String: user.Timezone, Valid: len(user.Timezone) > 0},
+				sql.NullTime{Time: user.TwoFactorEnabledAt, Valid: user.TwoFactorEnabled()},
+				sql.NullTime{Time: user.DisabledAt, Valid: user.Disabled()},
 				user.UpdatedAt,
 				user.ID,
 			}
@@ -97,7 +315,7 @@ func (r userRepository) StoreUser(ctx context.Context, user *internal.User) (err
 
 		res, err := stmt.ExecContext(ctx, args...)
 		if err != nil {
-			return fmt.Errorf("executing mysql query: %w", err)
+			return evaluateUserStoreError(err)
 		}
 
 		if user.ID <= 0 {
@@ -118,8 +336,169 @@ func (r userRepository) StoreUser(ctx context.Context, user *internal.User) (err
 	return nil
 }
 
+func (r userRepository) StoreUserProfile(ctx context.Context, user *internal.User) error {
+	var preferredLocations []byte
+
+	if user.PreferredLocations != nil {
+		var err error
+
+		preferredLocations, err = json.Marshal(user.PreferredLocations)
+		if err != nil {
+			return fmt.Errorf("marshalling user preferred locations to json: %w", err)
+		}
+	}
+
+	query := `
+		UPDATE users
+		SET
+			email_verified_at = IF(email <=> ?, email_verified_at, NULL),
+			email = ?,
+			display_name = ?,
+			preferred_locations = ?,
+			preferred_job_type = ?,
+			timezone = ?,
+			updated_at = ?
+		WHERE id = ?
+	`
+	email := sql.NullString{String: user.Email, Valid: len(user.Email) > 0}
+	_, err := r.db.ExecContext(
+		ctx,
+		query,
+		email,
+		email,
+		sql.NullString{String: user.DisplayName, Valid: len(user.DisplayName) > 0},
+		preferredLocations,
+		sql.NullString{String: user.PreferredJobType, Valid: len(user.PreferredJobType) > 0},
+		sql.NullString{String: user.Timezone, Valid: len(user.Timezone) > 0},
+		user.UpdatedAt,
+		user.ID,
+	)
+	if err != nil {
+		return evaluateUserStoreError(err)
+	}
+
+	return nil
+}
+
+func (r userRepository) UpdateUserTwoFactor(
+	ctx context.Context,
+	userID int64,
+	twoFactorEnabledAt time.Time,
+	updatedAt time.Time,
+) error {
+	query := `
+		UPDATE users
+		SET two_factor_enabled_at = ?, updated_at = ?
+		WHERE id = ?
+	`
+	_, err := r.db.ExecContext(
+		ctx,
+		query,
+		sql.NullTime{Time: twoFactorEnabledAt, Valid: !twoFactorEnabledAt.IsZero()},
+		updatedAt,
+		userID,
+	)
+	if err != nil {
+		return fmt.Errorf("executing mysql query: %w", err)
+	}
+
+	return nil
+}
+
+func (r userRepository) UpdateUserDisabledAt(
+	ctx context.Context,
+	userID int64,
+	disabledAt time.Time,
+	updatedAt time.Time,
+) error {
+	query := `
+		UPDATE users
+		SET disabled_at = ?, updated_at = ?
+		WHERE id = ?
+	`
+	_, err := r.db.ExecContext(
+		ctx,
+		query,
+		sql.NullTime{Time: disabledAt, Valid: !disabledAt.IsZero()},
+		updatedAt,
+		userID,
+	)
+	if err != nil {
+		return fmt.Errorf("executing mysql query: %w", err)
+	}
+
+	return nil
+}
+
+func (r userRepository) scan(row rowScanner) (internal.User, error) {
+	var user internal.User
+	var email, displayName, preferredJobType, timezone sql.NullString
+	var preferredLocations []byte
+	var emailVerifiedAt, twoFactorEnabledAt, disabledAt sql.NullTime
+	var roles string
+
+	err := row.Scan(
+		&user.ID,
+		&user.Username,
+		&user.Password,
+		&email,
+		&emailVerifiedAt,
+		&displayName,
+		&preferredLocations,
+		&preferredJobType,
+		&timezone,
+		&twoFactorEnabledAt,
+		&user.TokenVersion,
+		&disabledAt,
+		&roles,
+		&user.CreatedAt,
+		&user.UpdatedAt,
+	)
+	if err != nil {
+		return internal.User{}, err
+	}
+
+	if len(preferredLocations) > 0 {
+		err = json.Unmarshal(preferredLocations, &user.PreferredLocations)
+		if err != nil {
+			return internal.User{}, fmt.Errorf("unmarshalling user preferred locations from json: %w", err)
+		}
+	}
+
+	user.Email = email.String
+	user.EmailVerifiedAt = emailVerifiedAt.Time
+	user.DisplayName = displayName.String
+	user.PreferredJobType = preferredJobType.String
+	user.Timezone = timezone.String
+	user.TwoFactorEnabledAt = twoFactorEnabledAt.Time
+	user.DisabledAt = disabledAt.Time
+
+	for _, each := range strings.Split(roles, ",") {
+		if len(each) > 0 {
+			user.Roles = append(user.Roles, internal.Role(each))
+		}
+	}
+
+	return user, nil
+}
+
 func NewUserRepository(db *sqlx.DB) *userRepository {
 	return &userRepository{
 		db: db,
 	}
 }
+
+func evaluateUserStoreError(err error) error {
+	var mysqlErr *gomysql.MySQLError
+
+	if errors.As(err, &mysqlErr) && mysqlErr.Number == mysqlErrDuplicateEntry {
+		switch {
+		case strings.Contains(mysqlErr.Message, "email_uidx"):
+			return internal.NewValidationError("email", "unique")
+		case strings.Contains(mysqlErr.Message, "username_uidx"):
+			return internal.NewValidationError("username", "unique")
+		}
+	}
+
+	return fmt.Errorf("executing mysql query: %w", err)
+}
@@ -0,0 +1,294 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var ErrSavedSearchNotFound = errors.New("saved search not found")
+
+type SavedSearchCreator interface {
+	CreateSavedSearch(ctx context.Context, req SavedSearchRequest) (SavedSearch, error)
+}
+
+type SavedSearchUpdater interface {
+	UpdateSavedSearch(ctx context.Context, savedSearchID int64, req SavedSearchRequest) (SavedSearch, error)
+}
+
+type SavedSearchRunner interface {
+	RunSavedSearch(
+		ctx context.Context,
+		userID int64,
+		savedSearchID int64,
+		opts ...Option[JobsListerOption],
+	) (JobsListResult, error)
+}
+
+type SavedSearchesListerByUserID interface {
+	ListSavedSearchesByUserID(ctx context.Context, userID int64) ([]SavedSearch, error)
+}
+
+type SavedSearchGetterByID interface {
+	GetSavedSearchByID(ctx context.Context, userID, savedSearchID int64) (SavedSearch, error)
+}
+
+type SavedSearchStorer interface {
+	StoreSavedSearch(ctx context.Context, savedSearch *SavedSearch) error
+}
+
+type SavedSearchDeleter interface {
+	DeleteSavedSearch(ctx context.Context, userID, savedSearchID int64) error
+}
+
+type SavedSearchRequest struct {
+	UserID      int64
+	Name        string
+	Description string
+	Location    string
+	FullTime    bool
+}
+
+type SavedSearch struct {
+	ID          int64
+	UserID      int64
+	Name        string
+	Description string
+	Location    string
+	FullTime    bool
+	LastRunAt   time.Time
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+func (ss SavedSearch) JobsListerOptions() []Option[JobsListerOption] {
+	opts := []Option[JobsListerOption]{}
+
+	if len(ss.Description) > 0 {
+		opts = append(opts, WithJobsListerDescription(ss.Description))
+	}
+
+	if len(ss.Location) > 0 {
+		opts = append(opts, WithJobsListerLocation(ss.Location))
+	}
+
+	if ss.FullTime {
+		opts = append(opts, WithJobsListerFullTime(ss.FullTime))
+	}
+
+	return opts
+}
+
+type savedSearchCreator struct {
+	validator         Validator[SavedSearchRequest]
+	timer             Timer
+	savedSearchStorer SavedSearchStorer
+}
+
+func (sc savedSearchCreator) CreateSavedSearch(ctx context.Context, req SavedSearchRequest) (SavedSearch, error) {
+	err := sc.validator.Validate(ctx, req)
+	if err != nil {
+		return SavedSearch{}, fmt.Errorf("validating saved search request: %w", err)
+	}
+
+	now := sc.timer.Now()
+	savedSearch := SavedSearch{
+		UserID:      req.UserID,
+		Name:        req.Name,
+		Description: req.Description,
+		Location:    req.Location,
+		FullTime:    req.FullTime,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	err = sc.savedSearchStorer.StoreSavedSearch(ctx, &savedSearch)
+	if err != nil {
+		return SavedSearch{}, fmt.Errorf("storing saved search: %w", err)
+	}
+
+	return savedSearch, nil
+}
+
+func NewSavedSearchCreator(
+	validator Validator[SavedSearchRequest],
+	timer Timer,
+	savedSearchStorer SavedSearchStorer,
+) *savedSearchCreator {
+	return &savedSearchCreator{
+		validator:         validator,
+		timer:             timer,
+		savedSearchStorer: savedSearchStorer,
+	}
+}
+
+type savedSearchUpdater struct {
+	validator             Validator[SavedSearchRequest]
+	timer                 Timer
+	savedSearchGetterByID SavedSearchGetterByID
+	savedSearchStorer     SavedSearchStorer
+}
+
+func (su savedSearchUpdater) UpdateSavedSearch(
+	ctx context.Context,
+	savedSearchID int64,
+	req SavedSearchRequest,
+) (SavedSearch, error) {
+	err := su.validator.Validate(ctx, req)
+	if err != nil {
+		return SavedSearch{}, fmt.Errorf("validating saved search request: %w", err)
+	}
+
+	savedSearch, err := su.savedSearchGetterByID.GetSavedSearchByID(ctx, req.UserID, savedSearchID)
+	if err != nil {
+		return SavedSearch{}, fmt.Errorf("getting saved search by id: %w", err)
+	}
+
+	savedSearch.Name = req.Name
+	savedSearch.Description = req.Description
+	savedSearch.Location = req.Location
+	savedSearch.FullTime = req.FullTime
+	savedSearch.UpdatedAt = su.timer.Now()
+
+	err = su.savedSearchStorer.StoreSavedSearch(ctx, &savedSearch)
+	if err != nil {
+		return SavedSearch{}, fmt.Errorf("storing saved search: %w", err)
+	}
+
+	return savedSearch, nil
+}
+
+func NewSavedSearchUpdater(
+	validator Validator[SavedSearchRequest],
+	timer Timer,
+	savedSearchGetterByID SavedSearchGetterByID,
+	savedSearchStorer SavedSearchStorer,
+) *savedSearchUpdater {
+	return &savedSearchUpdater{
+		validator:             validator,
+		timer:                 timer,
+		savedSearchGetterByID: savedSearchGetterByID,
+		savedSearchStorer:     savedSearchStorer,
+	}
+}
+
+type savedSearchRunner struct {
+	validator             Validator[JobsListerOption]
+	timer                 Timer
+	savedSearchGetterByID SavedSearchGetterByID
+	savedSearchStorer     SavedSearchStorer
+	jobsLister            JobsLister
+}
+
+func (sr savedSearchRunner) RunSavedSearch(
+	ctx context.Context,
+	userID int64,
+	savedSearchID int64,
+	opts ...Option[JobsListerOption],
+) (JobsListResult, error) {
+	savedSearch, err := sr.savedSearchGetterByID.GetSavedSearchByID(ctx, userID, savedSearchID)
+	if err != nil {
+		return JobsListResult{}, fmt.Errorf("getting saved search by id: %w", err)
+	}
+
+	opts = append(savedSearch.JobsListerOptions(), opts...)
+	jobsListerOption := JobsListerOption{}
+
+	ApplyOptions(&jobsListerOption, opts...)
+
+	err = sr.validator.Validate(ctx, jobsListerOption)
+	if err != nil {
+		return JobsListResult{}, fmt.Errorf("validating jobs lister option: %w", err)
+	}
+
+	jobsListResult, err := sr.jobsLister.ListJobs(ctx, opts...)
+	if err != nil {
+		return JobsListResult{}, fmt.Errorf("listing jobs: %w", err)
+	}
+
+	savedSearch.LastRunAt = sr.timer.Now()
+
+	err = sr.savedSearchStorer.StoreSavedSearch(ctx, &savedSearch)
+	if err != nil {
+		return JobsListResult{}, fmt.Errorf("storing saved search: %w", err)
+	}
+
+	return jobsListResult, nil
+}
+
+func NewSavedSearchRunner(
+	validator Validator[JobsListerOption],
+	timer Timer,
+	savedSearchGetterByID SavedSearchGetterByID,
+	savedSearchStorer SavedSearchStorer,
+	jobsLister JobsLister,
+) *savedSearchRunner {
+	return &savedSearchRunner{
+		validator:             validator,
+		timer:                 timer,
+		savedSearchGetterByID: savedSearchGetterByID,
+		savedSearchStorer:     savedSearchStorer,
+		jobsLister:            jobsLister,
+	}
+}
+
+type savedSearchRequestValidator struct {
+	validate *validator.Validate
+}
+
+func (v savedSearchRequestValidator) EvaluateErrorAs(err, target error) error {
+	if errors.As(err, &validator.ValidationErrors{}) {
+		err = target
+	}
+
+	return err
+}
+
+func (v savedSearchRequestValidator) Validate(ctx context.Context, req SavedSearchRequest) error {
+	validationErrors := ValidationErrors{}
+
+	err := validationErrors.Collect(v.validateName(ctx, req.Name))
+	if err != nil {
+		return err
+	}
+
+	err = v.validate.VarCtx(ctx, req.Description, "max=255")
+	if err != nil {
+		err = validationErrors.Collect(v.EvaluateErrorAs(err, NewValidationError("description", "max=255")))
+		if err != nil {
+			return err
+		}
+	}
+
+	err = v.validate.VarCtx(ctx, req.Location, "max=255")
+	if err != nil {
+		err = validationErrors.Collect(v.EvaluateErrorAs(err, NewValidationError("location", "max=255")))
+		if err != nil {
+			return err
+		}
+	}
+
+	return validationErrors.Err()
+}
+
+func (v savedSearchRequestValidator) validateName(ctx context.Context, name string) error {
+	err := v.validate.VarCtx(ctx, name, "required")
+	if err != nil {
+		return v.EvaluateErrorAs(err, NewValidationError("name", "required"))
+	}
+
+	err = v.validate.VarCtx(ctx, name, "max=100")
+	if err != nil {
+		return v.EvaluateErrorAs(err, NewValidationError("name", "max=100"))
+	}
+
+	return nil
+}
+
+func NewSavedSearchRequestValidator(validate *validator.Validate) *savedSearchRequestValidator {
+	return &savedSearchRequestValidator{
+		validate: validate,
+	}
+}
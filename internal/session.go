@@ -0,0 +1,349 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrRefreshTokenNotFound = errors.New("refresh token not found")
+	ErrRefreshTokenInvalid  = errors.New("refresh token is invalid")
+	ErrRefreshTokenReused   = errors.New("refresh token has already been used")
+)
+
+type SessionIssuer interface {
+	IssueSession(ctx context.Context, user User, twoFactor bool) (Session, error)
+}
+
+type SessionIssuerInFamily interface {
+	IssueSessionInFamily(ctx context.Context, user User, twoFactor bool, familyID uuid.UUID) (Session, error)
+}
+
+type SessionRefresher interface {
+	RefreshSession(ctx context.Context, refreshToken string) (Session, error)
+}
+
+type RefreshTokenGetterByHash interface {
+	GetRefreshTokenByHash(ctx context.Context, hash string) (RefreshToken, error)
+}
+
+type RefreshTokenStorer interface {
+	StoreRefreshToken(ctx context.Context, refreshToken *RefreshToken) error
+}
+
+type RefreshTokenConsumer interface {
+	ConsumeRefreshToken(ctx context.Context, refreshTokenID int64, usedAt time.Time) error
+}
+
+type RefreshTokenFamilyRevoker interface {
+	RevokeRefreshTokenFamily(ctx context.Context, familyID uuid.UUID, revokedAt time.Time) error
+}
+
+type RefreshTokensRevokerByUserID interface {
+	RevokeRefreshTokensByUserID(ctx context.Context, userID int64, revokedAt time.Time) error
+}
+
+type AccessTokenRevoker interface {
+	RevokeAccessToken(ctx context.Context, accessTokenID string, userID int64, expiresAt time.Time) error
+}
+
+type AccessTokenRevocationChecker interface {
+	IsAccessTokenRevoked(ctx context.Context, accessTokenID string) (bool, error)
+}
+
+type UserSessionsRevoker interface {
+	RevokeUserSessions(ctx context.Context, userID int64) error
+}
+
+type SessionTerminator interface {
+	TerminateSession(ctx context.Context, req SessionTerminationRequest) error
+}
+
+type SessionTerminationRequest struct {
+	UserID               int64
+	AccessTokenID        string
+	AccessTokenExpiresAt time.Time
+	RefreshToken         string
+	All                  bool
+}
+
+type Session struct {
+	User                  User
+	TwoFactor             bool
+	RefreshToken          string
+	RefreshTokenExpiresAt time.Time
+}
+
+type RefreshToken struct {
+	ID        int64
+	UserID    int64
+	FamilyID  uuid.UUID
+	TwoFactor bool
+	Hash      string
+	ExpiresAt time.Time
+	UsedAt    time.Time
+	RevokedAt time.Time
+	CreatedAt time.Time
+}
+
+type sessionIssuer struct {
+	timer              Timer
+	tokenGenerator     TokenGenerator
+	lifeTime           time.Duration
+	refreshTokenStorer RefreshTokenStorer
+}
+
+func (si sessionIssuer) IssueSession(ctx context.Context, user User, twoFactor bool) (Session, error) {
+	return si.IssueSessionInFamily(ctx, user, twoFactor, uuid.New())
+}
+
+func (si sessionIssuer) IssueSessionInFamily(
+	ctx context.Context,
+	user User,
+	twoFactor bool,
+	familyID uuid.UUID,
+) (Session, error) {
+	token, err := si.tokenGenerator.GenerateToken()
+	if err != nil {
+		return Session{}, fmt.Errorf("generating refresh token: %w", err)
+	}
+
+	now := si.timer.Now()
+	refreshToken := RefreshToken{
+		UserID:    user.ID,
+		FamilyID:  familyID,
+		TwoFactor: twoFactor,
+		Hash:      HashToken(token),
+		ExpiresAt: now.Add(si.lifeTime),
+		CreatedAt: now,
+	}
+	err = si.refreshTokenStorer.StoreRefreshToken(ctx, &refreshToken)
+	if err != nil {
+		return Session{}, fmt.Errorf("storing refresh token: %w", err)
+	}
+
+	return Session{
+		User:                  user,
+		TwoFactor:             twoFactor,
+		RefreshToken:          token,
+		RefreshTokenExpiresAt: refreshToken.ExpiresAt,
+	}, nil
+}
+
+func NewSessionIssuer(
+	timer Timer,
+	tokenGenerator TokenGenerator,
+	lifeTime time.Duration,
+	refreshTokenStorer RefreshTokenStorer,
+) *sessionIssuer {
+	return &sessionIssuer{
+		timer:              timer,
+		tokenGenerator:     tokenGenerator,
+		lifeTime:           lifeTime,
+		refreshTokenStorer: refreshTokenStorer,
+	}
+}
+
+type sessionRefresher struct {
+	timer                     Timer
+	sessionIssuerInFamily     SessionIssuerInFamily
+	refreshTokenGetterByHash  RefreshTokenGetterByHash
+	refreshTokenConsumer      RefreshTokenConsumer
+	refreshTokenFamilyRevoker RefreshTokenFamilyRevoker
+	userGetterByID            UserGetterByID
+}
+
+func (sr sessionRefresher) RefreshSession(ctx context.Context, token string) (Session, error) {
+	if len(token) == 0 {
+		return Session{}, NewValidationError("refresh_token", "required")
+	}
+
+	refreshToken, err := sr.refreshTokenGetterByHash.GetRefreshTokenByHash(ctx, HashToken(token))
+	if err != nil {
+		err = fmt.Errorf("getting refresh token by hash: %w", err)
+
+		if errors.Is(err, ErrRefreshTokenNotFound) {
+			err = ErrRefreshTokenInvalid
+		}
+
+		return Session{}, err
+	}
+
+	if !refreshToken.RevokedAt.IsZero() {
+		return Session{}, ErrRefreshTokenInvalid
+	}
+
+	now := sr.timer.Now()
+
+	if !refreshToken.UsedAt.IsZero() {
+		return Session{}, sr.revokeFamily(ctx, refreshToken, now)
+	}
+
+	if !now.Before(refreshToken.ExpiresAt) {
+		return Session{}, ErrRefreshTokenInvalid
+	}
+
+	err = sr.refreshTokenConsumer.ConsumeRefreshToken(ctx, refreshToken.ID, now)
+	if err != nil {
+		if errors.Is(err, ErrRefreshTokenReused) {
+			return Session{}, sr.revokeFamily(ctx, refreshToken, now)
+		}
+
+		return Session{}, fmt.Errorf("consuming refresh token: %w", err)
+	}
+
+	user, err := sr.userGetterByID.GetUserByID(ctx, refreshToken.UserID)
+	if err != nil {
+		err = fmt.Errorf("getting user by id: %w", err)
+
+		if errors.Is(err, ErrUserNotFound) {
+			err = ErrRefreshTokenInvalid
+		}
+
+		return Session{}, err
+	}
+
+	if user.Disabled() {
+		return Session{}, ErrUserDisabled
+	}
+
+	session, err := sr.sessionIssuerInFamily.IssueSessionInFamily(ctx, user, refreshToken.TwoFactor, refreshToken.FamilyID)
+	if err != nil {
+		return Session{}, fmt.Errorf("issuing session in refresh token family: %w", err)
+	}
+
+	return session, nil
+}
+
+func (sr sessionRefresher) revokeFamily(ctx context.Context, refreshToken RefreshToken, now time.Time) error {
+	err := sr.refreshTokenFamilyRevoker.RevokeRefreshTokenFamily(ctx, refreshToken.FamilyID, now)
+	if err != nil {
+		return fmt.Errorf("revoking refresh token family: %w", err)
+	}
+
+	return ErrRefreshTokenReused
+}
+
+func NewSessionRefresher(
+	timer Timer,
+	sessionIssuerInFamily SessionIssuerInFamily,
+	refreshTokenGetterByHash RefreshTokenGetterByHash,
+	refreshTokenConsumer RefreshTokenConsumer,
+	refreshTokenFamilyRevoker RefreshTokenFamilyRevoker,
+	userGetterByID UserGetterByID,
+) *sessionRefresher {
+	return &sessionRefresher{
+		timer:                     timer,
+		sessionIssuerInFamily:     sessionIssuerInFamily,
+		refreshTokenGetterByHash:  refreshTokenGetterByHash,
+		refreshTokenConsumer:      refreshTokenConsumer,
+		refreshTokenFamilyRevoker: refreshTokenFamilyRevoker,
+		userGetterByID:            userGetterByID,
+	}
+}
+
+type userSessionsRevoker struct {
+	timer                        Timer
+	userTokenVersionIncrementer  UserTokenVersionIncrementer
+	refreshTokensRevokerByUserID RefreshTokensRevokerByUserID
+}
+
+func (ur userSessionsRevoker) RevokeUserSessions(ctx context.Context, userID int64) error {
+	err := ur.userTokenVersionIncrementer.IncrementUserTokenVersion(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("incrementing user token version: %w", err)
+	}
+
+	err = ur.refreshTokensRevokerByUserID.RevokeRefreshTokensByUserID(ctx, userID, ur.timer.Now())
+	if err != nil {
+		return fmt.Errorf("revoking refresh tokens by user id: %w", err)
+	}
+
+	return nil
+}
+
+func NewUserSessionsRevoker(
+	timer Timer,
+	userTokenVersionIncrementer UserTokenVersionIncrementer,
+	refreshTokensRevokerByUserID RefreshTokensRevokerByUserID,
+) *userSessionsRevoker {
+	return &userSessionsRevoker{
+		timer:                        timer,
+		userTokenVersionIncrementer:  userTokenVersionIncrementer,
+		refreshTokensRevokerByUserID: refreshTokensRevokerByUserID,
+	}
+}
+
+type sessionTerminator struct {
+	timer                     Timer
+	accessTokenRevoker        AccessTokenRevoker
+	refreshTokenGetterByHash  RefreshTokenGetterByHash
+	refreshTokenFamilyRevoker RefreshTokenFamilyRevoker
+	userSessionsRevoker       UserSessionsRevoker
+}
+
+func (st sessionTerminator) TerminateSession(ctx context.Context, req SessionTerminationRequest) error {
+	if req.All {
+		err := st.userSessionsRevoker.RevokeUserSessions(ctx, req.UserID)
+		if err != nil {
+			return fmt.Errorf("revoking user sessions: %w", err)
+		}
+
+		return nil
+	}
+
+	var refreshToken RefreshToken
+
+	if len(req.RefreshToken) > 0 {
+		var err error
+
+		refreshToken, err = st.refreshTokenGetterByHash.GetRefreshTokenByHash(ctx, HashToken(req.RefreshToken))
+		if err != nil {
+			if errors.Is(err, ErrRefreshTokenNotFound) {
+				return NewValidationError("refresh_token", "exists")
+			}
+
+			return fmt.Errorf("getting refresh token by hash: %w", err)
+		}
+
+		if refreshToken.UserID != req.UserID {
+			return NewValidationError("refresh_token", "exists")
+		}
+	}
+
+	if len(req.AccessTokenID) > 0 {
+		err := st.accessTokenRevoker.RevokeAccessToken(ctx, req.AccessTokenID, req.UserID, req.AccessTokenExpiresAt)
+		if err != nil {
+			return fmt.Errorf("revoking access token: %w", err)
+		}
+	}
+
+	if len(req.RefreshToken) > 0 {
+		err := st.refreshTokenFamilyRevoker.RevokeRefreshTokenFamily(ctx, refreshToken.FamilyID, st.timer.Now())
+		if err != nil {
+			return fmt.Errorf("revoking refresh token family: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func NewSessionTerminator(
+	timer Timer,
+	accessTokenRevoker AccessTokenRevoker,
+	refreshTokenGetterByHash RefreshTokenGetterByHash,
+	refreshTokenFamilyRevoker RefreshTokenFamilyRevoker,
+	userSessionsRevoker UserSessionsRevoker,
+) *sessionTerminator {
+	return &sessionTerminator{
+		timer:                     timer,
+		accessTokenRevoker:        accessTokenRevoker,
+		refreshTokenGetterByHash:  refreshTokenGetterByHash,
+		refreshTokenFamilyRevoker: refreshTokenFamilyRevoker,
+		userSessionsRevoker:       userSessionsRevoker,
+	}
+}
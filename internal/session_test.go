@@ -0,0 +1,122 @@
+package internal_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/adystag/jobs-search/internal"
+
+	"github.com/google/uuid"
+)
+
+type recordingRefreshTokenRepository struct {
+	refreshTokens       map[string]internal.RefreshToken
+	revokedFamilies     []uuid.UUID
+	revokedAccessTokens []string
+}
+
+func (r *recordingRefreshTokenRepository) GetRefreshTokenByHash(
+	ctx context.Context,
+	hash string,
+) (internal.RefreshToken, error) {
+	refreshToken, ok := r.refreshTokens[hash]
+	if !ok {
+		return internal.RefreshToken{}, internal.ErrRefreshTokenNotFound
+	}
+
+	return refreshToken, nil
+}
+
+func (r *recordingRefreshTokenRepository) RevokeRefreshTokenFamily(
+	ctx context.Context,
+	familyID uuid.UUID,
+	revokedAt time.Time,
+) error {
+	r.revokedFamilies = append(r.revokedFamilies, familyID)
+
+	return nil
+}
+
+func (r *recordingRefreshTokenRepository) RevokeAccessToken(
+	ctx context.Context,
+	accessTokenID string,
+	userID int64,
+	expiresAt time.Time,
+) error {
+	r.revokedAccessTokens = append(r.revokedAccessTokens, accessTokenID)
+
+	return nil
+}
+
+func TestSessionTerminatorRefreshTokenOwnership(t *testing.T) {
+	familyID := uuid.New()
+
+	tests := []struct {
+		name         string
+		refreshToken string
+		wantTag      string
+		wantRevoked  bool
+	}{
+		{
+			name:         "own refresh token",
+			refreshToken: "own",
+			wantRevoked:  true,
+		},
+		{
+			name:         "another user refresh token",
+			refreshToken: "another",
+			wantTag:      "exists",
+		},
+		{
+			name:         "unknown refresh token",
+			refreshToken: "unknown",
+			wantTag:      "exists",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repository := &recordingRefreshTokenRepository{
+				refreshTokens: map[string]internal.RefreshToken{
+					internal.HashToken("own"):     {UserID: 1, FamilyID: familyID},
+					internal.HashToken("another"): {UserID: 2, FamilyID: uuid.New()},
+				},
+			}
+			terminator := internal.NewSessionTerminator(
+				fixedTimer{now: time.Now()},
+				repository,
+				repository,
+				repository,
+				nil,
+			)
+
+			err := terminator.TerminateSession(context.Background(), internal.SessionTerminationRequest{
+				UserID:        1,
+				AccessTokenID: "access-token",
+				RefreshToken:  tt.refreshToken,
+			})
+
+			if len(tt.wantTag) == 0 {
+				if err != nil {
+					t.Fatalf("TerminateSession() error = %v, want nil", err)
+				}
+			} else {
+				var validationError internal.ValidationError
+
+				if !errors.As(err, &validationError) || validationError.Tag() != tt.wantTag {
+					t.Fatalf("TerminateSession() error = %v, want refresh_token:%s", err, tt.wantTag)
+				}
+			}
+
+			if revoked := len(repository.revokedFamilies) > 0; revoked != tt.wantRevoked {
+				t.Errorf("refresh token family revoked = %t, want %t", revoked, tt.wantRevoked)
+			}
+
+			if revoked := len(repository.revokedAccessTokens) > 0; revoked != tt.wantRevoked {
+				t.Errorf("access token revoked = %t, want %t", revoked, tt.wantRevoked)
+			}
+		})
+	}
+}
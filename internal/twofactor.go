@@ -0,0 +1,704 @@
+package internal
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrTwoFactorAlreadyEnabled    = errors.New("two factor authentication is already enabled")
+	ErrTwoFactorNotEnabled        = errors.New("two factor authentication is not enabled")
+	ErrTwoFactorRequired          = errors.New("two factor authentication is required")
+	ErrTwoFactorNotEnrolled       = errors.New("two factor authentication is not enrolled")
+	ErrTwoFactorCodeInvalid       = errors.New("two factor code is invalid")
+	ErrTwoFactorChallengeNotFound = errors.New("two factor challenge not found")
+	ErrTwoFactorChallengeInvalid  = errors.New("two factor challenge is invalid")
+	ErrTOTPSecretNotFound         = errors.New("totp secret not found")
+)
+
+const (
+	totpDigits     = 6
+	totpPeriod     = 30 * time.Second
+	totpSkew       = 1
+	totpSecretSize = 20
+)
+
+var (
+	totpSecretEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+	totpCodePattern    = regexp.MustCompile(`^[0-9]{6}$`)
+)
+
+type TwoFactorEnroller interface {
+	EnrollTwoFactor(ctx context.Context, req TwoFactorEnrollmentRequest) (TwoFactorEnrollment, error)
+}
+
+type TwoFactorConfirmer interface {
+	ConfirmTwoFactor(ctx context.Context, req TwoFactorConfirmationRequest) ([]string, error)
+}
+
+type TwoFactorDisabler interface {
+	DisableTwoFactor(ctx context.Context, req TwoFactorDisableRequest) error
+}
+
+type TwoFactorChallengeIssuer interface {
+	IssueTwoFactorChallenge(ctx context.Context, user User) (TwoFactorChallenge, string, error)
+}
+
+type TwoFactorAuthenticator interface {
+	AuthenticateTwoFactor(ctx context.Context, req TwoFactorAuthenticationRequest) (User, error)
+}
+
+type UserTwoFactorUpdater interface {
+	UpdateUserTwoFactor(ctx context.Context, userID int64, twoFactorEnabledAt time.Time, updatedAt time.Time) error
+}
+
+type TOTPSecretGetterByUserID interface {
+	GetTOTPSecretByUserID(ctx context.Context, userID int64) (TOTPSecret, error)
+}
+
+type TOTPSecretStorer interface {
+	StoreTOTPSecret(ctx context.Context, totpSecret *TOTPSecret) error
+}
+
+type TOTPSecretDeleter interface {
+	DeleteTOTPSecret(ctx context.Context, userID int64) error
+}
+
+type TOTPStepConsumer interface {
+	ConsumeTOTPStep(ctx context.Context, userID int64, step int64) error
+}
+
+type RecoveryCodesReplacer interface {
+	ReplaceRecoveryCodes(ctx context.Context, userID int64, recoveryCodes []RecoveryCode) error
+}
+
+type RecoveryCodeConsumer interface {
+	ConsumeRecoveryCode(ctx context.Context, userID int64, hash string, usedAt time.Time) error
+}
+
+type TwoFactorChallengeGetterByHash interface {
+	GetTwoFactorChallengeByHash(ctx context.Context, hash string) (TwoFactorChallenge, error)
+}
+
+type TwoFactorChallengeStorer interface {
+	StoreTwoFactorChallenge(ctx context.Context, twoFactorChallenge *TwoFactorChallenge) error
+}
+
+type TwoFactorChallengeAttemptRecorder interface {
+	RecordTwoFactorChallengeAttempt(ctx context.Context, twoFactorChallengeID int64) error
+}
+
+type TwoFactorChallengeConsumer interface {
+	ConsumeTwoFactorChallenge(ctx context.Context, twoFactorChallengeID int64, usedAt time.Time) error
+}
+
+type TwoFactorEnrollmentRequest struct {
+	UserID   int64
+	Password string
+}
+
+type TwoFactorConfirmationRequest struct {
+	UserID int64
+	Code   string
+}
+
+type TwoFactorDisableRequest struct {
+	UserID   int64
+	Password string
+}
+
+type TwoFactorAuthenticationRequest struct {
+	ChallengeToken string
+	Code           string
+}
+
+type TwoFactorEnrollment struct {
+	Secret string
+	URI    string
+}
+
+type TOTPSecret struct {
+	UserID       int64
+	Secret       string
+	LastUsedStep int64
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+type RecoveryCode struct {
+	ID        int64
+	UserID    int64
+	Hash      string
+	UsedAt    time.Time
+	CreatedAt time.Time
+}
+
+type TwoFactorChallenge struct {
+	ID        int64
+	UserID    int64
+	Hash      string
+	Attempts  int
+	ExpiresAt time.Time
+	UsedAt    time.Time
+	CreatedAt time.Time
+}
+
+func GenerateTOTPCode(secret []byte, step int64) string {
+	counter := make([]byte, 8)
+
+	binary.BigEndian.PutUint64(counter, uint64(step))
+
+	mac := hmac.New(sha1.New, secret)
+
+	mac.Write(counter)
+
+	sum := mac.Sum(nil)
+	offset := sum[len(sum)-1] & 0x0f
+	code := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	return fmt.Sprintf("%0*d", totpDigits, code%1000000)
+}
+
+func TOTPStep(t time.Time) int64 {
+	return t.Unix() / int64(totpPeriod/time.Second)
+}
+
+func totpURI(issuer, username, secret string) string {
+	values := url.Values{}
+
+	values.Set("secret", secret)
+	values.Set("issuer", issuer)
+	values.Set("algorithm", "SHA1")
+	values.Set("digits", strconv.Itoa(totpDigits))
+	values.Set("period", strconv.Itoa(int(totpPeriod/time.Second)))
+
+	return fmt.Sprintf(
+		"otpauth://totp/%s?%s",
+		url.PathEscape(issuer+":"+username),
+		strings.ReplaceAll(values.Encode(), "+", "%20"),
+	)
+}
+
+func normalizeRecoveryCode(code string) string {
+	return strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+}
+
+func verifyTOTPCode(
+	ctx context.Context,
+	cipher Cipher,
+	totpSecretGetterByUserID TOTPSecretGetterByUserID,
+	totpStepConsumer TOTPStepConsumer,
+	userID int64,
+	code string,
+	now time.Time,
+) error {
+	if !totpCodePattern.MatchString(code) {
+		return ErrTwoFactorCodeInvalid
+	}
+
+	totpSecret, err := totpSecretGetterByUserID.GetTOTPSecretByUserID(ctx, userID)
+	if err != nil {
+		err = fmt.Errorf("getting totp secret by user id: %w", err)
+
+		if errors.Is(err, ErrTOTPSecretNotFound) {
+			err = ErrTwoFactorNotEnrolled
+		}
+
+		return err
+	}
+
+	secret, err := cipher.Decrypt(totpSecret.Secret)
+	if err != nil {
+		return fmt.Errorf("decrypting totp secret: %w", err)
+	}
+
+	key, err := totpSecretEncoding.DecodeString(secret)
+	if err != nil {
+		return fmt.Errorf("decoding totp secret: %w", err)
+	}
+
+	current := TOTPStep(now)
+
+	for step := current - totpSkew; step <= current+totpSkew; step++ {
+		if step <= totpSecret.LastUsedStep {
+			continue
+		}
+
+		if subtle.ConstantTimeCompare([]byte(GenerateTOTPCode(key, step)), []byte(code)) != 1 {
+			continue
+		}
+
+		err = totpStepConsumer.ConsumeTOTPStep(ctx, userID, step)
+		if err != nil {
+			return fmt.Errorf("consuming totp step: %w", err)
+		}
+
+		return nil
+	}
+
+	return ErrTwoFactorCodeInvalid
+}
+
+type twoFactorEnroller struct {
+	timer            Timer
+	issuer           string
+	comparator       Comparator
+	cipher           Cipher
+	userGetterByID   UserGetterByID
+	totpSecretStorer TOTPSecretStorer
+}
+
+func (te twoFactorEnroller) EnrollTwoFactor(
+	ctx context.Context,
+	req TwoFactorEnrollmentRequest,
+) (TwoFactorEnrollment, error) {
+	if len(req.Password) == 0 {
+		return TwoFactorEnrollment{}, NewValidationError("password", "required")
+	}
+
+	user, err := te.userGetterByID.GetUserByID(ctx, req.UserID)
+	if err != nil {
+		return TwoFactorEnrollment{}, fmt.Errorf("getting user by id: %w", err)
+	}
+
+	err = te.comparator.Compare(user.Password, req.Password)
+	if err != nil {
+		if errors.Is(err, ErrHashMismatched) {
+			return TwoFactorEnrollment{}, NewValidationError("password", "password")
+		}
+
+		return TwoFactorEnrollment{}, fmt.Errorf("comparing hashed with plain user password: %w", err)
+	}
+
+	if user.TwoFactorEnabled() {
+		return TwoFactorEnrollment{}, ErrTwoFactorAlreadyEnabled
+	}
+
+	key := make([]byte, totpSecretSize)
+
+	_, err = rand.Read(key)
+	if err != nil {
+		return TwoFactorEnrollment{}, fmt.Errorf("reading random bytes: %w", err)
+	}
+
+	secret := totpSecretEncoding.EncodeToString(key)
+
+	encryptedSecret, err := te.cipher.Encrypt(secret)
+	if err != nil {
+		return TwoFactorEnrollment{}, fmt.Errorf("encrypting totp secret: %w", err)
+	}
+
+	now := te.timer.Now()
+	totpSecret := TOTPSecret{
+		UserID:    user.ID,
+		Secret:    encryptedSecret,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	err = te.totpSecretStorer.StoreTOTPSecret(ctx, &totpSecret)
+	if err != nil {
+		return TwoFactorEnrollment{}, fmt.Errorf("storing totp secret: %w", err)
+	}
+
+	return TwoFactorEnrollment{
+		Secret: secret,
+		URI:    totpURI(te.issuer, user.Username, secret),
+	}, nil
+}
+
+func NewTwoFactorEnroller(
+	timer Timer,
+	issuer string,
+	comparator Comparator,
+	cipher Cipher,
+	userGetterByID UserGetterByID,
+	totpSecretStorer TOTPSecretStorer,
+) *twoFactorEnroller {
+	return &twoFactorEnroller{
+		timer:            timer,
+		issuer:           issuer,
+		comparator:       comparator,
+		cipher:           cipher,
+		userGetterByID:   userGetterByID,
+		totpSecretStorer: totpSecretStorer,
+	}
+}
+
+type twoFactorConfirmer struct {
+	timer                    Timer
+	tokenGenerator           TokenGenerator
+	recoveryCodeCount        int
+	cipher                   Cipher
+	userGetterByID           UserGetterByID
+	userTwoFactorUpdater     UserTwoFactorUpdater
+	totpSecretGetterByUserID TOTPSecretGetterByUserID
+	totpStepConsumer         TOTPStepConsumer
+	recoveryCodesReplacer    RecoveryCodesReplacer
+	userSessionsRevoker      UserSessionsRevoker
+}
+
+func (tc twoFactorConfirmer) ConfirmTwoFactor(ctx context.Context, req TwoFactorConfirmationRequest) ([]string, error) {
+	if len(req.Code) == 0 {
+		return nil, NewValidationError("code", "required")
+	}
+
+	user, err := tc.userGetterByID.GetUserByID(ctx, req.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("getting user by id: %w", err)
+	}
+
+	if user.TwoFactorEnabled() {
+		return nil, ErrTwoFactorAlreadyEnabled
+	}
+
+	now := tc.timer.Now()
+
+	err = verifyTOTPCode(ctx, tc.cipher, tc.totpSecretGetterByUserID, tc.totpStepConsumer, user.ID, req.Code, now)
+	if err != nil {
+		if errors.Is(err, ErrTwoFactorCodeInvalid) {
+			return nil, NewValidationError("code", "totp")
+		}
+
+		return nil, fmt.Errorf("verifying totp code: %w", err)
+	}
+
+	codes := []string{}
+	recoveryCodes := []RecoveryCode{}
+
+	for len(codes) < tc.recoveryCodeCount {
+		token, err := tc.tokenGenerator.GenerateToken()
+		if err != nil {
+			return nil, fmt.Errorf("generating recovery code: %w", err)
+		}
+
+		token = strings.ToLower(nonAlphanumeric.ReplaceAllString(token, ""))
+
+		if len(token) < 10 {
+			continue
+		}
+
+		code := token[:5] + "-" + token[5:10]
+
+		codes = append(codes, code)
+		recoveryCodes = append(recoveryCodes, RecoveryCode{
+			UserID:    user.ID,
+			Hash:      HashToken(normalizeRecoveryCode(code)),
+			CreatedAt: now,
+		})
+	}
+
+	err = tc.recoveryCodesReplacer.ReplaceRecoveryCodes(ctx, user.ID, recoveryCodes)
+	if err != nil {
+		return nil, fmt.Errorf("replacing recovery codes: %w", err)
+	}
+
+	err = tc.userTwoFactorUpdater.UpdateUserTwoFactor(ctx, user.ID, now, now)
+	if err != nil {
+		return nil, fmt.Errorf("updating user two factor: %w", err)
+	}
+
+	err = tc.userSessionsRevoker.RevokeUserSessions(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("revoking user sessions: %w", err)
+	}
+
+	return codes, nil
+}
+
+func NewTwoFactorConfirmer(
+	timer Timer,
+	tokenGenerator TokenGenerator,
+	recoveryCodeCount int,
+	cipher Cipher,
+	userGetterByID UserGetterByID,
+	userTwoFactorUpdater UserTwoFactorUpdater,
+	totpSecretGetterByUserID TOTPSecretGetterByUserID,
+	totpStepConsumer TOTPStepConsumer,
+	recoveryCodesReplacer RecoveryCodesReplacer,
+	userSessionsRevoker UserSessionsRevoker,
+) *twoFactorConfirmer {
+	return &twoFactorConfirmer{
+		timer:                    timer,
+		tokenGenerator:           tokenGenerator,
+		recoveryCodeCount:        recoveryCodeCount,
+		cipher:                   cipher,
+		userGetterByID:           userGetterByID,
+		userTwoFactorUpdater:     userTwoFactorUpdater,
+		totpSecretGetterByUserID: totpSecretGetterByUserID,
+		totpStepConsumer:         totpStepConsumer,
+		recoveryCodesReplacer:    recoveryCodesReplacer,
+		userSessionsRevoker:      userSessionsRevoker,
+	}
+}
+
+type twoFactorDisabler struct {
+	timer                 Timer
+	comparator            Comparator
+	userGetterByID        UserGetterByID
+	userTwoFactorUpdater  UserTwoFactorUpdater
+	totpSecretDeleter     TOTPSecretDeleter
+	recoveryCodesReplacer RecoveryCodesReplacer
+}
+
+func (td twoFactorDisabler) DisableTwoFactor(ctx context.Context, req TwoFactorDisableRequest) error {
+	if len(req.Password) == 0 {
+		return NewValidationError("password", "required")
+	}
+
+	user, err := td.userGetterByID.GetUserByID(ctx, req.UserID)
+	if err != nil {
+		return fmt.Errorf("getting user by id: %w", err)
+	}
+
+	err = td.comparator.Compare(user.Password, req.Password)
+	if err != nil {
+		if errors.Is(err, ErrHashMismatched) {
+			return NewValidationError("password", "password")
+		}
+
+		return fmt.Errorf("comparing hashed with plain user password: %w", err)
+	}
+
+	if !user.TwoFactorEnabled() {
+		return ErrTwoFactorNotEnabled
+	}
+
+	err = td.userTwoFactorUpdater.UpdateUserTwoFactor(ctx, user.ID, time.Time{}, td.timer.Now())
+	if err != nil {
+		return fmt.Errorf("updating user two factor: %w", err)
+	}
+
+	err = td.totpSecretDeleter.DeleteTOTPSecret(ctx, user.ID)
+	if err != nil {
+		return fmt.Errorf("deleting totp secret: %w", err)
+	}
+
+	err = td.recoveryCodesReplacer.ReplaceRecoveryCodes(ctx, user.ID, nil)
+	if err != nil {
+		return fmt.Errorf("replacing recovery codes: %w", err)
+	}
+
+	return nil
+}
+
+func NewTwoFactorDisabler(
+	timer Timer,
+	comparator Comparator,
+	userGetterByID UserGetterByID,
+	userTwoFactorUpdater UserTwoFactorUpdater,
+	totpSecretDeleter TOTPSecretDeleter,
+	recoveryCodesReplacer RecoveryCodesReplacer,
+) *twoFactorDisabler {
+	return &twoFactorDisabler{
+		timer:                 timer,
+		comparator:            comparator,
+		userGetterByID:        userGetterByID,
+		userTwoFactorUpdater:  userTwoFactorUpdater,
+		totpSecretDeleter:     totpSecretDeleter,
+		recoveryCodesReplacer: recoveryCodesReplacer,
+	}
+}
+
+type twoFactorChallengeIssuer struct {
+	timer                    Timer
+	tokenGenerator           TokenGenerator
+	lifeTime                 time.Duration
+	twoFactorChallengeStorer TwoFactorChallengeStorer
+}
+
+func (ti twoFactorChallengeIssuer) IssueTwoFactorChallenge(
+	ctx context.Context,
+	user User,
+) (TwoFactorChallenge, string, error) {
+	token, err := ti.tokenGenerator.GenerateToken()
+	if err != nil {
+		return TwoFactorChallenge{}, "", fmt.Errorf("generating two factor challenge token: %w", err)
+	}
+
+	now := ti.timer.Now()
+	twoFactorChallenge := TwoFactorChallenge{
+		UserID:    user.ID,
+		Hash:      HashToken(token),
+		ExpiresAt: now.Add(ti.lifeTime),
+		CreatedAt: now,
+	}
+	err = ti.twoFactorChallengeStorer.StoreTwoFactorChallenge(ctx, &twoFactorChallenge)
+	if err != nil {
+		return TwoFactorChallenge{}, "", fmt.Errorf("storing two factor challenge: %w", err)
+	}
+
+	return twoFactorChallenge, token, nil
+}
+
+func NewTwoFactorChallengeIssuer(
+	timer Timer,
+	tokenGenerator TokenGenerator,
+	lifeTime time.Duration,
+	twoFactorChallengeStorer TwoFactorChallengeStorer,
+) *twoFactorChallengeIssuer {
+	return &twoFactorChallengeIssuer{
+		timer:                    timer,
+		tokenGenerator:           tokenGenerator,
+		lifeTime:                 lifeTime,
+		twoFactorChallengeStorer: twoFactorChallengeStorer,
+	}
+}
+
+type twoFactorAuthenticator struct {
+	timer                             Timer
+	maxAttempts                       int
+	cipher                            Cipher
+	twoFactorChallengeGetterByHash    TwoFactorChallengeGetterByHash
+	twoFactorChallengeAttemptRecorder TwoFactorChallengeAttemptRecorder
+	twoFactorChallengeConsumer        TwoFactorChallengeConsumer
+	userGetterByID                    UserGetterByID
+	totpSecretGetterByUserID          TOTPSecretGetterByUserID
+	totpStepConsumer                  TOTPStepConsumer
+	recoveryCodeConsumer              RecoveryCodeConsumer
+	userLoginThrottler                UserLoginThrottler
+}
+
+func (ta twoFactorAuthenticator) AuthenticateTwoFactor(
+	ctx context.Context,
+	req TwoFactorAuthenticationRequest,
+) (User, error) {
+	if len(req.ChallengeToken) == 0 {
+		return User{}, NewValidationError("challenge_token", "required")
+	}
+
+	if len(req.Code) == 0 {
+		return User{}, NewValidationError("code", "required")
+	}
+
+	twoFactorChallenge, err := ta.twoFactorChallengeGetterByHash.GetTwoFactorChallengeByHash(
+		ctx,
+		HashToken(req.ChallengeToken),
+	)
+	if err != nil {
+		err = fmt.Errorf("getting two factor challenge by hash: %w", err)
+
+		if errors.Is(err, ErrTwoFactorChallengeNotFound) {
+			err = ErrTwoFactorChallengeInvalid
+		}
+
+		return User{}, err
+	}
+
+	now := ta.timer.Now()
+
+	if !twoFactorChallenge.UsedAt.IsZero() ||
+		!now.Before(twoFactorChallenge.ExpiresAt) ||
+		twoFactorChallenge.Attempts >= ta.maxAttempts {
+		return User{}, ErrTwoFactorChallengeInvalid
+	}
+
+	user, err := ta.userGetterByID.GetUserByID(ctx, twoFactorChallenge.UserID)
+	if err != nil {
+		err = fmt.Errorf("getting user by id: %w", err)
+
+		if errors.Is(err, ErrUserNotFound) {
+			err = ErrTwoFactorChallengeInvalid
+		}
+
+		return User{}, err
+	}
+
+	if user.Disabled() {
+		return User{}, ErrUserDisabled
+	}
+
+	if !user.TwoFactorEnabled() {
+		return User{}, ErrTwoFactorChallengeInvalid
+	}
+
+	err = ta.userLoginThrottler.CheckUserLoginThrottle(ctx, user.Username)
+	if err != nil {
+		return User{}, fmt.Errorf("checking user login throttle: %w", err)
+	}
+
+	err = ta.verify(ctx, user, req.Code, now)
+	if err != nil {
+		if !errors.Is(err, ErrTwoFactorCodeInvalid) {
+			return User{}, fmt.Errorf("verifying two factor code: %w", err)
+		}
+
+		recordErr := ta.twoFactorChallengeAttemptRecorder.RecordTwoFactorChallengeAttempt(ctx, twoFactorChallenge.ID)
+		if recordErr != nil {
+			return User{}, fmt.Errorf("recording two factor challenge attempt: %w", recordErr)
+		}
+
+		recordErr = ta.userLoginThrottler.RecordUserLoginFailure(ctx, user.Username)
+		if recordErr != nil {
+			return User{}, fmt.Errorf("recording user login failure: %w", recordErr)
+		}
+
+		return User{}, err
+	}
+
+	err = ta.twoFactorChallengeConsumer.ConsumeTwoFactorChallenge(ctx, twoFactorChallenge.ID, now)
+	if err != nil {
+		return User{}, fmt.Errorf("consuming two factor challenge: %w", err)
+	}
+
+	err = ta.userLoginThrottler.ResetUserLoginThrottle(ctx, user.Username)
+	if err != nil {
+		return User{}, fmt.Errorf("resetting user login throttle: %w", err)
+	}
+
+	return user, nil
+}
+
+func (ta twoFactorAuthenticator) verify(ctx context.Context, user User, code string, now time.Time) error {
+	code = strings.TrimSpace(code)
+
+	if totpCodePattern.MatchString(code) {
+		return verifyTOTPCode(ctx, ta.cipher, ta.totpSecretGetterByUserID, ta.totpStepConsumer, user.ID, code, now)
+	}
+
+	err := ta.recoveryCodeConsumer.ConsumeRecoveryCode(ctx, user.ID, HashToken(normalizeRecoveryCode(code)), now)
+	if err != nil {
+		return fmt.Errorf("consuming recovery code: %w", err)
+	}
+
+	return nil
+}
+
+func NewTwoFactorAuthenticator(
+	timer Timer,
+	maxAttempts int,
+	cipher Cipher,
+	twoFactorChallengeGetterByHash TwoFactorChallengeGetterByHash,
+	twoFactorChallengeAttemptRecorder TwoFactorChallengeAttemptRecorder,
+	twoFactorChallengeConsumer TwoFactorChallengeConsumer,
+	userGetterByID UserGetterByID,
+	totpSecretGetterByUserID TOTPSecretGetterByUserID,
+	totpStepConsumer TOTPStepConsumer,
+	recoveryCodeConsumer RecoveryCodeConsumer,
+	userLoginThrottler UserLoginThrottler,
+) *twoFactorAuthenticator {
+	return &twoFactorAuthenticator{
+		timer:                             timer,
+		maxAttempts:                       maxAttempts,
+		cipher:                            cipher,
+		twoFactorChallengeGetterByHash:    twoFactorChallengeGetterByHash,
+		twoFactorChallengeAttemptRecorder: twoFactorChallengeAttemptRecorder,
+		twoFactorChallengeConsumer:        twoFactorChallengeConsumer,
+		userGetterByID:                    userGetterByID,
+		totpSecretGetterByUserID:          totpSecretGetterByUserID,
+		totpStepConsumer:                  totpStepConsumer,
+		recoveryCodeConsumer:              recoveryCodeConsumer,
+		userLoginThrottler:                userLoginThrottler,
+	}
+}
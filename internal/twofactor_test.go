@@ -0,0 +1,258 @@
+package internal_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/adystag/jobs-search/internal"
+)
+
+type fixedTimer struct {
+	now time.Time
+}
+
+func (t fixedTimer) Now() time.Time {
+	return t.now
+}
+
+type memoryLoginThrottleRepository struct {
+	throttles map[internal.LoginThrottleKey]internal.LoginThrottle
+}
+
+func (r *memoryLoginThrottleRepository) GetLoginThrottle(
+	ctx context.Context,
+	key internal.LoginThrottleKey,
+) (internal.LoginThrottle, error) {
+	throttle := r.throttles[key]
+	throttle.Key = key
+
+	return throttle, nil
+}
+
+func (r *memoryLoginThrottleRepository) RecordLoginFailure(
+	ctx context.Context,
+	key internal.LoginThrottleKey,
+	failedAt, windowStart time.Time,
+) (internal.LoginThrottle, error) {
+	throttle := r.throttles[key]
+	throttle.Key = key
+
+	if throttle.LastFailedAt.Before(windowStart) {
+		throttle.Failures = 0
+	}
+
+	throttle.Failures++
+	throttle.LastFailedAt = failedAt
+	r.throttles[key] = throttle
+
+	return throttle, nil
+}
+
+func (r *memoryLoginThrottleRepository) LockLogin(
+	ctx context.Context,
+	key internal.LoginThrottleKey,
+	lockedUntil time.Time,
+) error {
+	throttle := r.throttles[key]
+	throttle.LockedUntil = lockedUntil
+	r.throttles[key] = throttle
+
+	return nil
+}
+
+func (r *memoryLoginThrottleRepository) ResetLoginThrottle(ctx context.Context, key internal.LoginThrottleKey) error {
+	delete(r.throttles, key)
+
+	return nil
+}
+
+func (r *memoryLoginThrottleRepository) AuditLoginAttempt(ctx context.Context, attempt internal.LoginAttempt) error {
+	return nil
+}
+
+type fixedUserRepository struct {
+	user internal.User
+}
+
+func (r fixedUserRepository) AuthenticateUser(
+	ctx context.Context,
+	req internal.UserAuthenticationRequest,
+) (internal.User, error) {
+	return r.user, nil
+}
+
+func (r fixedUserRepository) GetUserByID(ctx context.Context, userID int64) (internal.User, error) {
+	return r.user, nil
+}
+
+type fixedTwoFactorChallengeRepository struct {
+	challenge internal.TwoFactorChallenge
+}
+
+func (r fixedTwoFactorChallengeRepository) GetTwoFactorChallengeByHash(
+	ctx context.Context,
+	hash string,
+) (internal.TwoFactorChallenge, error) {
+	return r.challenge, nil
+}
+
+func (r fixedTwoFactorChallengeRepository) RecordTwoFactorChallengeAttempt(
+	ctx context.Context,
+	twoFactorChallengeID int64,
+) error {
+	return nil
+}
+
+func (r fixedTwoFactorChallengeRepository) ConsumeTwoFactorChallenge(
+	ctx context.Context,
+	twoFactorChallengeID int64,
+	usedAt time.Time,
+) error {
+	return nil
+}
+
+type fixedRecoveryCodeRepository struct {
+	hash string
+}
+
+func (r fixedRecoveryCodeRepository) ConsumeRecoveryCode(
+	ctx context.Context,
+	userID int64,
+	hash string,
+	usedAt time.Time,
+) error {
+	if hash != r.hash {
+		return internal.ErrTwoFactorCodeInvalid
+	}
+
+	return nil
+}
+
+func TestTwoFactorAuthenticatorLoginThrottle(t *testing.T) {
+	type step struct {
+		action  string
+		wantErr error
+	}
+
+	tests := []struct {
+		name  string
+		steps []step
+	}{
+		{
+			name: "failed codes lock account",
+			steps: []step{
+				{action: "password"},
+				{action: "wrong", wantErr: internal.ErrTwoFactorCodeInvalid},
+				{action: "wrong", wantErr: internal.ErrTwoFactorCodeInvalid},
+				{action: "wrong", wantErr: internal.ErrTwoFactorCodeInvalid},
+				{action: "right", wantErr: internal.ErrAccountLocked},
+				{action: "password", wantErr: internal.ErrAccountLocked},
+			},
+		},
+		{
+			name: "password does not reset failed codes",
+			steps: []step{
+				{action: "password"},
+				{action: "wrong", wantErr: internal.ErrTwoFactorCodeInvalid},
+				{action: "wrong", wantErr: internal.ErrTwoFactorCodeInvalid},
+				{action: "password"},
+				{action: "wrong", wantErr: internal.ErrTwoFactorCodeInvalid},
+				{action: "right", wantErr: internal.ErrAccountLocked},
+			},
+		},
+		{
+			name: "valid code resets failed codes",
+			steps: []step{
+				{action: "password"},
+				{action: "wrong", wantErr: internal.ErrTwoFactorCodeInvalid},
+				{action: "wrong", wantErr: internal.ErrTwoFactorCodeInvalid},
+				{action: "right"},
+				{action: "password"},
+				{action: "wrong", wantErr: internal.ErrTwoFactorCodeInvalid},
+				{action: "wrong", wantErr: internal.ErrTwoFactorCodeInvalid},
+				{action: "right"},
+			},
+		},
+	}
+
+	now := time.Date(2023, time.May, 4, 7, 31, 44, 0, time.UTC)
+	user := internal.User{
+		ID:                 1,
+		Username:           "jane",
+		TwoFactorEnabledAt: now.Add(-time.Hour),
+	}
+	users := fixedUserRepository{user: user}
+	challenges := fixedTwoFactorChallengeRepository{
+		challenge: internal.TwoFactorChallenge{
+			ID:        1,
+			UserID:    user.ID,
+			ExpiresAt: now.Add(time.Minute),
+		},
+	}
+	recoveryCodes := fixedRecoveryCodeRepository{hash: internal.HashToken("abcdefghij")}
+	codes := map[string]string{
+		"wrong": "0123456789",
+		"right": "abcde-fghij",
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			timer := fixedTimer{now: now}
+			throttles := &memoryLoginThrottleRepository{throttles: map[internal.LoginThrottleKey]internal.LoginThrottle{}}
+			userAuthenticator := internal.NewThrottledUserAuthenticator(
+				timer,
+				internal.LoginPolicy{
+					MaxUsernameFailures: 3,
+					FailureWindow:       time.Hour,
+					LockoutDuration:     time.Hour,
+				},
+				users,
+				throttles,
+				throttles,
+				throttles,
+				throttles,
+				throttles,
+			)
+			twoFactorAuthenticator := internal.NewTwoFactorAuthenticator(
+				timer,
+				10,
+				nil,
+				challenges,
+				challenges,
+				challenges,
+				users,
+				nil,
+				nil,
+				recoveryCodes,
+				userAuthenticator,
+			)
+
+			for i, each := range tt.steps {
+				var err error
+
+				if each.action == "password" {
+					_, err = userAuthenticator.AuthenticateUser(ctx, internal.UserAuthenticationRequest{
+						Username: "Jane",
+						Password: "password",
+					})
+				} else {
+					_, err = twoFactorAuthenticator.AuthenticateTwoFactor(ctx, internal.TwoFactorAuthenticationRequest{
+						ChallengeToken: "challenge",
+						Code:           codes[each.action],
+					})
+				}
+
+				if each.wantErr == nil && err != nil {
+					t.Fatalf("step %d (%s) error = %v, want nil", i, each.action, err)
+				}
+
+				if each.wantErr != nil && !errors.Is(err, each.wantErr) {
+					t.Fatalf("step %d (%s) error = %v, want %v", i, each.action, err, each.wantErr)
+				}
+			}
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log"
 	"time"
 
 	"github.com/go-playground/validator/v10"
@@ -12,8 +13,39 @@ import (
 var (
 	ErrUserNotFound    = errors.New("user not found")
 	ErrUnauthenticated = errors.New("user/password is not correct")
+	ErrUserDisabled    = errors.New("user is disabled")
 )
 
+type Role string
+
+const RoleAdmin Role = "admin"
+
+type Permission string
+
+const (
+	PermissionUsersRead  Permission = "users:read"
+	PermissionUsersWrite Permission = "users:write"
+	PermissionCacheRead  Permission = "cache:read"
+)
+
+var rolePermissions = map[Role][]Permission{
+	RoleAdmin: {
+		PermissionUsersRead,
+		PermissionUsersWrite,
+		PermissionCacheRead,
+	},
+}
+
+func (r Role) HasPermission(permission Permission) bool {
+	for _, each := range rolePermissions[r] {
+		if each == permission {
+			return true
+		}
+	}
+
+	return false
+}
+
 type UserAuthenticator interface {
 	AuthenticateUser(ctx context.Context, req UserAuthenticationRequest) (User, error)
 }
@@ -26,33 +58,132 @@ type UserGetterByUsername interface {
 	GetUserByUsername(ctx context.Context, username string) (User, error)
 }
 
+type UserGetterByID interface {
+	GetUserByID(ctx context.Context, userID int64) (User, error)
+}
+
+type UserGetterByEmail interface {
+	GetUserByEmail(ctx context.Context, email string) (User, error)
+}
+
+type UserProfileUpdater interface {
+	UpdateUserProfile(ctx context.Context, req UserProfileUpdateRequest) (User, error)
+}
+
+type UsersLister interface {
+	ListUsers(ctx context.Context) ([]User, error)
+}
+
+type UserDisabler interface {
+	DisableUser(ctx context.Context, req UserDisableRequest) (User, error)
+}
+
+type UserRoleGranter interface {
+	GrantUserRole(ctx context.Context, req UserRoleGrantRequest) (User, error)
+}
+
+type UserRoleStorer interface {
+	StoreUserRole(ctx context.Context, userID int64, role Role) error
+}
+
 type UserStorer interface {
 	StoreUser(ctx context.Context, user *User) error
 }
 
+type UserProfileStorer interface {
+	StoreUserProfile(ctx context.Context, user *User) error
+}
+
+type UserPasswordUpdater interface {
+	UpdateUserPassword(ctx context.Context, userID int64, password string, updatedAt time.Time) error
+}
+
+type UserDisabledAtUpdater interface {
+	UpdateUserDisabledAt(ctx context.Context, userID int64, disabledAt time.Time, updatedAt time.Time) error
+}
+
+type UserTokenVersionIncrementer interface {
+	IncrementUserTokenVersion(ctx context.Context, userID int64) error
+}
+
 type UserAuthenticationRequest struct {
 	Username string
 	Password string
+	IP       string
 }
 
 type UserRegistrationRequest struct {
 	UserAuthenticationRequest
 	PasswordConfirmation string
+	Email                string
+}
+
+type UserProfileUpdateRequest struct {
+	UserID             int64
+	Email              *string
+	DisplayName        *string
+	PreferredLocations *[]string
+	PreferredJobType   *string
+	Timezone           *string
+}
+
+type UserRoleGrantRequest struct {
+	Username string
+	Role     Role
+}
+
+type UserDisableRequest struct {
+	ActorID  int64
+	UserID   int64
+	Disabled bool
 }
 
 type User struct {
-	ID        int64
-	Username  string
-	Password  string
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	ID                 int64
+	Username           string
+	Password           string
+	Email              string
+	EmailVerifiedAt    time.Time
+	DisplayName        string
+	PreferredLocations []string
+	PreferredJobType   string
+	Timezone           string
+	TwoFactorEnabledAt time.Time
+	TokenVersion       int64
+	Roles              []Role
+	DisabledAt         time.Time
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+}
+
+func (u User) EmailVerified() bool {
+	return len(u.Email) > 0 && !u.EmailVerifiedAt.IsZero()
+}
+
+func (u User) TwoFactorEnabled() bool {
+	return !u.TwoFactorEnabledAt.IsZero()
+}
+
+func (u User) Disabled() bool {
+	return !u.DisabledAt.IsZero()
+}
+
+func (u User) HasPermission(permission Permission) bool {
+	for _, each := range u.Roles {
+		if each.HasPermission(permission) {
+			return true
+		}
+	}
+
+	return false
 }
 
 type userRegistrator struct {
-	validator  Validator[UserRegistrationRequest]
-	timer      Timer
-	hasher     Hasher
-	userStorer UserStorer
+	validator                      Validator[UserRegistrationRequest]
+	timer                          Timer
+	hasher                         Hasher
+	userStorer                     UserStorer
+	userEmailVerificationRequester UserEmailVerificationRequester
 }
 
 func (ur userRegistrator) RegisterUser(ctx context.Context, req UserRegistrationRequest) (User, error) {
@@ -70,6 +201,7 @@ func (ur userRegistrator) RegisterUser(ctx context.Context, req UserRegistration
 	user := User{
 		Username:  req.Username,
 		Password:  req.Password,
+		Email:     req.Email,
 		CreatedAt: now,
 		UpdatedAt: now,
 	}
@@ -78,6 +210,13 @@ func (ur userRegistrator) RegisterUser(ctx context.Context, req UserRegistration
 		return User{}, fmt.Errorf("storing user: %w", err)
 	}
 
+	if len(user.Email) > 0 {
+		err = ur.userEmailVerificationRequester.RequestUserEmailVerification(ctx, user.ID)
+		if err != nil {
+			log.Printf("requesting email verification for user %d: %s\n", user.ID, err)
+		}
+	}
+
 	return user, nil
 }
 
@@ -86,17 +225,194 @@ func NewUserRegistrator(
 	timer Timer,
 	hasher Hasher,
 	userStorer UserStorer,
+	userEmailVerificationRequester UserEmailVerificationRequester,
 ) *userRegistrator {
 	return &userRegistrator{
-		validator:  validator,
-		timer:      timer,
-		hasher:     hasher,
-		userStorer: userStorer,
+		validator:                      validator,
+		timer:                          timer,
+		hasher:                         hasher,
+		userStorer:                     userStorer,
+		userEmailVerificationRequester: userEmailVerificationRequester,
+	}
+}
+
+type userProfileUpdater struct {
+	validator                      Validator[UserProfileUpdateRequest]
+	timer                          Timer
+	userGetterByID                 UserGetterByID
+	userProfileStorer              UserProfileStorer
+	userEmailVerificationRequester UserEmailVerificationRequester
+}
+
+func (up userProfileUpdater) UpdateUserProfile(ctx context.Context, req UserProfileUpdateRequest) (User, error) {
+	err := up.validator.Validate(ctx, req)
+	if err != nil {
+		return User{}, fmt.Errorf("validating user profile update request: %w", err)
+	}
+
+	user, err := up.userGetterByID.GetUserByID(ctx, req.UserID)
+	if err != nil {
+		return User{}, fmt.Errorf("getting user by id: %w", err)
+	}
+
+	emailChanged := req.Email != nil && *req.Email != user.Email
+
+	if emailChanged {
+		user.Email = *req.Email
+		user.EmailVerifiedAt = time.Time{}
+	}
+
+	if req.DisplayName != nil {
+		user.DisplayName = *req.DisplayName
+	}
+
+	if req.PreferredLocations != nil {
+		user.PreferredLocations = *req.PreferredLocations
+	}
+
+	if req.PreferredJobType != nil {
+		user.PreferredJobType = *req.PreferredJobType
+	}
+
+	if req.Timezone != nil {
+		user.Timezone = *req.Timezone
+	}
+
+	user.UpdatedAt = up.timer.Now()
+
+	err = up.userProfileStorer.StoreUserProfile(ctx, &user)
+	if err != nil {
+		return User{}, fmt.Errorf("storing user profile: %w", err)
+	}
+
+	if emailChanged && len(user.Email) > 0 {
+		err = up.userEmailVerificationRequester.RequestUserEmailVerification(ctx, user.ID)
+		if err != nil {
+			log.Printf("requesting email verification for user %d: %s\n", user.ID, err)
+		}
+	}
+
+	return user, nil
+}
+
+func NewUserProfileUpdater(
+	validator Validator[UserProfileUpdateRequest],
+	timer Timer,
+	userGetterByID UserGetterByID,
+	userProfileStorer UserProfileStorer,
+	userEmailVerificationRequester UserEmailVerificationRequester,
+) *userProfileUpdater {
+	return &userProfileUpdater{
+		validator:                      validator,
+		timer:                          timer,
+		userGetterByID:                 userGetterByID,
+		userProfileStorer:              userProfileStorer,
+		userEmailVerificationRequester: userEmailVerificationRequester,
+	}
+}
+
+type userDisabler struct {
+	timer                 Timer
+	userGetterByID        UserGetterByID
+	userDisabledAtUpdater UserDisabledAtUpdater
+	userSessionsRevoker   UserSessionsRevoker
+}
+
+func (ud userDisabler) DisableUser(ctx context.Context, req UserDisableRequest) (User, error) {
+	if req.Disabled && req.ActorID == req.UserID {
+		return User{}, NewValidationError("user_id", "ne=self")
+	}
+
+	user, err := ud.userGetterByID.GetUserByID(ctx, req.UserID)
+	if err != nil {
+		return User{}, fmt.Errorf("getting user by id: %w", err)
+	}
+
+	if user.Disabled() == req.Disabled {
+		return user, nil
+	}
+
+	now := ud.timer.Now()
+
+	user.DisabledAt = time.Time{}
+	user.UpdatedAt = now
+
+	if req.Disabled {
+		user.DisabledAt = now
+	}
+
+	err = ud.userDisabledAtUpdater.UpdateUserDisabledAt(ctx, user.ID, user.DisabledAt, user.UpdatedAt)
+	if err != nil {
+		return User{}, fmt.Errorf("updating user disabled at: %w", err)
+	}
+
+	if req.Disabled {
+		err = ud.userSessionsRevoker.RevokeUserSessions(ctx, user.ID)
+		if err != nil {
+			return User{}, fmt.Errorf("revoking user sessions: %w", err)
+		}
+
+		user.TokenVersion++
+	}
+
+	return user, nil
+}
+
+func NewUserDisabler(
+	timer Timer,
+	userGetterByID UserGetterByID,
+	userDisabledAtUpdater UserDisabledAtUpdater,
+	userSessionsRevoker UserSessionsRevoker,
+) *userDisabler {
+	return &userDisabler{
+		timer:                 timer,
+		userGetterByID:        userGetterByID,
+		userDisabledAtUpdater: userDisabledAtUpdater,
+		userSessionsRevoker:   userSessionsRevoker,
+	}
+}
+
+type userRoleGranter struct {
+	userGetterByUsername UserGetterByUsername
+	userRoleStorer       UserRoleStorer
+}
+
+func (ug userRoleGranter) GrantUserRole(ctx context.Context, req UserRoleGrantRequest) (User, error) {
+	if _, ok := rolePermissions[req.Role]; !ok {
+		return User{}, NewValidationError("role", "oneof")
+	}
+
+	user, err := ug.userGetterByUsername.GetUserByUsername(ctx, req.Username)
+	if err != nil {
+		return User{}, fmt.Errorf("getting user by username: %w", err)
+	}
+
+	for _, each := range user.Roles {
+		if each == req.Role {
+			return user, nil
+		}
+	}
+
+	err = ug.userRoleStorer.StoreUserRole(ctx, user.ID, req.Role)
+	if err != nil {
+		return User{}, fmt.Errorf("storing user role: %w", err)
+	}
+
+	user.Roles = append(user.Roles, req.Role)
+
+	return user, nil
+}
+
+func NewUserRoleGranter(userGetterByUsername UserGetterByUsername, userRoleStorer UserRoleStorer) *userRoleGranter {
+	return &userRoleGranter{
+		userGetterByUsername: userGetterByUsername,
+		userRoleStorer:       userRoleStorer,
 	}
 }
 
 type userRegistrationRequestValidator struct {
-	validate *validator.Validate
+	validate      *validator.Validate
+	emailRequired bool
 }
 
 func (v userRegistrationRequestValidator) EvaluateErrorAs(err, target error) error {
@@ -108,37 +424,66 @@ func (v userRegistrationRequestValidator) EvaluateErrorAs(err, target error) err
 }
 
 func (v userRegistrationRequestValidator) Validate(ctx context.Context, req UserRegistrationRequest) error {
-	err := v.validate.VarCtx(ctx, req.Username, "required")
+	validationErrors := ValidationErrors{}
+
+	err := validationErrors.Collect(v.validateUsername(ctx, req.Username))
+	if err != nil {
+		return err
+	}
+
+	err = validationErrors.Collect(v.validatePassword(ctx, req.Password, req.PasswordConfirmation))
+	if err != nil {
+		return err
+	}
+
+	err = validationErrors.Collect(v.validateEmail(ctx, req.Email))
+	if err != nil {
+		return err
+	}
+
+	return validationErrors.Err()
+}
+
+func (v userRegistrationRequestValidator) validateUsername(ctx context.Context, username string) error {
+	err := v.validate.VarCtx(ctx, username, "required")
 	if err != nil {
 		return v.EvaluateErrorAs(err, NewValidationError("username", "required"))
 	}
 
-	err = v.validate.VarCtx(ctx, req.Username, "min=3")
+	err = v.validate.VarCtx(ctx, username, "min=3")
 	if err != nil {
 		return v.EvaluateErrorAs(err, NewValidationError("username", "min=3"))
 	}
 
-	err = v.validate.VarCtx(ctx, req.Username, "max=15")
+	err = v.validate.VarCtx(ctx, username, "max=15")
 	if err != nil {
 		return v.EvaluateErrorAs(err, NewValidationError("username", "max=15"))
 	}
 
-	err = v.validate.VarCtx(ctx, req.Username, "alphanum")
+	err = v.validate.VarCtx(ctx, username, "alphanum")
 	if err != nil {
 		return v.EvaluateErrorAs(err, NewValidationError("username", "alphanum"))
 	}
 
-	err = v.validate.VarCtx(ctx, req.Password, "required")
+	return nil
+}
+
+func (v userRegistrationRequestValidator) validatePassword(
+	ctx context.Context,
+	password string,
+	passwordConfirmation string,
+) error {
+	err := v.validate.VarCtx(ctx, password, "required")
 	if err != nil {
 		return v.EvaluateErrorAs(err, NewValidationError("password", "required"))
 	}
 
-	err = v.validate.VarCtx(ctx, req.Password, "min=6")
+	err = v.validate.VarCtx(ctx, password, "min=6")
 	if err != nil {
 		return v.EvaluateErrorAs(err, NewValidationError("password", "min=6"))
 	}
 
-	err = v.validate.VarWithValueCtx(ctx, req.Password, req.PasswordConfirmation, "eqfield")
+	err = v.validate.VarWithValueCtx(ctx, password, passwordConfirmation, "eqfield")
 	if err != nil {
 		return v.EvaluateErrorAs(err, NewValidationError("password", "eqfield=password_confirmation"))
 	}
@@ -146,9 +491,38 @@ func (v userRegistrationRequestValidator) Validate(ctx context.Context, req User
 	return nil
 }
 
-func NewUserRegistrationRequestValidator(validate *validator.Validate) *userRegistrationRequestValidator {
+func (v userRegistrationRequestValidator) validateEmail(ctx context.Context, email string) error {
+	if v.emailRequired {
+		err := v.validate.VarCtx(ctx, email, "required")
+		if err != nil {
+			return v.EvaluateErrorAs(err, NewValidationError("email", "required"))
+		}
+	}
+
+	if len(email) == 0 {
+		return nil
+	}
+
+	err := v.validate.VarCtx(ctx, email, "email")
+	if err != nil {
+		return v.EvaluateErrorAs(err, NewValidationError("email", "email"))
+	}
+
+	err = v.validate.VarCtx(ctx, email, "max=255")
+	if err != nil {
+		return v.EvaluateErrorAs(err, NewValidationError("email", "max=255"))
+	}
+
+	return nil
+}
+
+func NewUserRegistrationRequestValidator(
+	validate *validator.Validate,
+	emailRequired bool,
+) *userRegistrationRequestValidator {
 	return &userRegistrationRequestValidator{
-		validate: validate,
+		validate:      validate,
+		emailRequired: emailRequired,
 	}
 }
 
@@ -177,10 +551,227 @@ func NewUsernameUniquenessValidator(userGetterByUsername UserGetterByUsername) *
 	}
 }
 
+type userProfileUpdateRequestValidator struct {
+	validate      *validator.Validate
+	emailRequired bool
+}
+
+func (v userProfileUpdateRequestValidator) EvaluateErrorAs(err, target error) error {
+	if errors.As(err, &validator.ValidationErrors{}) {
+		err = target
+	}
+
+	return err
+}
+
+func (v userProfileUpdateRequestValidator) Validate(ctx context.Context, req UserProfileUpdateRequest) error {
+	validationErrors := ValidationErrors{}
+
+	err := validationErrors.Collect(v.validateEmail(ctx, req.Email))
+	if err != nil {
+		return err
+	}
+
+	err = validationErrors.Collect(v.validateDisplayName(ctx, req.DisplayName))
+	if err != nil {
+		return err
+	}
+
+	err = validationErrors.Collect(v.validatePreferredLocations(ctx, req.PreferredLocations))
+	if err != nil {
+		return err
+	}
+
+	err = validationErrors.Collect(v.validatePreferredJobType(ctx, req.PreferredJobType))
+	if err != nil {
+		return err
+	}
+
+	err = validationErrors.Collect(v.validateTimezone(ctx, req.Timezone))
+	if err != nil {
+		return err
+	}
+
+	return validationErrors.Err()
+}
+
+func (v userProfileUpdateRequestValidator) validateEmail(ctx context.Context, email *string) error {
+	if email == nil {
+		return nil
+	}
+
+	if v.emailRequired {
+		err := v.validate.VarCtx(ctx, *email, "required")
+		if err != nil {
+			return v.EvaluateErrorAs(err, NewValidationError("email", "required"))
+		}
+	}
+
+	if len(*email) == 0 {
+		return nil
+	}
+
+	err := v.validate.VarCtx(ctx, *email, "email")
+	if err != nil {
+		return v.EvaluateErrorAs(err, NewValidationError("email", "email"))
+	}
+
+	err = v.validate.VarCtx(ctx, *email, "max=255")
+	if err != nil {
+		return v.EvaluateErrorAs(err, NewValidationError("email", "max=255"))
+	}
+
+	return nil
+}
+
+func (v userProfileUpdateRequestValidator) validateDisplayName(ctx context.Context, displayName *string) error {
+	if displayName == nil {
+		return nil
+	}
+
+	err := v.validate.VarCtx(ctx, *displayName, "max=100")
+	if err != nil {
+		return v.EvaluateErrorAs(err, NewValidationError("display_name", "max=100"))
+	}
+
+	return nil
+}
+
+func (v userProfileUpdateRequestValidator) validatePreferredLocations(
+	ctx context.Context,
+	preferredLocations *[]string,
+) error {
+	if preferredLocations == nil {
+		return nil
+	}
+
+	err := v.validate.VarCtx(ctx, *preferredLocations, "max=10")
+	if err != nil {
+		return v.EvaluateErrorAs(err, NewValidationError("preferred_locations", "max=10"))
+	}
+
+	for _, each := range *preferredLocations {
+		err = v.validate.VarCtx(ctx, each, "required")
+		if err != nil {
+			return v.EvaluateErrorAs(err, NewValidationError("preferred_locations", "dive,required"))
+		}
+
+		err = v.validate.VarCtx(ctx, each, "max=100")
+		if err != nil {
+			return v.EvaluateErrorAs(err, NewValidationError("preferred_locations", "dive,max=100"))
+		}
+	}
+
+	return nil
+}
+
+func (v userProfileUpdateRequestValidator) validatePreferredJobType(ctx context.Context, preferredJobType *string) error {
+	if preferredJobType == nil || len(*preferredJobType) == 0 {
+		return nil
+	}
+
+	err := v.validate.VarCtx(ctx, *preferredJobType, "oneof=full_time part_time contract internship")
+	if err != nil {
+		return v.EvaluateErrorAs(err, NewValidationError(
+			"preferred_job_type",
+			"oneof=full_time part_time contract internship",
+		))
+	}
+
+	return nil
+}
+
+func (v userProfileUpdateRequestValidator) validateTimezone(ctx context.Context, timezone *string) error {
+	if timezone == nil || len(*timezone) == 0 {
+		return nil
+	}
+
+	err := v.validate.VarCtx(ctx, *timezone, "timezone")
+	if err != nil {
+		return v.EvaluateErrorAs(err, NewValidationError("timezone", "timezone"))
+	}
+
+	return nil
+}
+
+func NewUserProfileUpdateRequestValidator(
+	validate *validator.Validate,
+	emailRequired bool,
+) *userProfileUpdateRequestValidator {
+	return &userProfileUpdateRequestValidator{
+		validate:      validate,
+		emailRequired: emailRequired,
+	}
+}
+
+type registrationEmailUniquenessValidator struct {
+	userGetterByEmail UserGetterByEmail
+}
+
+func (v registrationEmailUniquenessValidator) Validate(ctx context.Context, req UserRegistrationRequest) error {
+	if len(req.Email) == 0 {
+		return nil
+	}
+
+	user, err := v.userGetterByEmail.GetUserByEmail(ctx, req.Email)
+	if err != nil {
+		if !errors.Is(err, ErrUserNotFound) {
+			return fmt.Errorf("getting user by email: %w", err)
+		}
+	}
+
+	if user.ID > 0 {
+		return NewValidationError("email", "unique")
+	}
+
+	return nil
+}
+
+func NewRegistrationEmailUniquenessValidator(
+	userGetterByEmail UserGetterByEmail,
+) *registrationEmailUniquenessValidator {
+	return &registrationEmailUniquenessValidator{
+		userGetterByEmail: userGetterByEmail,
+	}
+}
+
+type emailUniquenessValidator struct {
+	userGetterByEmail UserGetterByEmail
+}
+
+func (v emailUniquenessValidator) Validate(ctx context.Context, req UserProfileUpdateRequest) error {
+	if req.Email == nil || len(*req.Email) == 0 {
+		return nil
+	}
+
+	user, err := v.userGetterByEmail.GetUserByEmail(ctx, *req.Email)
+	if err != nil {
+		if !errors.Is(err, ErrUserNotFound) {
+			return fmt.Errorf("getting user by email: %w", err)
+		}
+	}
+
+	if user.ID > 0 && user.ID != req.UserID {
+		return NewValidationError("email", "unique")
+	}
+
+	return nil
+}
+
+func NewEmailUniquenessValidator(userGetterByEmail UserGetterByEmail) *emailUniquenessValidator {
+	return &emailUniquenessValidator{
+		userGetterByEmail: userGetterByEmail,
+	}
+}
+
 type userAuthenticator struct {
 	validator            Validator[UserAuthenticationRequest]
+	timer                Timer
 	userGetterByUsername UserGetterByUsername
 	comparator           Comparator
+	hasher               Hasher
+	rehashChecker        RehashChecker
+	userPasswordUpdater  UserPasswordUpdater
 }
 
 func (ua userAuthenticator) AuthenticateUser(ctx context.Context, req UserAuthenticationRequest) (User, error) {
@@ -211,18 +802,56 @@ func (ua userAuthenticator) AuthenticateUser(ctx context.Context, req UserAuthen
 		return User{}, err
 	}
 
+	if user.Disabled() {
+		return User{}, ErrUserDisabled
+	}
+
+	if ua.rehashChecker.NeedsRehash(user.Password) {
+		err = ua.rehash(ctx, &user, req.Password)
+		if err != nil {
+			log.Printf("rehashing password of user %d: %s\n", user.ID, err)
+		}
+	}
+
 	return user, nil
 }
 
+func (ua userAuthenticator) rehash(ctx context.Context, user *User, plain string) error {
+	hashed, err := ua.hasher.Hash(plain)
+	if err != nil {
+		return fmt.Errorf("hashing plain user password: %w", err)
+	}
+
+	now := ua.timer.Now()
+
+	err = ua.userPasswordUpdater.UpdateUserPassword(ctx, user.ID, hashed, now)
+	if err != nil {
+		return fmt.Errorf("updating user password: %w", err)
+	}
+
+	user.Password = hashed
+	user.UpdatedAt = now
+
+	return nil
+}
+
 func NewUserAuthenticator(
 	validator Validator[UserAuthenticationRequest],
+	timer Timer,
 	userGetterByUsername UserGetterByUsername,
 	comparator Comparator,
+	hasher Hasher,
+	rehashChecker RehashChecker,
+	userPasswordUpdater UserPasswordUpdater,
 ) *userAuthenticator {
 	return &userAuthenticator{
 		validator:            validator,
+		timer:                timer,
 		userGetterByUsername: userGetterByUsername,
 		comparator:           comparator,
+		hasher:               hasher,
+		rehashChecker:        rehashChecker,
+		userPasswordUpdater:  userPasswordUpdater,
 	}
 }
 
@@ -239,17 +868,25 @@ func (v userAuthenticationRequestValidator) EvaluateErrorAs(err, target error) e
 }
 
 func (v userAuthenticationRequestValidator) Validate(ctx context.Context, req UserAuthenticationRequest) error {
+	validationErrors := ValidationErrors{}
+
 	err := v.validate.VarCtx(ctx, req.Username, "required")
 	if err != nil {
-		return v.EvaluateErrorAs(err, NewValidationError("username", "required"))
+		err = validationErrors.Collect(v.EvaluateErrorAs(err, NewValidationError("username", "required")))
+		if err != nil {
+			return err
+		}
 	}
 
 	err = v.validate.VarCtx(ctx, req.Password, "required")
 	if err != nil {
-		return v.EvaluateErrorAs(err, NewValidationError("password", "required"))
+		err = validationErrors.Collect(v.EvaluateErrorAs(err, NewValidationError("password", "required")))
+		if err != nil {
+			return err
+		}
 	}
 
-	return nil
+	return validationErrors.Err()
 }
 
 func NewUserAuthenticationRequestValidator(validate *validator.Validate) *userAuthenticationRequestValidator {
@@ -3,14 +3,32 @@ package internal
 import (
 	"bytes"
 	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
+	"golang.org/x/crypto/argon2"
 	"golang.org/x/crypto/bcrypt"
 )
 
-var ErrHashMismatched = errors.New("hash mismatched")
+var (
+	ErrHashMismatched    = errors.New("hash mismatched")
+	ErrHashUnrecognized  = errors.New("hash algorithm is not recognized")
+	ErrCiphertextInvalid = errors.New("ciphertext is invalid")
+)
+
+const (
+	argon2idMinSaltLength = 8
+	argon2idMinKeyLength  = 16
+)
 
 type Option[T OptionConstraint] func(opt *T)
 
@@ -26,10 +44,30 @@ type Comparator interface {
 	Compare(hashed, plain string) error
 }
 
+type RehashChecker interface {
+	NeedsRehash(hashed string) bool
+}
+
+type PasswordHasher interface {
+	Hasher
+	Comparator
+	RehashChecker
+	Recognizes(hashed string) bool
+}
+
+type Cipher interface {
+	Encrypt(plain string) (string, error)
+	Decrypt(ciphertext string) (string, error)
+}
+
 type Timer interface {
 	Now() time.Time
 }
 
+type TokenGenerator interface {
+	GenerateToken() (string, error)
+}
+
 type Validator[T any] interface {
 	Validate(ctx context.Context, val T) error
 }
@@ -57,6 +95,17 @@ func (ve ValidationError) Error() string {
 	return fmt.Sprintf("%s field validation failed at %s tag", ve.field, ve.tag)
 }
 
+func (ve ValidationError) As(target any) bool {
+	validationErrors, ok := target.(*ValidationErrors)
+	if !ok {
+		return false
+	}
+
+	*validationErrors = ValidationErrors{ve}
+
+	return true
+}
+
 func NewValidationError(field, tag string) ValidationError {
 	return ValidationError{
 		field: field,
@@ -64,6 +113,49 @@ func NewValidationError(field, tag string) ValidationError {
 	}
 }
 
+type ValidationErrors []ValidationError
+
+func (ves ValidationErrors) Error() string {
+	messages := []string{}
+
+	for _, each := range ves {
+		messages = append(messages, each.Error())
+	}
+
+	return strings.Join(messages, ", ")
+}
+
+func (ves ValidationErrors) As(target any) bool {
+	validationError, ok := target.(*ValidationError)
+	if !ok || len(ves) == 0 {
+		return false
+	}
+
+	*validationError = ves[0]
+
+	return true
+}
+
+func (ves *ValidationErrors) Collect(err error) error {
+	var validationErrors ValidationErrors
+
+	if errors.As(err, &validationErrors) {
+		*ves = append(*ves, validationErrors...)
+
+		return nil
+	}
+
+	return err
+}
+
+func (ves ValidationErrors) Err() error {
+	if len(ves) == 0 {
+		return nil
+	}
+
+	return ves
+}
+
 type bcryptHasher struct {
 	cost int
 }
@@ -93,12 +185,262 @@ func (h bcryptHasher) Compare(hashed, plain string) error {
 	return nil
 }
 
+func (h bcryptHasher) Recognizes(hashed string) bool {
+	return strings.HasPrefix(hashed, "$2a$") ||
+		strings.HasPrefix(hashed, "$2b$") ||
+		strings.HasPrefix(hashed, "$2y$")
+}
+
+func (h bcryptHasher) NeedsRehash(hashed string) bool {
+	cost, err := bcrypt.Cost(bytes.NewBufferString(hashed).Bytes())
+	if err != nil {
+		return true
+	}
+
+	return cost < h.cost
+}
+
 func NewBcryptHasher(cost int) *bcryptHasher {
 	return &bcryptHasher{
 		cost: cost,
 	}
 }
 
+type argon2idParams struct {
+	memory      uint32
+	iterations  uint32
+	parallelism uint8
+	keyLength   uint32
+}
+
+type argon2idHasher struct {
+	params     argon2idParams
+	saltLength uint32
+}
+
+func (h argon2idHasher) Hash(plain string) (string, error) {
+	salt := make([]byte, h.saltLength)
+
+	_, err := rand.Read(salt)
+	if err != nil {
+		return "", fmt.Errorf("reading random salt: %w", err)
+	}
+
+	key := argon2.IDKey(
+		bytes.NewBufferString(plain).Bytes(),
+		salt,
+		h.params.iterations,
+		h.params.memory,
+		h.params.parallelism,
+		h.params.keyLength,
+	)
+
+	return fmt.Sprintf(
+		"$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version,
+		h.params.memory,
+		h.params.iterations,
+		h.params.parallelism,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+func (h argon2idHasher) Compare(hashed, plain string) error {
+	params, salt, key, err := h.decode(hashed)
+	if err != nil {
+		return fmt.Errorf("decoding argon2id hash: %w", err)
+	}
+
+	compared := argon2.IDKey(
+		bytes.NewBufferString(plain).Bytes(),
+		salt,
+		params.iterations,
+		params.memory,
+		params.parallelism,
+		params.keyLength,
+	)
+
+	if subtle.ConstantTimeCompare(key, compared) != 1 {
+		return fmt.Errorf("comparing argon2id hash with plain: %w", ErrHashMismatched)
+	}
+
+	return nil
+}
+
+func (h argon2idHasher) Recognizes(hashed string) bool {
+	return strings.HasPrefix(hashed, "$argon2id$")
+}
+
+func (h argon2idHasher) NeedsRehash(hashed string) bool {
+	params, salt, _, err := h.decode(hashed)
+	if err != nil {
+		return true
+	}
+
+	return params != h.params || uint32(len(salt)) < h.saltLength
+}
+
+func (h argon2idHasher) decode(hashed string) (argon2idParams, []byte, []byte, error) {
+	var params argon2idParams
+	var version int
+
+	comps := strings.Split(hashed, "$")
+
+	if len(comps) != 6 || comps[1] != "argon2id" {
+		return argon2idParams{}, nil, nil, ErrHashUnrecognized
+	}
+
+	_, err := fmt.Sscanf(comps[2], "v=%d", &version)
+	if err != nil {
+		return argon2idParams{}, nil, nil, fmt.Errorf("parsing version: %w", err)
+	}
+
+	if version != argon2.Version {
+		return argon2idParams{}, nil, nil, fmt.Errorf("%w: unsupported argon2 version %d", ErrHashUnrecognized, version)
+	}
+
+	_, err = fmt.Sscanf(comps[3], "m=%d,t=%d,p=%d", &params.memory, &params.iterations, &params.parallelism)
+	if err != nil {
+		return argon2idParams{}, nil, nil, fmt.Errorf("parsing parameters: %w", err)
+	}
+
+	if params.iterations == 0 || params.parallelism == 0 || params.memory < 8*uint32(params.parallelism) {
+		return argon2idParams{}, nil, nil, fmt.Errorf("%w: invalid argon2 parameters", ErrHashUnrecognized)
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(comps[4])
+	if err != nil {
+		return argon2idParams{}, nil, nil, fmt.Errorf("decoding salt: %w", err)
+	}
+
+	if len(salt) < argon2idMinSaltLength {
+		return argon2idParams{}, nil, nil, fmt.Errorf("%w: argon2 salt is too short", ErrHashUnrecognized)
+	}
+
+	key, err := base64.RawStdEncoding.DecodeString(comps[5])
+	if err != nil {
+		return argon2idParams{}, nil, nil, fmt.Errorf("decoding key: %w", err)
+	}
+
+	if len(key) < argon2idMinKeyLength {
+		return argon2idParams{}, nil, nil, fmt.Errorf("%w: argon2 key is too short", ErrHashUnrecognized)
+	}
+
+	params.keyLength = uint32(len(key))
+
+	return params, salt, key, nil
+}
+
+func NewArgon2idHasher(memory, iterations uint32, parallelism uint8, saltLength, keyLength uint32) *argon2idHasher {
+	return &argon2idHasher{
+		params: argon2idParams{
+			memory:      memory,
+			iterations:  iterations,
+			parallelism: parallelism,
+			keyLength:   keyLength,
+		},
+		saltLength: saltLength,
+	}
+}
+
+type multiAlgorithmHasher struct {
+	primary PasswordHasher
+	hashers []PasswordHasher
+}
+
+func (h multiAlgorithmHasher) Hash(plain string) (string, error) {
+	return h.primary.Hash(plain)
+}
+
+func (h multiAlgorithmHasher) Compare(hashed, plain string) error {
+	for _, hasher := range h.hashers {
+		if hasher.Recognizes(hashed) {
+			return hasher.Compare(hashed, plain)
+		}
+	}
+
+	return ErrHashUnrecognized
+}
+
+func (h multiAlgorithmHasher) Recognizes(hashed string) bool {
+	for _, hasher := range h.hashers {
+		if hasher.Recognizes(hashed) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (h multiAlgorithmHasher) NeedsRehash(hashed string) bool {
+	return !h.primary.Recognizes(hashed) || h.primary.NeedsRehash(hashed)
+}
+
+func NewMultiAlgorithmHasher(primary PasswordHasher, others ...PasswordHasher) *multiAlgorithmHasher {
+	return &multiAlgorithmHasher{
+		primary: primary,
+		hashers: append([]PasswordHasher{primary}, others...),
+	}
+}
+
+type aesGCMCipher struct {
+	aead cipher.AEAD
+}
+
+func (c aesGCMCipher) Encrypt(plain string) (string, error) {
+	nonce := make([]byte, c.aead.NonceSize())
+
+	_, err := rand.Read(nonce)
+	if err != nil {
+		return "", fmt.Errorf("reading random bytes: %w", err)
+	}
+
+	sealed := c.aead.Seal(nonce, nonce, bytes.NewBufferString(plain).Bytes(), nil)
+
+	return base64.RawStdEncoding.EncodeToString(sealed), nil
+}
+
+func (c aesGCMCipher) Decrypt(ciphertext string) (string, error) {
+	sealed, err := base64.RawStdEncoding.DecodeString(ciphertext)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrCiphertextInvalid, err)
+	}
+
+	if len(sealed) < c.aead.NonceSize() {
+		return "", fmt.Errorf("%w: ciphertext is too short", ErrCiphertextInvalid)
+	}
+
+	plain, err := c.aead.Open(nil, sealed[:c.aead.NonceSize()], sealed[c.aead.NonceSize():], nil)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrCiphertextInvalid, err)
+	}
+
+	return string(plain), nil
+}
+
+func NewAESGCMCipher(secret []byte) (*aesGCMCipher, error) {
+	if len(secret) == 0 {
+		return nil, errors.New("cipher secret is empty")
+	}
+
+	key := sha256.Sum256(secret)
+
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return nil, fmt.Errorf("initializing aes block cipher: %w", err)
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("initializing gcm mode: %w", err)
+	}
+
+	return &aesGCMCipher{
+		aead: aead,
+	}, nil
+}
+
 type timer struct{}
 
 func (timer) Now() time.Time {
@@ -109,19 +451,48 @@ func NewTimer() *timer {
 	return &timer{}
 }
 
+type randomTokenGenerator struct {
+	size int
+}
+
+func (g randomTokenGenerator) GenerateToken() (string, error) {
+	b := make([]byte, g.size)
+
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", fmt.Errorf("reading random bytes: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func NewRandomTokenGenerator(size int) *randomTokenGenerator {
+	return &randomTokenGenerator{
+		size: size,
+	}
+}
+
+func HashToken(token string) string {
+	sum := sha256.Sum256(bytes.NewBufferString(token).Bytes())
+
+	return hex.EncodeToString(sum[:])
+}
+
 type validationAggregator[T any] struct {
 	validators []Validator[T]
 }
 
 func (v validationAggregator[T]) Validate(ctx context.Context, val T) error {
+	validationErrors := ValidationErrors{}
+
 	for index, validator := range v.validators {
-		err := validator.Validate(ctx, val)
+		err := validationErrors.Collect(validator.Validate(ctx, val))
 		if err != nil {
 			return fmt.Errorf("calling validator number %d: %w", index, err)
 		}
 	}
 
-	return nil
+	return validationErrors.Err()
 }
 
 func NewValidationAggregator[T any](v1, v2 Validator[T], vn ...Validator[T]) *validationAggregator[T] {
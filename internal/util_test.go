@@ -0,0 +1,102 @@
+package internal_test
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/adystag/jobs-search/internal"
+)
+
+func TestArgon2idHasherCompareParameters(t *testing.T) {
+	hasher := internal.NewArgon2idHasher(64, 1, 1, 16, 32)
+
+	hashed, err := hasher.Hash("password")
+	if err != nil {
+		t.Fatalf("Hash() error = %v", err)
+	}
+
+	comps := strings.Split(hashed, "$")
+	encode := func(n int) string {
+		return base64.RawStdEncoding.EncodeToString(make([]byte, n))
+	}
+	replace := func(index int, value string) string {
+		replaced := append([]string{}, comps...)
+		replaced[index] = value
+
+		return strings.Join(replaced, "$")
+	}
+
+	tests := []struct {
+		name    string
+		hashed  string
+		wantErr error
+	}{
+		{
+			name:   "valid hash",
+			hashed: hashed,
+		},
+		{
+			name:    "zero memory",
+			hashed:  replace(3, "m=0,t=1,p=1"),
+			wantErr: internal.ErrHashUnrecognized,
+		},
+		{
+			name:    "memory below parallelism minimum",
+			hashed:  replace(3, "m=15,t=1,p=2"),
+			wantErr: internal.ErrHashUnrecognized,
+		},
+		{
+			name:    "zero iterations",
+			hashed:  replace(3, "m=64,t=0,p=1"),
+			wantErr: internal.ErrHashUnrecognized,
+		},
+		{
+			name:    "zero parallelism",
+			hashed:  replace(3, "m=64,t=1,p=0"),
+			wantErr: internal.ErrHashUnrecognized,
+		},
+		{
+			name:    "short salt",
+			hashed:  replace(4, encode(4)),
+			wantErr: internal.ErrHashUnrecognized,
+		},
+		{
+			name:    "empty key",
+			hashed:  replace(5, ""),
+			wantErr: internal.ErrHashUnrecognized,
+		},
+		{
+			name:    "short key",
+			hashed:  replace(5, encode(8)),
+			wantErr: internal.ErrHashUnrecognized,
+		},
+		{
+			name:    "wrong key",
+			hashed:  replace(5, encode(32)),
+			wantErr: internal.ErrHashMismatched,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := hasher.Compare(tt.hashed, "password")
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Fatalf("Compare() error = %v, want nil", err)
+				}
+
+				return
+			}
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Compare() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr == internal.ErrHashUnrecognized && !hasher.NeedsRehash(tt.hashed) {
+				t.Errorf("NeedsRehash() = false, want true")
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/adystag/jobs-search/internal"
+)
+
+type JobsSynchronizationWorker struct {
+	jobsSynchronizer internal.JobsSynchronizer
+	interval         time.Duration
+}
+
+func (w JobsSynchronizationWorker) Run(ctx context.Context) error {
+	ticker := time.NewTicker(w.interval)
+
+	defer ticker.Stop()
+
+	for {
+		synchronized, err := w.jobsSynchronizer.SynchronizeJobs(ctx)
+		if err != nil {
+			log.Printf("synchronizing jobs: %s\n", err)
+		} else {
+			log.Printf("synchronized %d jobs\n", synchronized)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func NewJobsSynchronizationWorker(
+	jobsSynchronizer internal.JobsSynchronizer,
+	interval time.Duration,
+) *JobsSynchronizationWorker {
+	return &JobsSynchronizationWorker{
+		jobsSynchronizer: jobsSynchronizer,
+		interval:         interval,
+	}
+}